@@ -0,0 +1,172 @@
+package client
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Smoothing factors for the envelope followers that split pushed levels into bands
+const (
+	bassSmoothing   = 0.08
+	midSmoothing    = 0.35
+	audioBandDecay  = 0.85
+	audioBandMaxRGB = 65535
+)
+
+// AudioBands holds the bass, mid and treble energy of the audio signal, each 0-1
+type AudioBands struct {
+	Bass   float64
+	Mid    float64
+	Treble float64
+}
+
+// audioAnalyzer derives frequency bands from a stream of amplitude samples.
+// Bass follows the slow envelope, mid the faster envelope above it and treble the
+// sample-to-sample transients, which is enough to drive lights without an FFT.
+type audioAnalyzer struct {
+	slow  float64
+	fast  float64
+	last  float64
+	bands AudioBands
+}
+
+// push feeds an amplitude sample (0-1) into the analyzer
+func (a *audioAnalyzer) push(level float64) {
+	level = clamp01(level)
+
+	a.slow += bassSmoothing * (level - a.slow)
+	a.fast += midSmoothing * (level - a.fast)
+
+	a.bands = AudioBands{
+		Bass:   clamp01(a.slow * 2),
+		Mid:    clamp01(math.Abs(a.fast-a.slow) * 4),
+		Treble: clamp01(math.Abs(level-a.last) * 4),
+	}
+	a.last = level
+}
+
+// decay fades the bands so lights fall back to dark when samples stop arriving
+func (a *audioAnalyzer) decay() {
+	a.bands.Bass *= audioBandDecay
+	a.bands.Mid *= audioBandDecay
+	a.bands.Treble *= audioBandDecay
+}
+
+// SetAudioReactive switches the streaming loop to render colors from pushed audio levels
+// on every frame instead of waiting for SendColors.
+func (e *EntertainmentStreamer) SetAudioReactive(enabled bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !enabled {
+		e.audio = nil
+		return
+	}
+	if e.audio == nil {
+		e.audio = &audioAnalyzer{}
+	}
+}
+
+// AudioReactive reports whether the streamer is in audio-reactive mode
+func (e *EntertainmentStreamer) AudioReactive() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.audio != nil
+}
+
+// PushAudioLevel feeds an amplitude sample (0-1) to the audio-reactive mode
+func (e *EntertainmentStreamer) PushAudioLevel(level float64) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.audio == nil {
+		return fmt.Errorf("audio-reactive mode is not enabled")
+	}
+
+	e.audio.push(level)
+	return nil
+}
+
+// PushAudioBands sets the band levels directly, for callers that run their own analysis
+func (e *EntertainmentStreamer) PushAudioBands(bands AudioBands) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.audio == nil {
+		return fmt.Errorf("audio-reactive mode is not enabled")
+	}
+
+	e.audio.bands = AudioBands{
+		Bass:   clamp01(bands.Bass),
+		Mid:    clamp01(bands.Mid),
+		Treble: clamp01(bands.Treble),
+	}
+	return nil
+}
+
+// audioFrame renders the current bands across the configuration's channels and decays them.
+// The caller must hold e.mu.
+func (e *EntertainmentStreamer) audioFrame() map[int]RGB {
+	channelIDs := make([]int, 0, len(e.config.Channels))
+	for _, channel := range e.config.Channels {
+		channelIDs = append(channelIDs, channel.ChannelID)
+	}
+
+	colors := AudioColors(e.audio.bands, channelIDs)
+	e.audio.decay()
+	return colors
+}
+
+// AudioColors maps audio bands onto channels. A single channel mixes all three bands
+// (bass red, mid green, treble blue); with more channels the bands are dealt out in
+// channel ID order so bass, mid and treble each light their own share of the room.
+func AudioColors(bands AudioBands, channelIDs []int) map[int]RGB {
+	result := make(map[int]RGB, len(channelIDs))
+	if len(channelIDs) == 0 {
+		return result
+	}
+
+	if len(channelIDs) == 1 {
+		result[channelIDs[0]] = RGB{
+			Red:   bandLevel(bands.Bass),
+			Green: bandLevel(bands.Mid),
+			Blue:  bandLevel(bands.Treble),
+		}
+		return result
+	}
+
+	sorted := append([]int(nil), channelIDs...)
+	sort.Ints(sorted)
+
+	for i, channelID := range sorted {
+		switch i % 3 {
+		case 0:
+			// Bass pulses warm red-orange
+			result[channelID] = RGB{Red: bandLevel(bands.Bass), Green: bandLevel(bands.Bass * 0.25)}
+		case 1:
+			// Mids glow green-cyan
+			result[channelID] = RGB{Green: bandLevel(bands.Mid), Blue: bandLevel(bands.Mid * 0.4)}
+		default:
+			// Treble flickers blue-violet
+			result[channelID] = RGB{Red: bandLevel(bands.Treble * 0.4), Blue: bandLevel(bands.Treble)}
+		}
+	}
+
+	return result
+}
+
+// bandLevel converts a 0-1 band level to a 16-bit color component
+func bandLevel(level float64) uint16 {
+	return uint16(math.Round(clamp01(level) * audioBandMaxRGB))
+}
+
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
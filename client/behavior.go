@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// BehaviorInstance represents a native automation configured on the bridge
+type BehaviorInstance struct {
+	ID            string                 `json:"id"`
+	Type          string                 `json:"type"`
+	ScriptID      string                 `json:"script_id"`
+	Enabled       bool                   `json:"enabled"`
+	Status        string                 `json:"status,omitempty"`
+	LastError     string                 `json:"last_error,omitempty"`
+	Metadata      BehaviorMetadata       `json:"metadata"`
+	Configuration map[string]interface{} `json:"configuration,omitempty"`
+	Dependees     []BehaviorDependee     `json:"dependees,omitempty"`
+}
+
+// BehaviorMetadata contains the user-facing name of a behavior
+type BehaviorMetadata struct {
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+}
+
+// BehaviorDependee is a resource a behavior instance depends on
+type BehaviorDependee struct {
+	Type   string             `json:"type"`
+	Target ResourceIdentifier `json:"target"`
+	Level  string             `json:"level"`
+}
+
+// BehaviorScript describes an automation template such as wake up or timers
+type BehaviorScript struct {
+	ID          string           `json:"id"`
+	Type        string           `json:"type"`
+	Description string           `json:"description"`
+	Version     string           `json:"version"`
+	Metadata    BehaviorMetadata `json:"metadata"`
+}
+
+// GetBehaviorInstances returns all native automations
+func (c *Client) GetBehaviorInstances(ctx context.Context) ([]BehaviorInstance, error) {
+	var response struct {
+		Errors []Error            `json:"errors"`
+		Data   []BehaviorInstance `json:"data"`
+	}
+	
+	err := c.getJSON(ctx, "/resource/behavior_instance", &response)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(response.Errors) > 0 {
+		return nil, apiError(response.Errors)
+	}
+	
+	return response.Data, nil
+}
+
+// GetBehaviorScripts returns the automation templates available on the bridge
+func (c *Client) GetBehaviorScripts(ctx context.Context) ([]BehaviorScript, error) {
+	var response struct {
+		Errors []Error          `json:"errors"`
+		Data   []BehaviorScript `json:"data"`
+	}
+	
+	err := c.getJSON(ctx, "/resource/behavior_script", &response)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(response.Errors) > 0 {
+		return nil, apiError(response.Errors)
+	}
+	
+	return response.Data, nil
+}
+
+// SetBehaviorInstanceEnabled enables or disables a native automation
+func (c *Client) SetBehaviorInstanceEnabled(ctx context.Context, id string, enabled bool) error {
+	update := map[string]interface{}{
+		"enabled": enabled,
+	}
+	_, err := c.put(ctx, fmt.Sprintf("/resource/behavior_instance/%s", id), update)
+	return err
+}
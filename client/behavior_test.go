@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const behaviorPayload = `{
+	"errors": [],
+	"data": [
+		{
+			"id": "b1",
+			"type": "behavior_instance",
+			"script_id": "ff8957e3-2eb9-4699-a0c8-ad2cb3ede704",
+			"enabled": true,
+			"status": "running",
+			"metadata": {"name": "Wake up"},
+			"configuration": {"when": {"time_point": {"type": "time"}}},
+			"dependees": [{"type": "ResourceDependee", "target": {"rid": "room-1", "rtype": "room"}, "level": "critical"}]
+		},
+		{
+			"id": "b2",
+			"type": "behavior_instance",
+			"script_id": "67d9395b-4403-42cc-b5f0-740b699d67c6",
+			"enabled": false,
+			"status": "disabled",
+			"metadata": {"name": "Hallway motion"}
+		}
+	]
+}`
+
+func TestGetBehaviorInstances(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/clip/v2/resource/behavior_instance" {
+			t.Errorf("Expected path /clip/v2/resource/behavior_instance, got %s", r.URL.Path)
+		}
+		w.Write([]byte(behaviorPayload))
+	}))
+	defer server.Close()
+	
+	client := &Client{
+		bridgeIP:   server.URL,
+		username:   "test-key",
+		httpClient: server.Client(),
+		baseURL:    server.URL + "/clip/v2",
+	}
+	
+	behaviors, err := client.GetBehaviorInstances(context.Background())
+	if err != nil {
+		t.Fatalf("GetBehaviorInstances failed: %v", err)
+	}
+	
+	if len(behaviors) != 2 {
+		t.Fatalf("Expected 2 behaviors, got %d", len(behaviors))
+	}
+	
+	wake := behaviors[0]
+	if wake.Metadata.Name != "Wake up" || !wake.Enabled || wake.Status != "running" {
+		t.Errorf("Unexpected first behavior: %+v", wake)
+	}
+	
+	if len(wake.Dependees) != 1 || wake.Dependees[0].Target.RID != "room-1" {
+		t.Errorf("Expected dependee on room-1, got %+v", wake.Dependees)
+	}
+	
+	if behaviors[1].Enabled {
+		t.Error("Expected second behavior to be disabled")
+	}
+}
+
+func TestSetBehaviorInstanceEnabled(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		var requestReceived map[string]interface{}
+		
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "PUT" {
+				t.Errorf("Expected PUT method, got %s", r.Method)
+			}
+			
+			if r.URL.Path != "/clip/v2/resource/behavior_instance/b1" {
+				t.Errorf("Expected path /clip/v2/resource/behavior_instance/b1, got %s", r.URL.Path)
+			}
+			
+			json.NewDecoder(r.Body).Decode(&requestReceived)
+			w.Write([]byte(`{"data":[{"rid":"b1","rtype":"behavior_instance"}],"errors":[]}`))
+		}))
+		
+		client := &Client{
+			bridgeIP:   server.URL,
+			username:   "test-key",
+			httpClient: server.Client(),
+			baseURL:    server.URL + "/clip/v2",
+		}
+		
+		if err := client.SetBehaviorInstanceEnabled(context.Background(), "b1", enabled); err != nil {
+			t.Fatalf("SetBehaviorInstanceEnabled failed: %v", err)
+		}
+		server.Close()
+		
+		if len(requestReceived) != 1 || requestReceived["enabled"] != enabled {
+			t.Errorf("Expected body {enabled: %v}, got %v", enabled, requestReceived)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// cachedPaths are the collection reads served from the response cache
+var cachedPaths = map[string]bool{
+	"/resource/light":  true,
+	"/resource/room":   true,
+	"/resource/zone":   true,
+	"/resource/device": true,
+}
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+// responseCache keeps recent collection responses for a short TTL.
+// Any mutating request bumps the generation so in-flight reads are not stored stale.
+type responseCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	entries    map[string]cacheEntry
+	generation uint64
+}
+
+// SetCacheTTL enables caching of GetLights, GetRooms, GetZones and GetDevices responses for
+// the given duration. The cache is cleared by any PUT, POST or DELETE. Zero disables it.
+// Call this before the client is shared between goroutines.
+func (c *Client) SetCacheTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		c.cache = nil
+		return
+	}
+	c.cache = &responseCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// InvalidateCache drops all cached responses so the next read goes to the bridge
+func (c *Client) InvalidateCache() {
+	if c.cache != nil {
+		c.cache.invalidate()
+	}
+}
+
+// lookup returns a cached body and the generation to use when storing a fresh one
+func (rc *responseCache) lookup(path string) ([]byte, uint64, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[path]
+	if ok && time.Now().Before(entry.expires) {
+		return entry.body, rc.generation, true
+	}
+	return nil, rc.generation, false
+}
+
+// store saves a response unless the cache was invalidated since the read began
+func (rc *responseCache) store(path string, body []byte, generation uint64) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if generation != rc.generation {
+		return
+	}
+	rc.entries[path] = cacheEntry{body: body, expires: time.Now().Add(rc.ttl)}
+}
+
+func (rc *responseCache) invalidate() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.generation++
+	rc.entries = make(map[string]cacheEntry)
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetLightsCachedWithinTTL(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", Light{ID: "light-1", Metadata: Metadata{Name: "Desk"}})
+	c := fb.client()
+	c.SetCacheTTL(time.Minute)
+	ctx := context.Background()
+	
+	for i := 0; i < 2; i++ {
+		lights, err := c.GetLights(ctx)
+		if err != nil {
+			t.Fatalf("GetLights failed: %v", err)
+		}
+		if len(lights) != 1 {
+			t.Fatalf("Expected 1 light, got %d", len(lights))
+		}
+	}
+	
+	if gets := fb.RequestsFor("GET", "/clip/v2/resource/light"); len(gets) != 1 {
+		t.Errorf("Expected the second GetLights to be served from cache, got %d requests", len(gets))
+	}
+}
+
+func TestCacheInvalidatedByMutation(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", Light{ID: "light-1", Metadata: Metadata{Name: "Desk"}})
+	c := fb.client()
+	c.SetCacheTTL(time.Minute)
+	ctx := context.Background()
+	
+	c.GetLights(ctx)
+	if err := c.TurnOnLight(ctx, "light-1"); err != nil {
+		t.Fatalf("TurnOnLight failed: %v", err)
+	}
+	lights, _ := c.GetLights(ctx)
+	
+	if gets := fb.RequestsFor("GET", "/clip/v2/resource/light"); len(gets) != 2 {
+		t.Errorf("Expected a mutation to force a fresh read, got %d requests", len(gets))
+	}
+	if len(lights) != 1 || !lights[0].On.On {
+		t.Errorf("Expected the fresh read to see the light on, got %+v", lights)
+	}
+}
+
+func TestCacheExpiryAndForceRefresh(t *testing.T) {
+	fb := newFakeBridge(t)
+	c := fb.client()
+	c.SetCacheTTL(20 * time.Millisecond)
+	ctx := context.Background()
+	
+	c.GetRooms(ctx)
+	time.Sleep(40 * time.Millisecond)
+	c.GetRooms(ctx)
+	c.InvalidateCache()
+	c.GetRooms(ctx)
+	
+	if gets := fb.RequestsFor("GET", "/clip/v2/resource/room"); len(gets) != 3 {
+		t.Errorf("Expected expiry and InvalidateCache to each force a read, got %d requests", len(gets))
+	}
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+)
+
+// colorCacheSize bounds the number of conversions kept in memory
+const colorCacheSize = 256
+
+// Known Hue color gamuts
+var gamuts = map[string]Gamut{
+	"A": {Red: XY{X: 0.704, Y: 0.296}, Green: XY{X: 0.2151, Y: 0.7106}, Blue: XY{X: 0.138, Y: 0.08}},
+	"B": {Red: XY{X: 0.675, Y: 0.322}, Green: XY{X: 0.409, Y: 0.518}, Blue: XY{X: 0.167, Y: 0.04}},
+	"C": {Red: XY{X: 0.6915, Y: 0.3083}, Green: XY{X: 0.17, Y: 0.7}, Blue: XY{X: 0.1532, Y: 0.0475}},
+}
+
+type colorCacheKey struct {
+	hex       string
+	gamutType string
+}
+
+type colorCacheEntry struct {
+	key colorCacheKey
+	xy  XY
+}
+
+// colorCache is a bounded LRU cache of hex to XY conversions
+type colorCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[colorCacheKey]*list.Element
+	order    *list.List
+	hits     uint64
+	misses   uint64
+}
+
+var defaultColorCache = newColorCache(colorCacheSize)
+
+func newColorCache(capacity int) *colorCache {
+	return &colorCache{
+		capacity: capacity,
+		entries:  make(map[colorCacheKey]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (cc *colorCache) get(key colorCacheKey) (XY, bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if elem, ok := cc.entries[key]; ok {
+		cc.order.MoveToFront(elem)
+		cc.hits++
+		return elem.Value.(*colorCacheEntry).xy, true
+	}
+
+	cc.misses++
+	return XY{}, false
+}
+
+func (cc *colorCache) add(key colorCacheKey, xy XY) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if elem, ok := cc.entries[key]; ok {
+		elem.Value.(*colorCacheEntry).xy = xy
+		cc.order.MoveToFront(elem)
+		return
+	}
+
+	cc.entries[key] = cc.order.PushFront(&colorCacheEntry{key: key, xy: xy})
+
+	if cc.order.Len() > cc.capacity {
+		oldest := cc.order.Back()
+		cc.order.Remove(oldest)
+		delete(cc.entries, oldest.Value.(*colorCacheEntry).key)
+	}
+}
+
+func (cc *colorCache) len() int {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.order.Len()
+}
+
+// hexToXYForGamut converts a hex color to XY, clamped to the given gamut type (A, B or C)
+func hexToXYForGamut(hex, gamutType string) (float64, float64) {
+	key := colorCacheKey{
+		hex:       strings.ToUpper(strings.TrimPrefix(hex, "#")),
+		gamutType: gamutType,
+	}
+
+	if xy, ok := defaultColorCache.get(key); ok {
+		return xy.X, xy.Y
+	}
+
+	x, y := convertHexToXY(hex)
+	if gamut, ok := gamuts[gamutType]; ok {
+		x, y = clampToGamut(x, y, gamut)
+	}
+
+	defaultColorCache.add(key, XY{X: x, Y: y})
+	return x, y
+}
+
+// clampToGamut moves a point outside the gamut triangle to the closest point on its edge
+func clampToGamut(x, y float64, gamut Gamut) (float64, float64) {
+	p := XY{X: x, Y: y}
+	if inGamut(p, gamut) {
+		return x, y
+	}
+
+	best := closestPointOnLine(gamut.Red, gamut.Green, p)
+	bestDist := squaredDistance(best, p)
+
+	for _, candidate := range []XY{
+		closestPointOnLine(gamut.Green, gamut.Blue, p),
+		closestPointOnLine(gamut.Blue, gamut.Red, p),
+	} {
+		if d := squaredDistance(candidate, p); d < bestDist {
+			best = candidate
+			bestDist = d
+		}
+	}
+
+	return best.X, best.Y
+}
+
+func inGamut(p XY, gamut Gamut) bool {
+	d1 := cross(p, gamut.Red, gamut.Green)
+	d2 := cross(p, gamut.Green, gamut.Blue)
+	d3 := cross(p, gamut.Blue, gamut.Red)
+
+	hasNeg := d1 < 0 || d2 < 0 || d3 < 0
+	hasPos := d1 > 0 || d2 > 0 || d3 > 0
+
+	return !(hasNeg && hasPos)
+}
+
+func cross(p, a, b XY) float64 {
+	return (p.X-b.X)*(a.Y-b.Y) - (a.X-b.X)*(p.Y-b.Y)
+}
+
+func closestPointOnLine(a, b, p XY) XY {
+	abX, abY := b.X-a.X, b.Y-a.Y
+	t := ((p.X-a.X)*abX + (p.Y-a.Y)*abY) / (abX*abX + abY*abY)
+
+	if t < 0 {
+		t = 0
+	} else if t > 1 {
+		t = 1
+	}
+
+	return XY{X: a.X + t*abX, Y: a.Y + t*abY}
+}
+
+func squaredDistance(a, b XY) float64 {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	return dx*dx + dy*dy
+}
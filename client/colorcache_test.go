@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestColorCacheMatchesFreshConversion(t *testing.T) {
+	colors := []string{"#FF0000", "#00FF00", "#0000FF", "#FFA500", "#123456", "ffffff"}
+	
+	for _, hex := range colors {
+		wantX, wantY := convertHexToXY(hex)
+		
+		// First call populates the cache, second is served from it
+		for i := 0; i < 2; i++ {
+			x, y := hexToXY(hex)
+			if x != wantX || y != wantY {
+				t.Errorf("hexToXY(%s) call %d = (%f, %f), want (%f, %f)", hex, i, x, y, wantX, wantY)
+			}
+		}
+	}
+}
+
+func TestColorCacheHits(t *testing.T) {
+	cache := newColorCache(4)
+	key := colorCacheKey{hex: "FF0000", gamutType: "C"}
+	
+	if _, ok := cache.get(key); ok {
+		t.Fatal("Expected miss on empty cache")
+	}
+	
+	cache.add(key, XY{X: 0.69, Y: 0.30})
+	
+	for i := 0; i < 10; i++ {
+		if _, ok := cache.get(key); !ok {
+			t.Fatal("Expected cache hit")
+		}
+	}
+	
+	if cache.hits != 10 || cache.misses != 1 {
+		t.Errorf("Expected 10 hits and 1 miss, got %d hits and %d misses", cache.hits, cache.misses)
+	}
+}
+
+func TestColorCacheEviction(t *testing.T) {
+	cache := newColorCache(2)
+	
+	cache.add(colorCacheKey{hex: "000001"}, XY{})
+	cache.add(colorCacheKey{hex: "000002"}, XY{})
+	cache.get(colorCacheKey{hex: "000001"})
+	cache.add(colorCacheKey{hex: "000003"}, XY{})
+	
+	if cache.len() != 2 {
+		t.Fatalf("Expected cache bounded to 2 entries, got %d", cache.len())
+	}
+	
+	if _, ok := cache.get(colorCacheKey{hex: "000002"}); ok {
+		t.Error("Expected least recently used entry to be evicted")
+	}
+	
+	if _, ok := cache.get(colorCacheKey{hex: "000001"}); !ok {
+		t.Error("Expected recently used entry to be kept")
+	}
+}
+
+func TestColorCacheGamutKeyed(t *testing.T) {
+	// Pure green is outside gamut B, so the clamped result must differ from the unclamped one
+	x, y := hexToXYForGamut("#00FF00", "")
+	bx, by := hexToXYForGamut("#00FF00", "B")
+	
+	if x == bx && y == by {
+		t.Error("Expected gamut B result to be cached separately from the unclamped result")
+	}
+	
+	cx, cy := clampToGamut(bx, by, gamuts["B"])
+	if abs(cx-bx) > 1e-9 || abs(cy-by) > 1e-9 {
+		t.Errorf("Expected clamped point (%f, %f) to lie within gamut B", bx, by)
+	}
+}
+
+func TestSetLightColorClampsToLightGamut(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", Light{ID: "light-1", Color: &Color{GamutType: "B"}})
+	c := fb.client()
+	
+	for i := 0; i < 2; i++ {
+		if err := c.SetLightColor(context.Background(), "light-1", "#00FF00"); err != nil {
+			t.Fatalf("SetLightColor failed: %v", err)
+		}
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1")
+	xy := puts[0].Body["color"].(map[string]interface{})["xy"].(map[string]interface{})
+	wantX, wantY := hexToXYForGamut("#00FF00", "B")
+	if abs(xy["x"].(float64)-wantX) > 1e-9 || abs(xy["y"].(float64)-wantY) > 1e-9 {
+		t.Errorf("Expected green clamped to gamut B (%f, %f), got (%v, %v)", wantX, wantY, xy["x"], xy["y"])
+	}
+	
+	// The gamut is looked up once per light
+	if gets := fb.RequestsFor("GET", "/clip/v2/resource/light/light-1"); len(gets) != 1 {
+		t.Errorf("Expected 1 light lookup, got %d", len(gets))
+	}
+}
+
+func TestColorCacheConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				hexToXY(fmt.Sprintf("#%06X", (i*100+j)%50))
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func BenchmarkHexToXYCached(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		hexToXY("#FF8800")
+	}
+}
+
+func BenchmarkHexToXYUncached(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		convertHexToXY("#FF8800")
+	}
+}
@@ -4,51 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // Scene CRUD operations
 
-// CreateSceneFromCurrentState creates a new scene capturing current light states
-func (c *Client) CreateSceneFromCurrentState(ctx context.Context, name string, roomID string) (*Scene, error) {
-	// Get the room to find all lights
-	rooms, err := c.GetRooms(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get rooms: %w", err)
-	}
-	
-	var targetRoom *Room
-	for _, r := range rooms {
-		if r.ID == roomID {
-			targetRoom = &r
-			break
-		}
-	}
-	
-	if targetRoom == nil {
-		return nil, fmt.Errorf("room %s not found", roomID)
-	}
-	
-	// Get all lights in the room
-	var lightIDs []string
-	devices, err := c.GetDevices(ctx)
+// CreateSceneFromCurrentState creates a new scene capturing current light states.
+// groupID may be a room, zone or grouped_light ID.
+func (c *Client) CreateSceneFromCurrentState(ctx context.Context, name string, groupID string) (*Scene, error) {
+	// Find the room or zone and all lights in it
+	owner, lightIDs, err := c.resolveGroupLights(ctx, groupID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get devices: %w", err)
-	}
-	
-	// Find all light IDs from devices in this room
-	for _, child := range targetRoom.Children {
-		if child.RType == "device" {
-			// Find this device and its lights
-			for _, device := range devices {
-				if device.ID == child.RID {
-					for _, svc := range device.Services {
-						if svc.RType == "light" {
-							lightIDs = append(lightIDs, svc.RID)
-						}
-					}
-				}
-			}
-		}
+		return nil, err
 	}
 	
 	// Get current state of each light
@@ -78,33 +45,32 @@ func (c *Client) CreateSceneFromCurrentState(ctx context.Context, name string, r
 			}
 		}
 		
-		// Add color if available
-		if light.Color != nil {
-			action.Action.Color = &Color{
-				XY: light.Color.XY,
-			}
-		}
-		
-		// Add color temperature if available
-		if light.ColorTemperature != nil && light.ColorTemperature.MirekValid {
+		// Capture the white color temperature or the color the light is showing, never
+		// both, since a scene action carrying both restores whichever the bridge applies last
+		if inColorTemperatureMode(light) {
 			action.Action.ColorTemperature = &ColorTemperature{
 				Mirek: light.ColorTemperature.Mirek,
 			}
+		} else if light.Color != nil {
+			action.Action.Color = &Color{
+				XY: light.Color.XY,
+			}
 		}
 		
 		actions = append(actions, action)
 	}
 	
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("no lights found in group %s", groupID)
+	}
+	
 	// Create the scene
 	sceneCreate := SceneCreate{
 		Type: "scene",
 		Metadata: Metadata{
 			Name: name,
 		},
-		Group: ResourceIdentifier{
-			RID:   roomID,
-			RType: "room",
-		},
+		Group:   owner,
 		Actions: actions,
 		Speed:   0.5, // Default transition speed
 	}
@@ -112,6 +78,71 @@ func (c *Client) CreateSceneFromCurrentState(ctx context.Context, name string, r
 	return c.CreateScene(ctx, sceneCreate)
 }
 
+// maxPaletteColors is the most colors the bridge accepts in a scene palette
+const maxPaletteColors = 9
+
+// CreateSceneWithPalette creates a scene whose lights take the given hex colors in turn and
+// whose palette holds the same colors, so the scene shifts between them when recalled dynamically.
+// groupID may be a room, zone or grouped_light ID; brightness is 0-100. autoDynamic makes a
+// plain recall start dynamic mode too.
+func (c *Client) CreateSceneWithPalette(ctx context.Context, name string, groupID string, colors []string, brightness float64, autoDynamic bool) (*Scene, error) {
+	if len(colors) == 0 {
+		return nil, fmt.Errorf("at least one color is required")
+	}
+	if len(colors) > maxPaletteColors {
+		return nil, fmt.Errorf("a palette holds at most %d colors, got %d", maxPaletteColors, len(colors))
+	}
+	if brightness <= 0 || brightness > 100 {
+		return nil, fmt.Errorf("brightness must be between 1 and 100")
+	}
+	
+	owner, lightIDs, err := c.resolveGroupLights(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if len(lightIDs) == 0 {
+		return nil, fmt.Errorf("no lights found in group %s", groupID)
+	}
+	
+	palette := &ScenePalette{
+		Color:            []PaletteColor{},
+		Dimming:          []Dimming{{Brightness: brightness}},
+		ColorTemperature: []PaletteTemperature{},
+	}
+	xys := make([]XY, len(colors))
+	for i, hex := range colors {
+		x, y := hexToXY(hex)
+		xys[i] = XY{X: x, Y: y}
+		palette.Color = append(palette.Color, PaletteColor{
+			Color:   Color{XY: xys[i]},
+			Dimming: Dimming{Brightness: brightness},
+		})
+	}
+	
+	// Spread the colors across the lights so the static recall already uses the palette
+	actions := make([]SceneAction, 0, len(lightIDs))
+	for i, lightID := range lightIDs {
+		actions = append(actions, SceneAction{
+			Target: ResourceIdentifier{RID: lightID, RType: "light"},
+			Action: LightUpdate{
+				On:      &OnState{On: true},
+				Dimming: &Dimming{Brightness: brightness},
+				Color:   &Color{XY: xys[i%len(xys)]},
+			},
+		})
+	}
+	
+	return c.CreateScene(ctx, SceneCreate{
+		Type:        "scene",
+		Metadata:    Metadata{Name: name},
+		Group:       owner,
+		Actions:     actions,
+		Palette:     palette,
+		Speed:       0.5,
+		AutoDynamic: autoDynamic,
+	})
+}
+
 // DeleteScene deletes a scene
 func (c *Client) DeleteScene(ctx context.Context, id string) error {
 	_, err := c.delete(ctx, fmt.Sprintf("/resource/scene/%s", id))
@@ -124,6 +155,301 @@ func (c *Client) UpdateScene(ctx context.Context, id string, update SceneUpdate)
 	return err
 }
 
+// MoveScene recreates a scene under a different room or zone and deletes the original.
+// Actions for lights present in both groups are kept; remaining lights in the new group
+// take the leftover actions in order. Returns the ID of the new scene.
+func (c *Client) MoveScene(ctx context.Context, sceneID, newGroupID string) (string, error) {
+	scene, err := c.GetScene(ctx, sceneID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get scene: %w", err)
+	}
+	
+	owner, lightIDs, err := c.resolveGroupLights(ctx, newGroupID)
+	if err != nil {
+		return "", err
+	}
+	
+	if owner.RID == scene.Group.RID {
+		return scene.ID, nil // Already in the target group
+	}
+	
+	actions := remapSceneActions(scene.Actions, lightIDs)
+	if len(actions) == 0 {
+		return "", fmt.Errorf("scene %s has no actions that can be applied to group %s", sceneID, newGroupID)
+	}
+	
+	newScene, err := c.CreateScene(ctx, SceneCreate{
+		Type:     "scene",
+		Metadata: scene.Metadata,
+		Group:    owner,
+		Actions:  actions,
+		Speed:    scene.Speed,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create scene in new group: %w", err)
+	}
+	
+	if err := c.DeleteScene(ctx, sceneID); err != nil {
+		return newScene.ID, fmt.Errorf("created scene %s but failed to delete original: %w", newScene.ID, err)
+	}
+	
+	return newScene.ID, nil
+}
+
+// DuplicateScene copies a scene's actions, palette and group into a new scene with a different name
+func (c *Client) DuplicateScene(ctx context.Context, sceneID, newName string) (*Scene, error) {
+	if newName == "" {
+		return nil, fmt.Errorf("a name for the new scene is required")
+	}
+	
+	scene, err := c.GetScene(ctx, sceneID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scene: %w", err)
+	}
+	
+	// The bridge rejects scenes without actions, so say why rather than pass on its error
+	if len(scene.Actions) == 0 {
+		return nil, fmt.Errorf("scene '%s' has no light actions to copy", scene.Metadata.Name)
+	}
+	
+	metadata := scene.Metadata
+	metadata.Name = newName
+	return c.CreateScene(ctx, SceneCreate{
+		Type:        "scene",
+		Metadata:    metadata,
+		Group:       scene.Group,
+		Actions:     scene.Actions,
+		Palette:     scene.Palette,
+		Speed:       scene.Speed,
+		AutoDynamic: scene.AutoDynamic,
+	})
+}
+
+// ApplySceneTemplate gives the lights of another room or zone a scene's look. Palette colors
+// are spread across the lights in turn; scenes without a palette have their light actions
+// mapped over as MoveScene does. This is a best-effort copy of colors and brightness, not a
+// native recall, so dynamic palettes and transitions are not reproduced. Returns the actions
+// that were sent to each light.
+func (c *Client) ApplySceneTemplate(ctx context.Context, sceneID, targetGroupID string) ([]SceneAction, error) {
+	scene, err := c.GetScene(ctx, sceneID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scene: %w", err)
+	}
+	
+	_, lightIDs, err := c.resolveGroupLights(ctx, targetGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if len(lightIDs) == 0 {
+		return nil, fmt.Errorf("no lights found in group %s", targetGroupID)
+	}
+	
+	actions := paletteActions(scene.Palette, lightIDs)
+	if actions == nil {
+		actions = remapSceneActions(scene.Actions, lightIDs)
+	}
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("scene '%s' has no colors or light actions to apply", scene.Metadata.Name)
+	}
+	
+	var failed []string
+	for _, action := range actions {
+		if err := c.UpdateLight(ctx, action.Target.RID, action.Action); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", action.Target.RID, err))
+		}
+	}
+	if len(failed) > 0 {
+		return actions, fmt.Errorf("failed to update %d of %d lights:\n%s", len(failed), len(actions), strings.Join(failed, "\n"))
+	}
+	
+	return actions, nil
+}
+
+// paletteActions spreads a palette's colors, or failing that its color temperatures, across
+// the lights. It returns nil when the palette has neither.
+func paletteActions(palette *ScenePalette, lightIDs []string) []SceneAction {
+	if palette == nil {
+		return nil
+	}
+	
+	var brightness float64
+	if len(palette.Dimming) > 0 {
+		brightness = palette.Dimming[0].Brightness
+	}
+	dimming := func(own Dimming) *Dimming {
+		if own.Brightness > 0 {
+			return &Dimming{Brightness: own.Brightness}
+		}
+		if brightness > 0 {
+			return &Dimming{Brightness: brightness}
+		}
+		return nil
+	}
+	
+	var actions []SceneAction
+	for i, lightID := range lightIDs {
+		update := LightUpdate{On: &OnState{On: true}}
+		switch {
+		case len(palette.Color) > 0:
+			entry := palette.Color[i%len(palette.Color)]
+			update.Color = &Color{XY: entry.Color.XY}
+			update.Dimming = dimming(entry.Dimming)
+		case len(palette.ColorTemperature) > 0:
+			entry := palette.ColorTemperature[i%len(palette.ColorTemperature)]
+			update.ColorTemperature = &ColorTemperature{Mirek: entry.ColorTemperature.Mirek}
+			update.Dimming = dimming(entry.Dimming)
+		default:
+			return nil
+		}
+		actions = append(actions, SceneAction{
+			Target: ResourceIdentifier{RID: lightID, RType: "light"},
+			Action: update,
+		})
+	}
+	
+	return actions
+}
+
+// remapSceneActions maps scene actions onto a new set of lights
+func remapSceneActions(actions []SceneAction, lightIDs []string) []SceneAction {
+	if len(actions) == 0 {
+		return nil
+	}
+	
+	byLight := make(map[string]SceneAction)
+	for _, action := range actions {
+		byLight[action.Target.RID] = action
+	}
+	
+	// Actions for lights that aren't in the new group are free to reuse
+	inNewGroup := make(map[string]bool)
+	for _, id := range lightIDs {
+		inNewGroup[id] = true
+	}
+	var spare []SceneAction
+	for _, action := range actions {
+		if !inNewGroup[action.Target.RID] {
+			spare = append(spare, action)
+		}
+	}
+	if len(spare) == 0 {
+		spare = actions
+	}
+	
+	var remapped []SceneAction
+	next := 0
+	for _, id := range lightIDs {
+		action, ok := byLight[id]
+		if !ok {
+			action = spare[next%len(spare)]
+			next++
+		}
+		
+		remapped = append(remapped, SceneAction{
+			Target: ResourceIdentifier{RID: id, RType: "light"},
+			Action: action.Action,
+		})
+	}
+	
+	return remapped
+}
+
+// resolveGroupLights finds the room or zone for a room, zone or grouped_light ID
+// and returns it along with the IDs of the lights it contains
+func (c *Client) resolveGroupLights(ctx context.Context, groupID string) (ResourceIdentifier, []string, error) {
+	owner, children, err := c.resolveGroupOwner(ctx, groupID)
+	if err != nil {
+		return ResourceIdentifier{}, nil, err
+	}
+	
+	var devices []Device
+	for _, child := range children {
+		if child.RType == "device" {
+			devices, err = c.GetDevices(ctx)
+			if err != nil {
+				return ResourceIdentifier{}, nil, fmt.Errorf("failed to get devices: %w", err)
+			}
+			break
+		}
+	}
+	
+	var lightIDs []string
+	for _, child := range children {
+		switch child.RType {
+		case "light":
+			lightIDs = append(lightIDs, child.RID)
+		case "device":
+			for _, device := range devices {
+				if device.ID == child.RID {
+					for _, svc := range device.Services {
+						if svc.RType == "light" {
+							lightIDs = append(lightIDs, svc.RID)
+						}
+					}
+				}
+			}
+		}
+	}
+	
+	return owner, lightIDs, nil
+}
+
+// GetGroupLights returns the IDs of the lights in a room or zone, given its ID or its grouped_light ID
+func (c *Client) GetGroupLights(ctx context.Context, groupID string) ([]string, error) {
+	_, lightIDs, err := c.resolveGroupLights(ctx, groupID)
+	return lightIDs, err
+}
+
+// resolveGroupOwner finds the room or zone for a room, zone or grouped_light ID
+// and returns it along with its children
+func (c *Client) resolveGroupOwner(ctx context.Context, groupID string) (ResourceIdentifier, []ResourceIdentifier, error) {
+	rooms, err := c.GetRooms(ctx)
+	if err != nil {
+		return ResourceIdentifier{}, nil, fmt.Errorf("failed to get rooms: %w", err)
+	}
+	
+	zones, err := c.GetZones(ctx)
+	if err != nil {
+		return ResourceIdentifier{}, nil, fmt.Errorf("failed to get zones: %w", err)
+	}
+	
+	var owner ResourceIdentifier
+	var children []ResourceIdentifier
+	
+	for _, room := range rooms {
+		if room.ID == groupID || hasService(room.Services, "grouped_light", groupID) {
+			owner = ResourceIdentifier{RID: room.ID, RType: "room"}
+			children = room.Children
+			break
+		}
+	}
+	
+	if owner.RID == "" {
+		for _, zone := range zones {
+			if zone.ID == groupID || hasService(zone.Services, "grouped_light", groupID) {
+				owner = ResourceIdentifier{RID: zone.ID, RType: "zone"}
+				children = zone.Children
+				break
+			}
+		}
+	}
+	
+	if owner.RID == "" {
+		return ResourceIdentifier{}, nil, fmt.Errorf("room or zone for group %s not found", groupID)
+	}
+	
+	return owner, children, nil
+}
+
+func hasService(services []ResourceIdentifier, rtype, rid string) bool {
+	for _, svc := range services {
+		if svc.RType == rtype && svc.RID == rid {
+			return true
+		}
+	}
+	return false
+}
+
 // Group CRUD operations
 
 // AddLightToGroup adds a light to a group
@@ -317,6 +643,86 @@ func (c *Client) RemoveLightFromGroup(ctx context.Context, groupID, lightID stri
 	return fmt.Errorf("room or zone for group %s not found", groupID)
 }
 
+// MoveLightToRoom moves the device holding a light out of its current room and into another,
+// given as a room or grouped_light ID. A device belongs to at most one room, so it is removed
+// first; if adding it to the new room then fails, the old room is restored. Returns the old
+// room, nil if the light had none, and the new one.
+func (c *Client) MoveLightToRoom(ctx context.Context, lightID, roomID string) (*Room, *Room, error) {
+	devices, err := c.GetDevices(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	var deviceID string
+	for _, device := range devices {
+		if hasService(device.Services, "light", lightID) {
+			deviceID = device.ID
+			break
+		}
+	}
+	if deviceID == "" {
+		return nil, nil, fmt.Errorf("device containing light %s not found", lightID)
+	}
+	
+	rooms, err := c.GetRooms(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	var from, to *Room
+	for i := range rooms {
+		room := &rooms[i]
+		if room.ID == roomID || hasService(room.Services, "grouped_light", roomID) {
+			to = room
+		}
+		if hasChild(room.Children, "device", deviceID) {
+			from = room
+		}
+	}
+	if to == nil {
+		return nil, nil, fmt.Errorf("room %s not found", roomID)
+	}
+	if from != nil && from.ID == to.ID {
+		return from, to, nil // Already there
+	}
+	
+	if from != nil {
+		remaining := []ResourceIdentifier{}
+		for _, child := range from.Children {
+			if !(child.RType == "device" && child.RID == deviceID) {
+				remaining = append(remaining, child)
+			}
+		}
+		// RoomUpdate omits an empty children list, which would leave the room unchanged
+		update := map[string]interface{}{"children": remaining}
+		if _, err := c.put(ctx, fmt.Sprintf("/resource/room/%s", from.ID), update); err != nil {
+			return nil, nil, fmt.Errorf("failed to remove light from %s: %w", from.Metadata.Name, err)
+		}
+	}
+	
+	children := append(append([]ResourceIdentifier{}, to.Children...), ResourceIdentifier{RID: deviceID, RType: "device"})
+	if err := c.UpdateRoom(ctx, to.ID, RoomUpdate{Children: children}); err != nil {
+		if from == nil {
+			return nil, nil, fmt.Errorf("failed to add light to %s: %w", to.Metadata.Name, err)
+		}
+		if rollbackErr := c.UpdateRoom(ctx, from.ID, RoomUpdate{Children: from.Children}); rollbackErr != nil {
+			return nil, nil, fmt.Errorf("failed to add light to %s: %w (and failed to put it back in %s: %v)", to.Metadata.Name, err, from.Metadata.Name, rollbackErr)
+		}
+		return nil, nil, fmt.Errorf("failed to add light to %s, left it in %s: %w", to.Metadata.Name, from.Metadata.Name, err)
+	}
+	
+	return from, to, nil
+}
+
+func hasChild(children []ResourceIdentifier, rtype, rid string) bool {
+	for _, child := range children {
+		if child.RType == rtype && child.RID == rid {
+			return true
+		}
+	}
+	return false
+}
+
 // Room/Zone update operations
 
 // UpdateRoom updates a room
@@ -331,6 +737,72 @@ func (c *Client) UpdateZone(ctx context.Context, id string, update ZoneUpdate) e
 	return err
 }
 
+// RoomArchetypes are the room types the bridge accepts
+var RoomArchetypes = []string{
+	"living_room", "kitchen", "dining", "bedroom", "kids_bedroom", "bathroom", "nursery",
+	"recreation", "office", "gym", "hallway", "toilet", "front_door", "garage", "terrace",
+	"garden", "driveway", "carport", "home", "downstairs", "upstairs", "top_floor", "attic",
+	"guest_room", "staircase", "lounge", "man_cave", "computer", "studio", "music", "tv",
+	"reading", "closet", "storage", "laundry_room", "balcony", "porch", "barbecue", "pool",
+	"other",
+}
+
+// validateRoomArchetype checks an archetype against the known room types
+func validateRoomArchetype(archetype string) error {
+	for _, known := range RoomArchetypes {
+		if archetype == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid room archetype '%s', valid archetypes: %s", archetype, strings.Join(RoomArchetypes, ", "))
+}
+
+// CreateRoom creates a new room. Room children are devices rather than lights.
+func (c *Client) CreateRoom(ctx context.Context, room RoomCreate) (*Room, error) {
+	if room.Type == "" {
+		room.Type = "room"
+	}
+	if err := validateRoomArchetype(room.Metadata.Archetype); err != nil {
+		return nil, err
+	}
+	if room.Children == nil {
+		room.Children = []ResourceIdentifier{}
+	}
+	
+	var response struct {
+		Errors []Error `json:"errors"`
+		Data   []struct {
+			ID string `json:"rid"`
+		} `json:"data"`
+	}
+	
+	respBody, err := c.post(ctx, "/resource/room", room)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	
+	if len(response.Errors) > 0 {
+		return nil, apiError(response.Errors)
+	}
+	
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("no room ID returned")
+	}
+	
+	// Get the created room
+	return c.GetRoom(ctx, response.Data[0].ID)
+}
+
+// DeleteRoom deletes a room. Its devices become unassigned.
+func (c *Client) DeleteRoom(ctx context.Context, id string) error {
+	_, err := c.delete(ctx, fmt.Sprintf("/resource/room/%s", id))
+	return err
+}
+
 // CreateZone creates a new zone
 func (c *Client) CreateZone(ctx context.Context, zone ZoneCreate) (*Zone, error) {
 	var response struct {
@@ -348,7 +820,7 @@ func (c *Client) CreateZone(ctx context.Context, zone ZoneCreate) (*Zone, error)
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	if len(response.Data) == 0 {
@@ -0,0 +1,473 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMoveScene(t *testing.T) {
+	fb := newFakeBridge(t)
+	
+	fb.Add("room", Room{
+		ID:       "room-old",
+		Type:     "room",
+		Metadata: Metadata{Name: "Old Room"},
+		Services: []ResourceIdentifier{{RID: "group-old", RType: "grouped_light"}},
+		Children: []ResourceIdentifier{{RID: "device-1", RType: "device"}, {RID: "device-2", RType: "device"}},
+	})
+	fb.Add("room", Room{
+		ID:       "room-new",
+		Type:     "room",
+		Metadata: Metadata{Name: "New Room"},
+		Services: []ResourceIdentifier{{RID: "group-new", RType: "grouped_light"}},
+		Children: []ResourceIdentifier{{RID: "device-2", RType: "device"}, {RID: "device-3", RType: "device"}},
+	})
+	fb.Add("device", Device{ID: "device-1", Services: []ResourceIdentifier{{RID: "light-1", RType: "light"}}})
+	fb.Add("device", Device{ID: "device-2", Services: []ResourceIdentifier{{RID: "light-2", RType: "light"}}})
+	fb.Add("device", Device{ID: "device-3", Services: []ResourceIdentifier{{RID: "light-3", RType: "light"}}})
+	
+	fb.Add("scene", Scene{
+		ID:       "scene-1",
+		Type:     "scene",
+		Metadata: Metadata{Name: "Reading"},
+		Group:    ResourceIdentifier{RID: "room-old", RType: "room"},
+		Speed:    0.7,
+		Actions: []SceneAction{
+			{Target: ResourceIdentifier{RID: "light-1", RType: "light"}, Action: LightUpdate{Dimming: &Dimming{Brightness: 10}}},
+			{Target: ResourceIdentifier{RID: "light-2", RType: "light"}, Action: LightUpdate{Dimming: &Dimming{Brightness: 20}}},
+		},
+	})
+	
+	c := fb.client()
+	newID, err := c.MoveScene(context.Background(), "scene-1", "group-new")
+	if err != nil {
+		t.Fatalf("MoveScene failed: %v", err)
+	}
+	
+	if newID == "" || newID == "scene-1" {
+		t.Fatalf("Expected a new scene ID, got %q", newID)
+	}
+	
+	posts := fb.RequestsFor("POST", "/clip/v2/resource/scene")
+	if len(posts) != 1 {
+		t.Fatalf("Expected 1 scene create, got %d", len(posts))
+	}
+	
+	body := posts[0].Body
+	group := body["group"].(map[string]interface{})
+	if group["rid"] != "room-new" || group["rtype"] != "room" {
+		t.Errorf("Expected scene to target room-new, got %v", group)
+	}
+	
+	if body["metadata"].(map[string]interface{})["name"] != "Reading" {
+		t.Errorf("Expected scene name to be preserved, got %v", body["metadata"])
+	}
+	
+	// light-2 keeps its own action, light-3 picks up the action from light-1 which left the group
+	brightness := map[string]float64{}
+	for _, a := range body["actions"].([]interface{}) {
+		action := a.(map[string]interface{})
+		target := action["target"].(map[string]interface{})["rid"].(string)
+		dimming := action["action"].(map[string]interface{})["dimming"].(map[string]interface{})
+		brightness[target] = dimming["brightness"].(float64)
+	}
+	
+	if len(brightness) != 2 || brightness["light-2"] != 20 || brightness["light-3"] != 10 {
+		t.Errorf("Unexpected remapped actions: %v", brightness)
+	}
+	
+	deletes := fb.RequestsFor("DELETE", "/clip/v2/resource/scene/scene-1")
+	if len(deletes) != 1 {
+		t.Errorf("Expected original scene to be deleted, got %d deletes", len(deletes))
+	}
+}
+
+func TestMoveSceneUnknownGroup(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("scene", Scene{
+		ID:      "scene-1",
+		Group:   ResourceIdentifier{RID: "room-old", RType: "room"},
+		Actions: []SceneAction{{Target: ResourceIdentifier{RID: "light-1", RType: "light"}}},
+	})
+	
+	_, err := fb.client().MoveScene(context.Background(), "scene-1", "missing")
+	if err == nil {
+		t.Fatal("Expected error for unknown group")
+	}
+	
+	if len(fb.RequestsFor("DELETE", "/clip/v2/resource/scene")) != 0 {
+		t.Error("Original scene must not be deleted when the move fails")
+	}
+}
+
+func TestDuplicateScene(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("scene", Scene{
+		ID:       "scene-1",
+		Type:     "scene",
+		Metadata: Metadata{Name: "Relax"},
+		Group:    ResourceIdentifier{RID: "room-1", RType: "room"},
+		Speed:    0.4,
+		Palette:  &ScenePalette{Dimming: []Dimming{{Brightness: 50}}},
+		Actions: []SceneAction{
+			{Target: ResourceIdentifier{RID: "light-1", RType: "light"}, Action: LightUpdate{Dimming: &Dimming{Brightness: 30}}},
+		},
+	})
+	fb.Add("scene", Scene{ID: "scene-empty", Metadata: Metadata{Name: "Empty"}})
+	
+	c := fb.client()
+	copied, err := c.DuplicateScene(context.Background(), "scene-1", "Relax Dim")
+	if err != nil {
+		t.Fatalf("DuplicateScene failed: %v", err)
+	}
+	if copied.ID == "scene-1" || copied.Metadata.Name != "Relax Dim" {
+		t.Errorf("Expected a new scene named Relax Dim, got %+v", copied)
+	}
+	
+	posts := fb.RequestsFor("POST", "/clip/v2/resource/scene")
+	if len(posts) != 1 {
+		t.Fatalf("Expected 1 scene create, got %d", len(posts))
+	}
+	body := posts[0].Body
+	if body["group"].(map[string]interface{})["rid"] != "room-1" || body["speed"] != 0.4 || body["palette"] == nil {
+		t.Errorf("Expected the group, speed and palette to be copied, got %v", body)
+	}
+	if actions := body["actions"].([]interface{}); len(actions) != 1 {
+		t.Errorf("Expected the action to be copied, got %v", actions)
+	}
+	
+	_, err = c.DuplicateScene(context.Background(), "scene-empty", "Copy")
+	if err == nil || !strings.Contains(err.Error(), "no light actions") {
+		t.Errorf("Expected a clear error for a scene without actions, got %v", err)
+	}
+	if posts := fb.RequestsFor("POST", "/clip/v2/resource/scene"); len(posts) != 1 {
+		t.Error("Expected no scene to be created from an empty scene")
+	}
+}
+
+func TestGetActiveScene(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("room", Room{
+		ID:       "room-1",
+		Type:     "room",
+		Metadata: Metadata{Name: "Living Room"},
+		Services: []ResourceIdentifier{{RID: "group-1", RType: "grouped_light"}},
+	})
+	fb.Add("room", Room{
+		ID:       "room-2",
+		Type:     "room",
+		Metadata: Metadata{Name: "Kitchen"},
+		Services: []ResourceIdentifier{{RID: "group-2", RType: "grouped_light"}},
+	})
+	fb.Add("scene", Scene{ID: "scene-1", Metadata: Metadata{Name: "Relax"}, Group: ResourceIdentifier{RID: "room-1", RType: "room"}, Status: &SceneStatus{Active: "inactive"}})
+	fb.Add("scene", Scene{ID: "scene-2", Metadata: Metadata{Name: "Read"}, Group: ResourceIdentifier{RID: "room-1", RType: "room"}, Status: &SceneStatus{Active: "static"}})
+	fb.Add("scene", Scene{ID: "scene-3", Metadata: Metadata{Name: "Cook"}, Group: ResourceIdentifier{RID: "room-2", RType: "room"}, Status: &SceneStatus{Active: "inactive"}})
+	c := fb.client()
+	ctx := context.Background()
+	
+	// Both the room ID and its grouped_light ID identify the group
+	for _, groupID := range []string{"room-1", "group-1"} {
+		scene, err := c.GetActiveScene(ctx, groupID)
+		if err != nil {
+			t.Fatalf("GetActiveScene(%s) failed: %v", groupID, err)
+		}
+		if scene == nil || scene.ID != "scene-2" {
+			t.Errorf("GetActiveScene(%s): expected scene-2, got %+v", groupID, scene)
+		}
+	}
+	
+	scene, err := c.GetActiveScene(ctx, "group-2")
+	if err != nil {
+		t.Fatalf("GetActiveScene failed: %v", err)
+	}
+	if scene != nil {
+		t.Errorf("Expected no active scene for the kitchen, got %s", scene.ID)
+	}
+	
+	if _, err := c.GetActiveScene(ctx, "missing"); err == nil {
+		t.Error("Expected an error for an unknown group")
+	}
+}
+
+func TestCreateRoomValidatesArchetype(t *testing.T) {
+	fb := newFakeBridge(t)
+	c := fb.client()
+	
+	_, err := c.CreateRoom(context.Background(), RoomCreate{Metadata: Metadata{Name: "Den", Archetype: "dungeon"}})
+	if err == nil || !strings.Contains(err.Error(), "living_room") {
+		t.Fatalf("Expected an error listing valid archetypes, got %v", err)
+	}
+	if len(fb.RequestsFor("POST", "/clip/v2/resource/room")) != 0 {
+		t.Error("Expected no request for an invalid archetype")
+	}
+	
+	room, err := c.CreateRoom(context.Background(), RoomCreate{
+		Metadata: Metadata{Name: "Den", Archetype: "lounge"},
+		Children: []ResourceIdentifier{{RID: "device-1", RType: "device"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateRoom failed: %v", err)
+	}
+	
+	posts := fb.RequestsFor("POST", "/clip/v2/resource/room")
+	if len(posts) != 1 || posts[0].Body["type"] != "room" {
+		t.Fatalf("Expected one room POST with type room, got %v", posts)
+	}
+	
+	if err := c.DeleteRoom(context.Background(), room.ID); err != nil {
+		t.Fatalf("DeleteRoom failed: %v", err)
+	}
+	if len(fb.RequestsFor("DELETE", "/clip/v2/resource/room/"+room.ID)) != 1 {
+		t.Error("Expected the room to be deleted")
+	}
+}
+
+func TestCreateSceneWithPalette(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("room", Room{
+		ID:       "room-1",
+		Type:     "room",
+		Services: []ResourceIdentifier{{RID: "group-1", RType: "grouped_light"}},
+		Children: []ResourceIdentifier{{RID: "device-1", RType: "device"}},
+	})
+	fb.Add("device", Device{ID: "device-1", Services: []ResourceIdentifier{
+		{RID: "light-1", RType: "light"},
+		{RID: "light-2", RType: "light"},
+		{RID: "light-3", RType: "light"},
+	}})
+	
+	c := fb.client()
+	if _, err := c.CreateSceneWithPalette(context.Background(), "Galaxy", "group-1", []string{"#FF0000", "#0000FF"}, 60, false); err != nil {
+		t.Fatalf("CreateSceneWithPalette failed: %v", err)
+	}
+	
+	posts := fb.RequestsFor("POST", "/clip/v2/resource/scene")
+	if len(posts) != 1 {
+		t.Fatalf("Expected one scene POST, got %d", len(posts))
+	}
+	body := posts[0].Body
+	
+	palette, _ := body["palette"].(map[string]interface{})
+	if colors, _ := palette["color"].([]interface{}); len(colors) != 2 {
+		t.Errorf("Expected 2 palette colors, got %v", palette)
+	}
+	if body["auto_dynamic"] != nil {
+		t.Errorf("Expected auto dynamic to be left off unless asked for, got %v", body["auto_dynamic"])
+	}
+	
+	actions, _ := body["actions"].([]interface{})
+	if len(actions) != 3 {
+		t.Fatalf("Expected an action per light, got %d", len(actions))
+	}
+	first := actions[0].(map[string]interface{})["action"].(map[string]interface{})["color"]
+	third := actions[2].(map[string]interface{})["action"].(map[string]interface{})["color"]
+	if fmt.Sprint(first) != fmt.Sprint(third) {
+		t.Errorf("Expected colors to repeat across lights, got %v and %v", first, third)
+	}
+	
+	if _, err := c.CreateSceneWithPalette(context.Background(), "Nebula", "group-1", []string{"#FF0000", "#0000FF"}, 60, true); err != nil {
+		t.Fatalf("CreateSceneWithPalette failed: %v", err)
+	}
+	if posts := fb.RequestsFor("POST", "/clip/v2/resource/scene"); len(posts) != 2 || posts[1].Body["auto_dynamic"] != true {
+		t.Errorf("Expected auto dynamic to be sent when asked for, got %v", posts)
+	}
+	
+	if _, err := c.CreateSceneWithPalette(context.Background(), "Too many", "group-1", make([]string, 10), 60, false); err == nil {
+		t.Error("Expected more than 9 colors to be rejected")
+	}
+}
+
+func TestCreateSceneFromCurrentStateCapturesColorTemperature(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("room", Room{
+		ID:       "room-1",
+		Type:     "room",
+		Services: []ResourceIdentifier{{RID: "group-1", RType: "grouped_light"}},
+		Children: []ResourceIdentifier{{RID: "device-1", RType: "device"}},
+	})
+	fb.Add("device", Device{ID: "device-1", Services: []ResourceIdentifier{
+		{RID: "light-ct", RType: "light"},
+		{RID: "light-color", RType: "light"},
+	}})
+	// A white light that reports its mode rather than mirek_valid, with a stale xy alongside
+	fb.Add("light", Light{
+		ID:               "light-ct",
+		On:               OnState{On: true},
+		Dimming:          Dimming{Brightness: 70},
+		Color:            &Color{XY: XY{X: 0.45, Y: 0.41}},
+		ColorTemperature: &ColorTemperature{Mirek: 366},
+		Mode:             "color_temperature",
+	})
+	fb.Add("light", Light{
+		ID:               "light-color",
+		On:               OnState{On: true},
+		Dimming:          Dimming{Brightness: 50},
+		Color:            &Color{XY: XY{X: 0.15, Y: 0.06}},
+		ColorTemperature: &ColorTemperature{Mirek: 0},
+		Mode:             "normal",
+	})
+	
+	if _, err := fb.client().CreateSceneFromCurrentState(context.Background(), "Evening", "group-1"); err != nil {
+		t.Fatalf("CreateSceneFromCurrentState failed: %v", err)
+	}
+	
+	posts := fb.RequestsFor("POST", "/clip/v2/resource/scene")
+	if len(posts) != 1 {
+		t.Fatalf("Expected one scene POST, got %d", len(posts))
+	}
+	
+	actions, _ := posts[0].Body["actions"].([]interface{})
+	if len(actions) != 2 {
+		t.Fatalf("Expected an action per light, got %d", len(actions))
+	}
+	for _, raw := range actions {
+		entry := raw.(map[string]interface{})
+		target := entry["target"].(map[string]interface{})["rid"]
+		action := entry["action"].(map[string]interface{})
+		_, hasColor := action["color"]
+		ct, hasCT := action["color_temperature"].(map[string]interface{})
+		
+		switch target {
+		case "light-ct":
+			if !hasCT || ct["mirek"] != float64(366) {
+				t.Errorf("Expected the white light to capture mirek 366, got %v", action)
+			}
+			if hasColor {
+				t.Errorf("Expected no color on the white light, got %v", action)
+			}
+		case "light-color":
+			if !hasColor {
+				t.Errorf("Expected the color light to capture its color, got %v", action)
+			}
+			if hasCT {
+				t.Errorf("Expected no color temperature on the color light, got %v", action)
+			}
+		default:
+			t.Errorf("Unexpected action target %v", target)
+		}
+	}
+}
+
+func newMoveLightBridge(t *testing.T) *fakeBridge {
+	fb := newFakeBridge(t)
+	fb.Add("room", Room{
+		ID:       "room-office",
+		Metadata: Metadata{Name: "Office"},
+		Services: []ResourceIdentifier{{RID: "group-office", RType: "grouped_light"}},
+		Children: []ResourceIdentifier{{RID: "device-1", RType: "device"}},
+	})
+	fb.Add("room", Room{
+		ID:       "room-den",
+		Metadata: Metadata{Name: "Den"},
+		Services: []ResourceIdentifier{{RID: "group-den", RType: "grouped_light"}},
+		Children: []ResourceIdentifier{{RID: "device-2", RType: "device"}},
+	})
+	fb.Add("device", Device{ID: "device-1", Services: []ResourceIdentifier{{RID: "light-1", RType: "light"}}})
+	fb.Add("device", Device{ID: "device-2", Services: []ResourceIdentifier{{RID: "light-2", RType: "light"}}})
+	return fb
+}
+
+func TestMoveLightToRoom(t *testing.T) {
+	fb := newMoveLightBridge(t)
+	
+	from, to, err := fb.client().MoveLightToRoom(context.Background(), "light-1", "group-den")
+	if err != nil {
+		t.Fatalf("MoveLightToRoom failed: %v", err)
+	}
+	if from == nil || from.Metadata.Name != "Office" || to.Metadata.Name != "Den" {
+		t.Fatalf("Expected a move from Office to Den, got %v to %v", from, to)
+	}
+	
+	office := fb.RequestsFor("PUT", "/clip/v2/resource/room/room-office")
+	if len(office) != 1 || fmt.Sprint(office[0].Body["children"]) != "[]" {
+		t.Errorf("Expected the device to be removed from the office, got %+v", office)
+	}
+	den := fb.RequestsFor("PUT", "/clip/v2/resource/room/room-den")
+	if len(den) != 1 || len(den[0].Body["children"].([]interface{})) != 2 {
+		t.Errorf("Expected the device to be added to the den, got %+v", den)
+	}
+}
+
+func TestMoveLightToRoomRollsBack(t *testing.T) {
+	fb := newMoveLightBridge(t)
+	fb.Fail("PUT", "/clip/v2/resource/room/room-den")
+	
+	_, _, err := fb.client().MoveLightToRoom(context.Background(), "light-1", "room-den")
+	if err == nil || !strings.Contains(err.Error(), "left it in Office") {
+		t.Fatalf("Expected the failed move to be rolled back, got %v", err)
+	}
+	
+	office := fb.RequestsFor("PUT", "/clip/v2/resource/room/room-office")
+	if len(office) != 2 {
+		t.Fatalf("Expected a removal and a restore for the office, got %d PUTs", len(office))
+	}
+	if children := office[1].Body["children"].([]interface{}); len(children) != 1 {
+		t.Errorf("Expected the office's children to be restored, got %v", children)
+	}
+}
+
+func TestApplySceneTemplate(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("room", Room{
+		ID:       "room-1",
+		Services: []ResourceIdentifier{{RID: "group-1", RType: "grouped_light"}},
+		Children: []ResourceIdentifier{{RID: "device-1", RType: "device"}},
+	})
+	fb.Add("device", Device{ID: "device-1", Services: []ResourceIdentifier{
+		{RID: "light-1", RType: "light"},
+		{RID: "light-2", RType: "light"},
+		{RID: "light-3", RType: "light"},
+	}})
+	fb.Add("scene", Scene{
+		ID:       "scene-palette",
+		Metadata: Metadata{Name: "Galaxy"},
+		Palette: &ScenePalette{
+			Color: []PaletteColor{
+				{Color: Color{XY: XY{X: 0.6, Y: 0.3}}},
+				{Color: Color{XY: XY{X: 0.2, Y: 0.1}}, Dimming: Dimming{Brightness: 20}},
+			},
+			Dimming: []Dimming{{Brightness: 70}},
+		},
+	})
+	fb.Add("scene", Scene{
+		ID:       "scene-plain",
+		Metadata: Metadata{Name: "Read"},
+		Actions: []SceneAction{
+			{Target: ResourceIdentifier{RID: "light-9", RType: "light"}, Action: LightUpdate{Dimming: &Dimming{Brightness: 90}}},
+		},
+	})
+	fb.Add("scene", Scene{ID: "scene-empty", Metadata: Metadata{Name: "Empty"}})
+	
+	c := fb.client()
+	if _, err := c.ApplySceneTemplate(context.Background(), "scene-palette", "group-1"); err != nil {
+		t.Fatalf("ApplySceneTemplate failed: %v", err)
+	}
+	
+	want := map[string]string{
+		"light-1": "map[xy:map[x:0.6 y:0.3]] map[brightness:70]",
+		"light-2": "map[xy:map[x:0.2 y:0.1]] map[brightness:20]",
+		"light-3": "map[xy:map[x:0.6 y:0.3]] map[brightness:70]",
+	}
+	for id, expected := range want {
+		puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/"+id)
+		if len(puts) != 1 {
+			t.Fatalf("Expected one update for %s, got %d", id, len(puts))
+		}
+		if got := fmt.Sprint(puts[0].Body["color"], " ", puts[0].Body["dimming"]); got != expected {
+			t.Errorf("%s: expected %s, got %s", id, expected, got)
+		}
+	}
+	
+	if _, err := c.ApplySceneTemplate(context.Background(), "scene-plain", "group-1"); err != nil {
+		t.Fatalf("ApplySceneTemplate without a palette failed: %v", err)
+	}
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-2"); len(puts) != 2 || fmt.Sprint(puts[1].Body["dimming"]) != "map[brightness:90]" {
+		t.Errorf("Expected the scene's action to be mapped onto light-2, got %+v", puts)
+	}
+	
+	if _, err := c.ApplySceneTemplate(context.Background(), "scene-empty", "group-1"); err == nil {
+		t.Error("Expected an error for a scene with nothing to apply")
+	}
+}
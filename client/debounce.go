@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// groupFlushTimeout bounds the combined PUT sent when a coalescing window closes
+const groupFlushTimeout = 10 * time.Second
+
+// groupDebouncer merges group updates arriving within a window into a single PUT
+type groupDebouncer struct {
+	client  *Client
+	window  time.Duration
+	mu      sync.Mutex
+	pending map[string]*pendingGroupUpdate
+}
+
+// pendingGroupUpdate is a merged update waiting for its window to close. done is closed
+// once it has been sent, after err holds the result for every caller that joined it.
+type pendingGroupUpdate struct {
+	update GroupUpdate
+	done   chan struct{}
+	err    error
+}
+
+// SetGroupCoalesceWindow buffers updates to the same group for the given window and sends
+// them as one combined request, avoiding visible stepping when on, brightness and color are
+// set in quick succession. While enabled, each group update waits for the window to close and
+// returns the combined request's result, so concurrent callers share it and every one of them
+// sees a failure. A window of zero (the default) disables coalescing.
+// Call this before the client is shared between goroutines.
+func (c *Client) SetGroupCoalesceWindow(window time.Duration) {
+	if c.groupDebounce != nil {
+		c.groupDebounce.Flush()
+	}
+
+	if window <= 0 {
+		c.groupDebounce = nil
+		return
+	}
+
+	c.groupDebounce = &groupDebouncer{
+		client:  c,
+		window:  window,
+		pending: make(map[string]*pendingGroupUpdate),
+	}
+}
+
+// FlushGroupUpdates sends any buffered group updates immediately
+func (c *Client) FlushGroupUpdates() {
+	if c.groupDebounce != nil {
+		c.groupDebounce.Flush()
+	}
+}
+
+// send merges an update into the pending update for a group, opening a window if none is
+// open, and waits for the combined request's result
+func (d *groupDebouncer) send(ctx context.Context, id string, update GroupUpdate) error {
+	pending := d.add(id, update)
+	select {
+	case <-pending.done:
+		return pending.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *groupDebouncer) add(id string, update GroupUpdate) *pendingGroupUpdate {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if pending, ok := d.pending[id]; ok {
+		mergeGroupUpdate(&pending.update, update)
+		return pending
+	}
+
+	pending := &pendingGroupUpdate{update: update, done: make(chan struct{})}
+	d.pending[id] = pending
+	time.AfterFunc(d.window, func() { d.flushGroup(id) })
+	return pending
+}
+
+// Flush sends every pending group update
+func (d *groupDebouncer) Flush() {
+	d.mu.Lock()
+	ids := make([]string, 0, len(d.pending))
+	for id := range d.pending {
+		ids = append(ids, id)
+	}
+	d.mu.Unlock()
+
+	for _, id := range ids {
+		d.flushGroup(id)
+	}
+}
+
+func (d *groupDebouncer) flushGroup(id string) {
+	d.mu.Lock()
+	pending, ok := d.pending[id]
+	delete(d.pending, id)
+	d.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), groupFlushTimeout)
+	defer cancel()
+
+	pending.err = d.client.sendGroupUpdate(ctx, id, pending.update)
+	close(pending.done)
+}
+
+// mergeGroupUpdate applies the fields set in next on top of base.
+// Color and color temperature are mutually exclusive, so setting one clears the other,
+// and the same goes for the two forms of effect.
+func mergeGroupUpdate(base *GroupUpdate, next GroupUpdate) {
+	if next.On != nil {
+		base.On = next.On
+	}
+	if next.Dimming != nil {
+		base.Dimming = next.Dimming
+	}
+	if next.Color != nil {
+		base.Color = next.Color
+		base.ColorTemperature = nil
+	}
+	if next.ColorTemperature != nil {
+		base.ColorTemperature = next.ColorTemperature
+		base.Color = nil
+	}
+	if next.Dynamics != nil {
+		base.Dynamics = next.Dynamics
+	}
+	if next.Effects != nil {
+		base.Effects = next.Effects
+		base.EffectsV2 = nil
+	}
+	if next.EffectsV2 != nil {
+		base.EffectsV2 = next.EffectsV2
+		base.Effects = nil
+	}
+	if next.Alert != nil {
+		base.Alert = next.Alert
+	}
+}
+
+// sendGroupUpdate PUTs a group update straight to the bridge
+func (c *Client) sendGroupUpdate(ctx context.Context, id string, update GroupUpdate) error {
+	_, err := c.put(ctx, fmt.Sprintf("/resource/grouped_light/%s", id), update)
+	return err
+}
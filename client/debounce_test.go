@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGroupUpdatesCoalesce(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("grouped_light", map[string]interface{}{"id": "group-1", "type": "grouped_light"})
+	c := fb.client()
+	c.SetGroupCoalesceWindow(50 * time.Millisecond)
+	
+	ctx := context.Background()
+	updates := []func() error{
+		func() error { return c.TurnOnGroup(ctx, "group-1") },
+		func() error { return c.SetGroupBrightness(ctx, "group-1", 40) },
+		func() error { return c.SetGroupColor(ctx, "group-1", "#FF0000") },
+	}
+	
+	// Each caller waits for the window to close, so the updates are sent concurrently
+	var wg sync.WaitGroup
+	errs := make([]error, len(updates))
+	for i, update := range updates {
+		wg.Add(1)
+		go func(i int, update func() error) {
+			defer wg.Done()
+			errs[i] = update()
+		}(i, update)
+	}
+	
+	time.Sleep(10 * time.Millisecond)
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/grouped_light/group-1"); len(puts) != 0 {
+		t.Fatalf("Expected no PUT before the window closes, got %d", len(puts))
+	}
+	
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Update %d failed: %v", i, err)
+		}
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/grouped_light/group-1")
+	if len(puts) != 1 {
+		t.Fatalf("Expected 1 combined PUT, got %d", len(puts))
+	}
+	
+	body := puts[0].Body
+	if on, ok := body["on"].(map[string]interface{}); !ok || on["on"] != true {
+		t.Errorf("Expected on=true in combined update, got %v", body["on"])
+	}
+	if dimming, ok := body["dimming"].(map[string]interface{}); !ok || dimming["brightness"] != 40.0 {
+		t.Errorf("Expected brightness 40 in combined update, got %v", body["dimming"])
+	}
+	if _, ok := body["color"]; !ok {
+		t.Error("Expected color in combined update")
+	}
+}
+
+func TestCoalescedGroupUpdateReportsFailure(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Fail("PUT", "/clip/v2/resource/grouped_light/group-1")
+	c := fb.client()
+	c.SetGroupCoalesceWindow(20 * time.Millisecond)
+	
+	if err := c.TurnOnGroup(context.Background(), "group-1"); err == nil {
+		t.Error("Expected the failed combined request to be reported to the caller")
+	}
+}
+
+func TestMergeGroupUpdateColorModes(t *testing.T) {
+	base := GroupUpdate{ColorTemperature: &ColorTemperature{Mirek: 300}}
+	mergeGroupUpdate(&base, GroupUpdate{Color: &Color{XY: XY{X: 0.6, Y: 0.3}}})
+	
+	if base.ColorTemperature != nil {
+		t.Error("Expected a later color to replace the color temperature")
+	}
+	if base.Color == nil {
+		t.Error("Expected color to be set")
+	}
+}
+
+func TestGroupUpdatesWithoutWindowSendImmediately(t *testing.T) {
+	fb := newFakeBridge(t)
+	c := fb.client()
+	
+	c.TurnOnGroup(context.Background(), "group-1")
+	c.SetGroupBrightness(context.Background(), "group-1", 40)
+	
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/grouped_light/group-1"); len(puts) != 2 {
+		t.Errorf("Expected 2 PUTs without coalescing, got %d", len(puts))
+	}
+}
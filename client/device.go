@@ -28,9 +28,9 @@ type ProductData struct {
 
 // PowerState represents device power information
 type PowerState struct {
-	PowerState       string  `json:"power_state"`
-	BatteryState     string  `json:"battery_state,omitempty"`
-	BatteryLevel     float64 `json:"battery_level,omitempty"`
+	PowerState       string   `json:"power_state"`
+	BatteryState     string   `json:"battery_state,omitempty"`
+	BatteryLevel     *float64 `json:"battery_level,omitempty"` // nil for devices that don't report a level
 }
 
 // GetDevices returns all devices
@@ -46,7 +46,7 @@ func (c *Client) GetDevices(ctx context.Context) ([]Device, error) {
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	return response.Data, nil
@@ -65,11 +65,11 @@ func (c *Client) GetDevice(ctx context.Context, id string) (*Device, error) {
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("device not found")
+		return nil, fmt.Errorf("device %w", ErrNotFound)
 	}
 	
 	return &response.Data[0], nil
@@ -84,4 +84,80 @@ func (c *Client) IdentifyDevice(ctx context.Context, id string) error {
 	}
 	_, err := c.put(ctx, fmt.Sprintf("/resource/device/%s", id), update)
 	return err
-}
\ No newline at end of file
+}
+
+// RenameDevice sets a device's name. The device name is what the Hue app shows for
+// a bulb, so this is usually the rename users want.
+func (c *Client) RenameDevice(ctx context.Context, id, name string) error {
+	update := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": name,
+		},
+	}
+	_, err := c.put(ctx, fmt.Sprintf("/resource/device/%s", id), update)
+	return err
+}
+
+// GetDevicePowers returns the battery state of all battery powered devices
+func (c *Client) GetDevicePowers(ctx context.Context) ([]DevicePower, error) {
+	var response struct {
+		Errors []Error       `json:"errors"`
+		Data   []DevicePower `json:"data"`
+	}
+	
+	err := c.getJSON(ctx, "/resource/device_power", &response)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(response.Errors) > 0 {
+		return nil, apiError(response.Errors)
+	}
+	
+	return response.Data, nil
+}
+
+// BridgeUpdateStatus reports the bridge's software version and whether an update is on the way
+type BridgeUpdateStatus struct {
+	SoftwareVersion string
+	State           string // As DeviceSoftwareUpdate.State, or "unknown" if the bridge doesn't report it
+	Problems        []string
+}
+
+// GetBridgeUpdateStatus returns the software update state of the bridge device
+func (c *Client) GetBridgeUpdateStatus(ctx context.Context) (*BridgeUpdateStatus, error) {
+	bridge, err := c.GetBridge(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	device, err := c.GetDevice(ctx, bridge.Owner.RID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bridge device: %w", err)
+	}
+	
+	var response struct {
+		Errors []Error                 `json:"errors"`
+		Data   []DeviceSoftwareUpdate `json:"data"`
+	}
+	
+	err = c.getJSON(ctx, "/resource/device_software_update", &response)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(response.Errors) > 0 {
+		return nil, apiError(response.Errors)
+	}
+	
+	status := &BridgeUpdateStatus{SoftwareVersion: device.ProductData.SoftwareVersion, State: "unknown"}
+	for _, update := range response.Data {
+		if update.Owner.RID == device.ID {
+			status.State = update.State
+			status.Problems = update.Problems
+			break
+		}
+	}
+	
+	return status, nil
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetDevicePowersParsesBatteryState(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("device_power", map[string]interface{}{
+		"id":    "power-1",
+		"type":  "device_power",
+		"owner": map[string]string{"rid": "device-1", "rtype": "device"},
+		"power_state": map[string]interface{}{
+			"battery_state": "low",
+			"battery_level": 12,
+		},
+	})
+	
+	powers, err := fb.client().GetDevicePowers(context.Background())
+	if err != nil {
+		t.Fatalf("GetDevicePowers failed: %v", err)
+	}
+	
+	if len(powers) != 1 {
+		t.Fatalf("Expected 1 device power, got %d", len(powers))
+	}
+	
+	power := powers[0]
+	if power.Owner.RID != "device-1" {
+		t.Errorf("Expected owner device-1, got %s", power.Owner.RID)
+	}
+	if level := power.PowerState.BatteryLevel; level == nil || *level != 12 || power.PowerState.BatteryState != "low" {
+		t.Errorf("Expected battery 12%% (low), got %v (%s)", level, power.PowerState.BatteryState)
+	}
+}
+
+func TestGetBridgeUpdateStatus(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("bridge", Bridge{ID: "bridge-1", Owner: ResourceIdentifier{RID: "device-bridge", RType: "device"}})
+	fb.Add("device", Device{ID: "device-bridge", ProductData: ProductData{SoftwareVersion: "1.66.1966060010"}})
+	fb.Add("device_software_update", DeviceSoftwareUpdate{ID: "swu-1", Owner: ResourceIdentifier{RID: "device-lamp", RType: "device"}, State: "installing"})
+	fb.Add("device_software_update", DeviceSoftwareUpdate{ID: "swu-2", Owner: ResourceIdentifier{RID: "device-bridge", RType: "device"}, State: "ready_to_install"})
+	
+	status, err := fb.client().GetBridgeUpdateStatus(context.Background())
+	if err != nil {
+		t.Fatalf("GetBridgeUpdateStatus failed: %v", err)
+	}
+	
+	if status.SoftwareVersion != "1.66.1966060010" {
+		t.Errorf("Expected the bridge's software version, got %q", status.SoftwareVersion)
+	}
+	if status.State != "ready_to_install" {
+		t.Errorf("Expected the bridge's own update state, got %q", status.State)
+	}
+}
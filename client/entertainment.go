@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"net"
+	"sort"
 	"sync"
 	"time"
 )
@@ -24,6 +25,14 @@ type Entertainment struct {
 	LightServices    []ResourceIdentifier      `json:"light_services"`
 }
 
+// Validate checks that the configuration has channels to stream to
+func (e *Entertainment) Validate() error {
+	if len(e.Channels) == 0 {
+		return fmt.Errorf("entertainment configuration '%s' (%s) has no channels; add lights to it in the Hue app before streaming", e.Metadata.Name, e.ID)
+	}
+	return nil
+}
+
 // StreamProxy contains streaming proxy information
 type StreamProxy struct {
 	Mode string `json:"mode"`
@@ -75,7 +84,7 @@ func (c *Client) GetEntertainmentConfigurations(ctx context.Context) ([]Entertai
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	return response.Data, nil
@@ -94,16 +103,74 @@ func (c *Client) GetEntertainmentConfiguration(ctx context.Context, id string) (
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("entertainment configuration not found")
+		return nil, fmt.Errorf("entertainment configuration %w", ErrNotFound)
 	}
 	
 	return &response.Data[0], nil
 }
 
+// StreamingApplication describes the app or client holding an entertainment stream.
+// Depending on bridge firmware the auth_v1 resource carries an app and/or device name.
+type StreamingApplication struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	AppName    string    `json:"app_name,omitempty"`
+	DeviceName string    `json:"device_name,omitempty"`
+	Metadata   *Metadata `json:"metadata,omitempty"`
+}
+
+// DisplayName returns the most descriptive name available for the application
+func (a *StreamingApplication) DisplayName() string {
+	switch {
+	case a.AppName != "" && a.DeviceName != "":
+		return fmt.Sprintf("%s on %s", a.AppName, a.DeviceName)
+	case a.AppName != "":
+		return a.AppName
+	case a.DeviceName != "":
+		return a.DeviceName
+	case a.Metadata != nil && a.Metadata.Name != "":
+		return a.Metadata.Name
+	default:
+		return a.ID
+	}
+}
+
+// GetStreamingApplication resolves an active streamer reference to the application behind it
+func (c *Client) GetStreamingApplication(ctx context.Context, streamer ResourceIdentifier) (*StreamingApplication, error) {
+	var response struct {
+		Errors []Error                `json:"errors"`
+		Data   []StreamingApplication `json:"data"`
+	}
+	
+	err := c.getJSON(ctx, fmt.Sprintf("/resource/%s/%s", streamer.RType, streamer.RID), &response)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(response.Errors) > 0 {
+		return nil, apiError(response.Errors)
+	}
+	
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("streaming application %w", ErrNotFound)
+	}
+	
+	return &response.Data[0], nil
+}
+
+// DescribeActiveStreamer returns a readable name for an active streamer, falling back to its ID
+func (c *Client) DescribeActiveStreamer(ctx context.Context, streamer ResourceIdentifier) string {
+	app, err := c.GetStreamingApplication(ctx, streamer)
+	if err != nil || app.DisplayName() == app.ID {
+		return fmt.Sprintf("%s %s", streamer.RType, streamer.RID)
+	}
+	return fmt.Sprintf("%s (%s %s)", app.DisplayName(), streamer.RType, streamer.RID)
+}
+
 // StartEntertainment starts entertainment mode
 func (c *Client) StartEntertainment(ctx context.Context, id string) error {
 	update := map[string]interface{}{
@@ -133,6 +200,59 @@ type EntertainmentStreamer struct {
 	updateRate    time.Duration
 	stopChan      chan struct{}
 	sequence      uint8
+	serviceLights map[string]string // entertainment service ID -> light ID
+	easing        time.Duration     // Time taken to tween toward new colors, zero sends them as-is
+	channels      map[int]*channelEase
+	audio         *audioAnalyzer    // Non-nil while in audio-reactive mode
+	stats         StreamStats
+	lastColors    map[int]RGB       // Colors last sent per channel, repeated by the keep-alive
+}
+
+// StreamStats describes how many packets a streamer has sent and how quickly
+type StreamStats struct {
+	Running      bool
+	StartedAt    time.Time
+	PacketsSent  uint64
+	SendErrors   uint64
+	LastSend     time.Time
+	LastError    string
+	EffectiveFPS float64 // Packets per second since streaming started
+}
+
+// channelEase tracks a channel's progress from its previous color toward its target
+type channelEase struct {
+	from   RGB
+	to     RGB
+	frame  int
+	frames int
+}
+
+// current returns the eased color for the channel's current frame
+func (c *channelEase) current() RGB {
+	if c.frame >= c.frames {
+		return c.to
+	}
+	
+	t := float64(c.frame) / float64(c.frames)
+	t = t * t * (3 - 2*t) // smoothstep
+	return RGB{
+		Red:   lerpUint16(c.from.Red, c.to.Red, t),
+		Green: lerpUint16(c.from.Green, c.to.Green, t),
+		Blue:  lerpUint16(c.from.Blue, c.to.Blue, t),
+	}
+}
+
+// RGB is a 16-bit per channel color used for streaming
+type RGB struct {
+	Red   uint16
+	Green uint16
+	Blue  uint16
+}
+
+// entertainmentService is the per-device entertainment service referenced by channel members
+type entertainmentService struct {
+	ID                string              `json:"id"`
+	RendererReference *ResourceIdentifier `json:"renderer_reference,omitempty"`
 }
 
 // EntertainmentUpdate represents a color update for streaming
@@ -163,18 +283,22 @@ func (e *EntertainmentStreamer) Start(ctx context.Context) error {
 		return fmt.Errorf("streamer already running")
 	}
 
-	// Start entertainment mode on the bridge
-	err := e.client.StartEntertainment(ctx, e.configID)
+	// Get entertainment configuration, refusing empty ones before touching the bridge
+	config, err := e.client.GetEntertainmentConfiguration(ctx, e.configID)
 	if err != nil {
-		return fmt.Errorf("failed to start entertainment mode: %w", err)
+		return fmt.Errorf("failed to get entertainment config: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return err
 	}
 
-	// Get entertainment configuration
-	config, err := e.client.GetEntertainmentConfiguration(ctx, e.configID)
+	// Start entertainment mode on the bridge
+	err = e.client.StartEntertainment(ctx, e.configID)
 	if err != nil {
-		return fmt.Errorf("failed to get entertainment config: %w", err)
+		return fmt.Errorf("failed to start entertainment mode: %w", err)
 	}
 	e.config = config
+	e.serviceLights = e.client.getEntertainmentServiceLights(ctx)
 
 	// Connect UDP socket
 	bridgeAddr, err := net.ResolveUDPAddr("udp", fmt.Sprintf("%s:2100", e.client.bridgeIP))
@@ -187,7 +311,12 @@ func (e *EntertainmentStreamer) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to connect UDP socket: %w", err)
 	}
 
+	// A restarted session starts fresh rather than repeating or easing from the last one's colors
 	e.running = true
+	e.stats = StreamStats{StartedAt: time.Now()}
+	e.stopChan = make(chan struct{})
+	e.lastColors = nil
+	e.channels = nil
 	
 	// Start the streaming loop
 	go e.streamingLoop()
@@ -224,18 +353,89 @@ func (e *EntertainmentStreamer) SetUpdateRate(rate time.Duration) {
 	e.updateRate = rate
 }
 
-// SendColors sends color updates to the entertainment lights
+// SetEasing makes the streaming loop tween each channel toward newly sent colors over the
+// given duration instead of jumping to them. Zero disables easing.
+func (e *EntertainmentStreamer) SetEasing(duration time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.easing = duration
+}
+
+// SendColors sends color updates to the entertainment lights.
+// With easing enabled the colors become targets that the streaming loop moves toward.
 func (e *EntertainmentStreamer) SendColors(updates []EntertainmentUpdate) error {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	e.mu.Lock()
+	defer e.mu.Unlock()
 
 	if !e.running {
 		return fmt.Errorf("streamer not running")
 	}
 
+	if e.easing > 0 {
+		if e.config == nil {
+			return fmt.Errorf("no entertainment configuration loaded")
+		}
+		e.setTargets(e.resolveChannelColors(updates))
+		return nil
+	}
+
 	return e.sendUDPPacket(updates)
 }
 
+// SendGradient distributes colors across the segments of a gradient light.
+// Segments are the channels whose members reference the light, ordered by member index.
+func (e *EntertainmentStreamer) SendGradient(lightID string, colors []RGB) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.running {
+		return fmt.Errorf("streamer not running")
+	}
+
+	if len(colors) == 0 {
+		return fmt.Errorf("no colors provided")
+	}
+
+	segments := e.lightSegments(lightID)
+	if len(segments) == 0 {
+		return fmt.Errorf("light %s is not part of the entertainment configuration", lightID)
+	}
+
+	if e.easing > 0 {
+		e.setTargets(distributeGradient(segments, colors))
+		return nil
+	}
+
+	return e.writeChannelColors(distributeGradient(segments, colors))
+}
+
+// GetSegments returns the channel IDs addressing a light, ordered by segment index
+func (e *EntertainmentStreamer) GetSegments(lightID string) []int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.lightSegments(lightID)
+}
+
+// GetStats returns packet counts and the effective frame rate of the stream
+func (e *EntertainmentStreamer) GetStats() StreamStats {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	
+	stats := e.stats
+	stats.Running = e.running
+	if !stats.StartedAt.IsZero() && stats.PacketsSent > 0 {
+		if elapsed := stats.LastSend.Sub(stats.StartedAt).Seconds(); elapsed > 0 {
+			stats.EffectiveFPS = float64(stats.PacketsSent) / elapsed
+		}
+	}
+	return stats
+}
+
+// Done returns a channel that is closed when the streamer is stopped
+func (e *EntertainmentStreamer) Done() <-chan struct{} {
+	return e.stopChan
+}
+
 // GetLights returns the lights in the entertainment configuration
 func (e *EntertainmentStreamer) GetLights() []ResourceIdentifier {
 	e.mu.RLock()
@@ -258,20 +458,109 @@ func (e *EntertainmentStreamer) streamingLoop() {
 		case <-e.stopChan:
 			return
 		case <-ticker.C:
-			// Send keep-alive packet
-			e.sendUDPPacket([]EntertainmentUpdate{})
+			e.tick()
 		}
 	}
 }
 
+// tick sends the next audio or eased frame, or a keep-alive packet when neither is active
+func (e *EntertainmentStreamer) tick() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.audio != nil && e.config != nil {
+		return e.writePacket(e.buildPacket(e.audioFrame()))
+	}
+
+	if e.easing <= 0 || len(e.channels) == 0 {
+		// Send keep-alive packet repeating the last colors
+		return e.sendUDPPacket([]EntertainmentUpdate{})
+	}
+
+	channelColors := make(map[int]RGB, len(e.channels))
+	for channelID, state := range e.channels {
+		if state.frame < state.frames {
+			state.frame++
+		}
+		channelColors[channelID] = state.current()
+	}
+
+	return e.writePacket(e.buildPacket(channelColors))
+}
+
+// setTargets starts easing each channel from its current color toward a new target.
+// The caller must hold e.mu.
+func (e *EntertainmentStreamer) setTargets(channelColors map[int]RGB) {
+	if e.channels == nil {
+		e.channels = make(map[int]*channelEase)
+	}
+
+	frames := 1
+	if e.updateRate > 0 {
+		frames = max(1, int(e.easing/e.updateRate))
+	}
+
+	for channelID, target := range channelColors {
+		var from RGB
+		if state, ok := e.channels[channelID]; ok {
+			from = state.current()
+		}
+		e.channels[channelID] = &channelEase{from: from, to: target, frames: frames}
+	}
+}
+
 // sendUDPPacket sends a UDP packet with color data
 func (e *EntertainmentStreamer) sendUDPPacket(updates []EntertainmentUpdate) error {
 	if e.config == nil {
 		return fmt.Errorf("no entertainment configuration loaded")
 	}
 
-	// Build entertainment protocol packet
-	packet := make([]byte, 0, 1024)
+	return e.writeChannelColors(e.resolveChannelColors(updates))
+}
+
+// writeChannelColors merges colors into the last sent colors and streams the result,
+// so channels not mentioned keep their color instead of going dark. The caller must hold e.mu.
+func (e *EntertainmentStreamer) writeChannelColors(channelColors map[int]RGB) error {
+	if e.lastColors == nil {
+		e.lastColors = make(map[int]RGB, len(channelColors))
+	}
+	for channelID, color := range channelColors {
+		e.lastColors[channelID] = color
+	}
+	
+	return e.writePacket(e.buildPacket(e.lastColors))
+}
+
+// resolveChannelColors maps light updates onto the channels whose members render those lights
+func (e *EntertainmentStreamer) resolveChannelColors(updates []EntertainmentUpdate) map[int]RGB {
+	// Create color data map
+	colorData := make(map[string]EntertainmentUpdate)
+	for _, update := range updates {
+		colorData[update.LightID] = update
+	}
+	
+	// Resolve each channel's color from the light its members belong to
+	channelColors := make(map[int]RGB)
+	for _, channel := range e.config.Channels {
+		for _, member := range channel.Members {
+			update, exists := colorData[member.Service.RID]
+			if !exists {
+				update, exists = colorData[e.serviceLights[member.Service.RID]]
+			}
+			if exists {
+				channelColors[channel.ChannelID] = RGB{Red: update.Red, Green: update.Green, Blue: update.Blue}
+				break
+			}
+		}
+	}
+	
+	return channelColors
+}
+
+// buildPacket builds an entertainment protocol packet with one entry per channel.
+// Channels missing from channelColors are sent as off.
+func (e *EntertainmentStreamer) buildPacket(channelColors map[int]RGB) []byte {
+	packet := make([]byte, 0, 16+len(e.config.Channels)*8)
 	
 	// Header: "HueStream" (9 bytes)
 	packet = append(packet, []byte("HueStream")...)
@@ -292,51 +581,121 @@ func (e *EntertainmentStreamer) sendUDPPacket(updates []EntertainmentUpdate) err
 	// Reserved (1 byte)
 	packet = append(packet, 0x00)
 	
-	// Create color data map
-	colorData := make(map[string]EntertainmentUpdate)
-	for _, update := range updates {
-		colorData[update.LightID] = update
+	// Add color data for each channel
+	for _, channel := range e.config.Channels {
+		color := channelColors[channel.ChannelID]
+		
+		// Channel ID (2 bytes)
+		packet = binary.LittleEndian.AppendUint16(packet, uint16(channel.ChannelID))
+		
+		// RGB values (6 bytes total - 2 bytes each)
+		packet = binary.LittleEndian.AppendUint16(packet, color.Red)
+		packet = binary.LittleEndian.AppendUint16(packet, color.Green)
+		packet = binary.LittleEndian.AppendUint16(packet, color.Blue)
 	}
 	
-	// Add color data for each channel
+	return packet
+}
+
+// writePacket sends a packet and records it in the stream stats. The caller must hold e.mu.
+func (e *EntertainmentStreamer) writePacket(packet []byte) error {
+	if e.conn == nil {
+		return fmt.Errorf("streamer not connected")
+	}
+	_, err := e.conn.Write(packet)
+	if err != nil {
+		e.stats.SendErrors++
+		e.stats.LastError = err.Error()
+		return err
+	}
+	e.stats.PacketsSent++
+	e.stats.LastSend = time.Now()
+	return nil
+}
+
+// lightSegments returns the channels addressing a light ordered by member index
+func (e *EntertainmentStreamer) lightSegments(lightID string) []int {
+	if e.config == nil {
+		return nil
+	}
+
+	type segment struct {
+		channelID int
+		index     int
+	}
+	var segments []segment
+	
 	for _, channel := range e.config.Channels {
 		for _, member := range channel.Members {
-			lightID := member.Service.RID
-			
-			update, exists := colorData[lightID]
-			if !exists {
-				// Default to off
-				update = EntertainmentUpdate{
-					LightID: lightID,
-					Red:     0,
-					Green:   0,
-					Blue:    0,
-				}
+			if member.Service.RID == lightID || e.serviceLights[member.Service.RID] == lightID {
+				segments = append(segments, segment{channelID: channel.ChannelID, index: member.Index})
+				break
 			}
-			
-			// Channel ID (2 bytes)
-			channelBytes := make([]byte, 2)
-			binary.LittleEndian.PutUint16(channelBytes, uint16(channel.ChannelID))
-			packet = append(packet, channelBytes...)
-			
-			// RGB values (6 bytes total - 2 bytes each)
-			redBytes := make([]byte, 2)
-			greenBytes := make([]byte, 2)
-			blueBytes := make([]byte, 2)
-			
-			binary.LittleEndian.PutUint16(redBytes, update.Red)
-			binary.LittleEndian.PutUint16(greenBytes, update.Green)
-			binary.LittleEndian.PutUint16(blueBytes, update.Blue)
-			
-			packet = append(packet, redBytes...)
-			packet = append(packet, greenBytes...)
-			packet = append(packet, blueBytes...)
 		}
 	}
 	
-	// Send packet
-	_, err := e.conn.Write(packet)
-	return err
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].index < segments[j].index
+	})
+	
+	channelIDs := make([]int, len(segments))
+	for i, seg := range segments {
+		channelIDs[i] = seg.channelID
+	}
+	return channelIDs
+}
+
+// distributeGradient spreads colors evenly across segments, interpolating between neighbours
+func distributeGradient(segments []int, colors []RGB) map[int]RGB {
+	result := make(map[int]RGB, len(segments))
+	
+	for i, channelID := range segments {
+		if len(colors) == 1 || len(segments) == 1 {
+			result[channelID] = colors[0]
+			continue
+		}
+		
+		pos := float64(i) / float64(len(segments)-1) * float64(len(colors)-1)
+		lower := int(pos)
+		if lower >= len(colors)-1 {
+			result[channelID] = colors[len(colors)-1]
+			continue
+		}
+		
+		t := pos - float64(lower)
+		a, b := colors[lower], colors[lower+1]
+		result[channelID] = RGB{
+			Red:   lerpUint16(a.Red, b.Red, t),
+			Green: lerpUint16(a.Green, b.Green, t),
+			Blue:  lerpUint16(a.Blue, b.Blue, t),
+		}
+	}
+	
+	return result
+}
+
+func lerpUint16(a, b uint16, t float64) uint16 {
+	return uint16(float64(a) + (float64(b)-float64(a))*t + 0.5)
+}
+
+// getEntertainmentServiceLights maps entertainment service IDs to the lights they render
+func (c *Client) getEntertainmentServiceLights(ctx context.Context) map[string]string {
+	var response struct {
+		Errors []Error                `json:"errors"`
+		Data   []entertainmentService `json:"data"`
+	}
+	
+	lights := make(map[string]string)
+	if err := c.getJSON(ctx, "/resource/entertainment", &response); err != nil {
+		return lights
+	}
+	
+	for _, svc := range response.Data {
+		if svc.RendererReference != nil && svc.RendererReference.RType == "light" {
+			lights[svc.ID] = svc.RendererReference.RID
+		}
+	}
+	return lights
 }
 
 // Helper functions for color conversion
@@ -0,0 +1,392 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestStreamer returns a running streamer that writes packets to a local UDP listener
+func newTestStreamer(t *testing.T, config *Entertainment) (*EntertainmentStreamer, *net.UDPConn) {
+	listener, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	
+	conn, err := net.DialUDP("udp", nil, listener.LocalAddr().(*net.UDPAddr))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	
+	return &EntertainmentStreamer{
+		conn:       conn,
+		configID:   config.ID,
+		config:     config,
+		running:    true,
+		updateRate: 50 * time.Millisecond,
+		stopChan:   make(chan struct{}),
+	}, listener
+}
+
+func readPacket(t *testing.T, listener *net.UDPConn) []byte {
+	buf := make([]byte, 2048)
+	listener.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, err := listener.Read(buf)
+	if err != nil {
+		t.Fatalf("failed to read packet: %v", err)
+	}
+	return buf[:n]
+}
+
+func gradientStripConfig(segments int) *Entertainment {
+	config := &Entertainment{ID: "ent-1"}
+	// Channels are deliberately listed in reverse index order
+	for i := segments - 1; i >= 0; i-- {
+		config.Channels = append(config.Channels, EntertainmentChannel{
+			ChannelID: i,
+			Members: []ChannelMember{
+				{Service: ResourceIdentifier{RID: "ent-svc-strip", RType: "entertainment"}, Index: i},
+			},
+		})
+	}
+	return config
+}
+
+func TestSendGradientSevenSegments(t *testing.T) {
+	config := gradientStripConfig(7)
+	streamer, listener := newTestStreamer(t, config)
+	streamer.serviceLights = map[string]string{"ent-svc-strip": "light-strip"}
+	
+	colors := make([]RGB, 7)
+	for i := range colors {
+		colors[i] = RGB{Red: uint16(i * 1000), Green: uint16(i * 100), Blue: uint16(i)}
+	}
+	
+	if err := streamer.SendGradient("light-strip", colors); err != nil {
+		t.Fatalf("SendGradient failed: %v", err)
+	}
+	
+	packet := readPacket(t, listener)
+	
+	if string(packet[:9]) != "HueStream" {
+		t.Fatalf("Expected HueStream header, got %q", packet[:9])
+	}
+	
+	if packet[9] != 0x02 || packet[10] != 0x00 {
+		t.Errorf("Expected API version 2.0, got %d.%d", packet[9], packet[10])
+	}
+	
+	if packet[14] != 0x01 {
+		t.Errorf("Expected RGB color mode, got %d", packet[14])
+	}
+	
+	const headerLen = 16
+	const entryLen = 8
+	if len(packet) != headerLen+7*entryLen {
+		t.Fatalf("Expected packet length %d, got %d", headerLen+7*entryLen, len(packet))
+	}
+	
+	for i := 0; i < 7; i++ {
+		entry := packet[headerLen+i*entryLen:]
+		channelID := int(binary.LittleEndian.Uint16(entry[0:2]))
+		
+		// Entries follow configuration order, which lists channel 6 first
+		if channelID != 6-i {
+			t.Errorf("Entry %d: expected channel %d, got %d", i, 6-i, channelID)
+		}
+		
+		want := colors[channelID]
+		got := RGB{
+			Red:   binary.LittleEndian.Uint16(entry[2:4]),
+			Green: binary.LittleEndian.Uint16(entry[4:6]),
+			Blue:  binary.LittleEndian.Uint16(entry[6:8]),
+		}
+		if got != want {
+			t.Errorf("Channel %d: expected %v, got %v", channelID, want, got)
+		}
+	}
+}
+
+func TestDistributeGradientInterpolates(t *testing.T) {
+	segments := []int{10, 11, 12, 13, 14}
+	colors := []RGB{{Red: 0}, {Red: 65535}}
+	
+	result := distributeGradient(segments, colors)
+	
+	expected := []uint16{0, 16384, 32768, 49151, 65535}
+	for i, channelID := range segments {
+		if diff := int(result[channelID].Red) - int(expected[i]); diff > 1 || diff < -1 {
+			t.Errorf("Segment %d: expected red ~%d, got %d", i, expected[i], result[channelID].Red)
+		}
+	}
+}
+
+func TestSendGradientUnknownLight(t *testing.T) {
+	streamer, _ := newTestStreamer(t, gradientStripConfig(3))
+	
+	if err := streamer.SendGradient("missing", []RGB{{Red: 1}}); err == nil {
+		t.Error("Expected error for light outside the configuration")
+	}
+}
+
+func TestDescribeActiveStreamer(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("auth_v1", StreamingApplication{ID: "app-1", Type: "auth_v1", AppName: "Hue Sync", DeviceName: "Living Room PC"})
+	c := fb.client()
+	
+	got := c.DescribeActiveStreamer(context.Background(), ResourceIdentifier{RID: "app-1", RType: "auth_v1"})
+	if got != "Hue Sync on Living Room PC (auth_v1 app-1)" {
+		t.Errorf("Expected streamer resolved to its app name, got %q", got)
+	}
+	
+	got = c.DescribeActiveStreamer(context.Background(), ResourceIdentifier{RID: "unknown", RType: "auth_v1"})
+	if got != "auth_v1 unknown" {
+		t.Errorf("Expected unknown streamer to fall back to its ID, got %q", got)
+	}
+}
+
+func TestStartRejectsConfigWithoutChannels(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("entertainment_configuration", Entertainment{ID: "ent-empty", Metadata: Metadata{Name: "Empty Area"}})
+	
+	streamer, _ := NewEntertainmentStreamer(fb.client(), "ent-empty")
+	err := streamer.Start(context.Background())
+	if err == nil {
+		t.Fatal("Expected an error starting a configuration with no channels")
+	}
+	if !strings.Contains(err.Error(), "has no channels") {
+		t.Errorf("Expected a clear no-channels error, got: %v", err)
+	}
+	
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/entertainment_configuration/"); len(puts) != 0 {
+		t.Errorf("Expected the bridge not to enter streaming mode, got %d PUTs", len(puts))
+	}
+}
+
+func TestEasingMovesMonotonicallyTowardTarget(t *testing.T) {
+	config := &Entertainment{ID: "ent-1", Channels: []EntertainmentChannel{
+		{ChannelID: 0, Members: []ChannelMember{{Service: ResourceIdentifier{RID: "light-1", RType: "light"}}}},
+	}}
+	streamer, listener := newTestStreamer(t, config)
+	streamer.SetEasing(250 * time.Millisecond) // 5 frames at 50ms
+	
+	channelColor := func(packet []byte) RGB {
+		entry := packet[16:]
+		return RGB{
+			Red:   binary.LittleEndian.Uint16(entry[2:4]),
+			Green: binary.LittleEndian.Uint16(entry[4:6]),
+			Blue:  binary.LittleEndian.Uint16(entry[6:8]),
+		}
+	}
+	
+	frames := func(n int) []RGB {
+		var colors []RGB
+		for i := 0; i < n; i++ {
+			if err := streamer.tick(); err != nil {
+				t.Fatalf("tick failed: %v", err)
+			}
+			colors = append(colors, channelColor(readPacket(t, listener)))
+		}
+		return colors
+	}
+	
+	// Ease up from off toward red
+	if err := streamer.SendColors([]EntertainmentUpdate{{LightID: "light-1", Red: 60000}}); err != nil {
+		t.Fatalf("SendColors failed: %v", err)
+	}
+	
+	prev := RGB{}
+	for i, color := range frames(5) {
+		if color.Red < prev.Red {
+			t.Errorf("Frame %d: red moved away from target (%d -> %d)", i, prev.Red, color.Red)
+		}
+		if i < 4 && color.Red >= 60000 {
+			t.Errorf("Frame %d: expected an intermediate value, got %d", i, color.Red)
+		}
+		prev = color
+	}
+	if prev.Red != 60000 {
+		t.Fatalf("Expected final frame to reach the target, got %d", prev.Red)
+	}
+	
+	// Holding at the target once reached
+	if held := frames(1)[0]; held.Red != 60000 {
+		t.Errorf("Expected channel to hold its target, got %d", held.Red)
+	}
+	
+	// A new target eases from the previous color rather than from black
+	streamer.SendColors([]EntertainmentUpdate{{LightID: "light-1", Blue: 40000}})
+	
+	prev = RGB{Red: 60000}
+	for i, color := range frames(5) {
+		if color.Red > prev.Red || color.Blue < prev.Blue {
+			t.Errorf("Frame %d: expected red to fall and blue to rise, got %v after %v", i, color, prev)
+		}
+		prev = color
+	}
+	if prev != (RGB{Blue: 40000}) {
+		t.Errorf("Expected final frame to reach the new target, got %v", prev)
+	}
+}
+
+func TestAudioColorsSingleChannelMixesBands(t *testing.T) {
+	colors := AudioColors(AudioBands{Bass: 1, Mid: 0.5, Treble: 0}, []int{3})
+	
+	got := colors[3]
+	if got.Red != 65535 || got.Green != 32768 || got.Blue != 0 {
+		t.Errorf("Expected bass red and half mid green, got %+v", got)
+	}
+}
+
+func TestAudioColorsDealsBandsAcrossChannels(t *testing.T) {
+	// Channel IDs are unordered to check the bands follow channel ID order
+	colors := AudioColors(AudioBands{Bass: 1, Mid: 0, Treble: 0}, []int{5, 0, 2, 1})
+	if len(colors) != 4 {
+		t.Fatalf("Expected a color for every channel, got %d", len(colors))
+	}
+	
+	// Bass drives channels 0 and 5 (first and fourth), mid and treble are silent
+	for _, channelID := range []int{0, 5} {
+		if colors[channelID].Red != 65535 {
+			t.Errorf("Expected bass channel %d at full red, got %+v", channelID, colors[channelID])
+		}
+	}
+	for _, channelID := range []int{1, 2} {
+		if colors[channelID] != (RGB{}) {
+			t.Errorf("Expected silent band channel %d to be off, got %+v", channelID, colors[channelID])
+		}
+	}
+	
+	colors = AudioColors(AudioBands{Treble: 1}, []int{0, 1, 2})
+	if colors[2].Blue != 65535 || colors[0] != (RGB{}) {
+		t.Errorf("Expected treble only on the third channel, got %+v", colors)
+	}
+}
+
+func TestAudioAnalyzerSplitsBands(t *testing.T) {
+	var a audioAnalyzer
+	
+	// A sustained loud signal builds bass while transients fade
+	for i := 0; i < 100; i++ {
+		a.push(0.8)
+	}
+	if a.bands.Bass < 0.9 {
+		t.Errorf("Expected sustained level to build bass, got %.2f", a.bands.Bass)
+	}
+	if a.bands.Treble != 0 {
+		t.Errorf("Expected no treble from a steady signal, got %.2f", a.bands.Treble)
+	}
+	
+	// A sharp spike registers as treble
+	a.push(0)
+	if a.bands.Treble < 0.9 {
+		t.Errorf("Expected a sharp drop to register as treble, got %.2f", a.bands.Treble)
+	}
+	
+	bass := a.bands.Bass
+	a.decay()
+	if a.bands.Bass >= bass {
+		t.Errorf("Expected bands to decay without new samples")
+	}
+}
+
+func TestAudioReactiveTickRendersPushedLevels(t *testing.T) {
+	config := &Entertainment{ID: "ent-1", Channels: []EntertainmentChannel{
+		{ChannelID: 0, Members: []ChannelMember{{Service: ResourceIdentifier{RID: "light-1", RType: "light"}}}},
+	}}
+	streamer, listener := newTestStreamer(t, config)
+	
+	if err := streamer.PushAudioLevel(0.5); err == nil {
+		t.Fatal("Expected pushing audio to fail before audio-reactive mode is enabled")
+	}
+	
+	streamer.SetAudioReactive(true)
+	if err := streamer.PushAudioBands(AudioBands{Bass: 1, Treble: 1}); err != nil {
+		t.Fatalf("PushAudioBands failed: %v", err)
+	}
+	if err := streamer.tick(); err != nil {
+		t.Fatalf("tick failed: %v", err)
+	}
+	
+	entry := readPacket(t, listener)[16:]
+	red := binary.LittleEndian.Uint16(entry[2:4])
+	blue := binary.LittleEndian.Uint16(entry[6:8])
+	if red != 65535 || blue != 65535 {
+		t.Errorf("Expected bass red and treble blue on the only channel, got red %d blue %d", red, blue)
+	}
+	
+	// Without new samples the next frame is dimmer
+	streamer.tick()
+	entry = readPacket(t, listener)[16:]
+	if binary.LittleEndian.Uint16(entry[2:4]) >= red {
+		t.Error("Expected the frame to decay without new audio")
+	}
+}
+
+func TestGetStatsCountsPackets(t *testing.T) {
+	config := &Entertainment{ID: "ent-1", Channels: []EntertainmentChannel{
+		{ChannelID: 0, Members: []ChannelMember{{Service: ResourceIdentifier{RID: "light-1", RType: "light"}}}},
+	}}
+	streamer, listener := newTestStreamer(t, config)
+	streamer.stats.StartedAt = time.Now().Add(-time.Second)
+	
+	if stats := streamer.GetStats(); stats.PacketsSent != 0 || stats.EffectiveFPS != 0 {
+		t.Fatalf("Expected empty stats before sending, got %+v", stats)
+	}
+	
+	for i := 0; i < 3; i++ {
+		if err := streamer.SendColors([]EntertainmentUpdate{{LightID: "light-1", Red: 100}}); err != nil {
+			t.Fatalf("SendColors failed: %v", err)
+		}
+		readPacket(t, listener)
+	}
+	
+	stats := streamer.GetStats()
+	if !stats.Running || stats.PacketsSent != 3 {
+		t.Errorf("Expected 3 packets on a running stream, got %+v", stats)
+	}
+	if stats.LastSend.IsZero() {
+		t.Error("Expected the last send time to be recorded")
+	}
+	if stats.EffectiveFPS <= 0 || stats.EffectiveFPS > 4 {
+		t.Errorf("Expected roughly 3 packets per second, got %.2f", stats.EffectiveFPS)
+	}
+}
+
+func TestKeepAliveRepeatsLastColors(t *testing.T) {
+	config := &Entertainment{ID: "ent-1", Channels: []EntertainmentChannel{
+		{ChannelID: 0, Members: []ChannelMember{{Service: ResourceIdentifier{RID: "light-1", RType: "light"}}}},
+		{ChannelID: 1, Members: []ChannelMember{{Service: ResourceIdentifier{RID: "light-2", RType: "light"}}}},
+	}}
+	streamer, listener := newTestStreamer(t, config)
+	
+	if err := streamer.SendColors([]EntertainmentUpdate{
+		{LightID: "light-1", Red: 50000, Green: 1000},
+		{LightID: "light-2", Blue: 40000},
+	}); err != nil {
+		t.Fatalf("SendColors failed: %v", err)
+	}
+	sent := readPacket(t, listener)
+	
+	if err := streamer.tick(); err != nil {
+		t.Fatalf("tick failed: %v", err)
+	}
+	keepAlive := readPacket(t, listener)
+	
+	// Everything after the header and sequence number must match
+	if string(keepAlive[12:]) != string(sent[12:]) {
+		t.Errorf("Expected keep-alive to repeat the colors\nsent: %v\ngot:  %v", sent[12:], keepAlive[12:])
+	}
+	
+	// Updating one light leaves the other at its last color
+	streamer.SendColors([]EntertainmentUpdate{{LightID: "light-1", Green: 30000}})
+	entries := readPacket(t, listener)[16:]
+	if blue := binary.LittleEndian.Uint16(entries[8+6 : 8+8]); blue != 40000 {
+		t.Errorf("Expected light-2 to keep its blue, got %d", blue)
+	}
+}
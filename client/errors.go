@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for the failures callers most often need to tell apart. Check with errors.Is.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrTimeout      = errors.New("request to the bridge timed out")
+)
+
+// APIError is an error reported by the bridge, either as an HTTP error status or in a
+// response's errors list. StatusCode is zero for errors reported in a successful response.
+type APIError struct {
+	StatusCode  int
+	Type        string
+	Address     string
+	Description string
+}
+
+func (e *APIError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Description)
+	}
+	return fmt.Sprintf("API error: %s", e.Description)
+}
+
+// Is matches the sentinel errors by HTTP status, falling back to the bridge's description
+// for errors reported in a successful response
+func (e *APIError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return target == ErrUnauthorized
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	case 0:
+		description := strings.ToLower(e.Description)
+		switch target {
+		case ErrNotFound:
+			return strings.Contains(description, "not found") || strings.Contains(description, "not available")
+		case ErrUnauthorized:
+			return strings.Contains(description, "unauthorized")
+		}
+	}
+	return false
+}
+
+// timeoutError marks a failed request as timed out when the deadline or the HTTP client's
+// timeout was hit, so it isn't reported as a generic connection failure
+func timeoutError(err error) error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return err
+}
+
+// apiError wraps the first error in a response's errors list
+func apiError(errs []Error) error {
+	return &APIError{Type: errs[0].Type, Address: errs[0].Address, Description: errs[0].Description}
+}
+
+// httpError builds the error for an HTTP error status, using the bridge's own error
+// description when the body carries one
+func httpError(status int, body []byte) error {
+	err := &APIError{StatusCode: status, Description: string(body)}
+
+	var response struct {
+		Errors []Error `json:"errors"`
+	}
+	if json.Unmarshal(body, &response) == nil && len(response.Errors) > 0 {
+		err.Type = response.Errors[0].Type
+		err.Address = response.Errors[0].Address
+		err.Description = response.Errors[0].Description
+	}
+	return err
+}
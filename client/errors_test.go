@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTypedHTTPErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		want   error
+	}{
+		{http.StatusNotFound, `{"errors":[{"description":"Not Found"}],"data":[]}`, ErrNotFound},
+		{http.StatusTooManyRequests, "Too Many Requests", ErrRateLimited},
+		{http.StatusUnauthorized, "Unauthorized", ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			w.Write([]byte(tt.body))
+		}))
+
+		client := NewClient(strings.TrimPrefix(server.URL, "https://"), "test-key", server.Client())
+		client.SetRetryPolicy(0, time.Millisecond)
+
+		_, err := client.GetLight(context.Background(), "light-1")
+		server.Close()
+
+		if !errors.Is(err, tt.want) {
+			t.Errorf("HTTP %d: expected errors.Is(%v), got %v", tt.status, tt.want, err)
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+			t.Errorf("HTTP %d: expected an APIError with the status, got %#v", tt.status, err)
+		}
+	}
+}
+
+func TestTypedResponseErrors(t *testing.T) {
+	err := apiError([]Error{{Type: "3", Address: "/lights/9", Description: "resource, /lights/9, not available"}})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a missing resource to match ErrNotFound, got %v", err)
+	}
+	if errors.Is(err, ErrRateLimited) {
+		t.Error("Expected a missing resource not to match ErrRateLimited")
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Address != "/lights/9" {
+		t.Errorf("Expected the bridge's error address to be kept, got %#v", err)
+	}
+}
+
+func TestTimeoutErrors(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(strings.TrimPrefix(server.URL, "https://"), "test-key", server.Client())
+	client.SetTimeout(20 * time.Millisecond)
+
+	_, err := client.GetLight(context.Background(), "light-1")
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("Expected an HTTP client timeout to match ErrTimeout, got %v", err)
+	}
+
+	client.SetTimeout(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = client.GetLight(ctx, "light-1")
+	if !errors.Is(err, ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected an expired deadline to match ErrTimeout and context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -4,10 +4,11 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"strings"
-	"time"
 )
 
 // EventStream represents a connection to the Hue event stream
@@ -16,9 +17,11 @@ type EventStream struct {
 	events    chan Event
 	errors    chan error
 	done      chan bool
-	reconnect bool
 }
 
+// ErrStreamClosed is reported when the bridge ends the event stream connection
+var ErrStreamClosed = errors.New("event stream closed by bridge")
+
 // Event represents a Hue v2 event
 type Event struct {
 	CreationTime string      `json:"creationtime"`
@@ -62,14 +65,29 @@ type EventData struct {
 	Alert *Alert `json:"alert,omitempty"`
 }
 
-// StreamEvents creates a new event stream connection
+// DecodeError reports an event stream message that could not be parsed.
+// The stream skips the bad event and carries on.
+type DecodeError struct {
+	Data string
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to parse event: %v", e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// StreamEvents creates a new event stream connection. The stream does not reconnect:
+// its channels close when the connection drops, and callers start a new stream.
 func (c *Client) StreamEvents(ctx context.Context) (*EventStream, error) {
 	stream := &EventStream{
 		client:    c,
 		events:    make(chan Event, 100),
 		errors:    make(chan error, 10),
 		done:      make(chan bool),
-		reconnect: true,
 	}
 	
 	go stream.connect(ctx)
@@ -89,38 +107,17 @@ func (es *EventStream) Errors() <-chan error {
 
 // Close stops the event stream
 func (es *EventStream) Close() {
-	es.reconnect = false
 	close(es.done)
 }
 
-// connect establishes and maintains the SSE connection
+// connect runs the SSE connection once. When it drops, the error is reported and both
+// channels are closed; reconnecting is left to the caller.
 func (es *EventStream) connect(ctx context.Context) {
 	defer close(es.events)
 	defer close(es.errors)
 	
-	for es.reconnect {
-		select {
-		case <-ctx.Done():
-			return
-		case <-es.done:
-			return
-		default:
-			err := es.streamEvents(ctx)
-			if err != nil {
-				es.errors <- fmt.Errorf("stream error: %w", err)
-				if es.reconnect {
-					// Wait before reconnecting
-					select {
-					case <-time.After(5 * time.Second):
-						continue
-					case <-ctx.Done():
-						return
-					case <-es.done:
-						return
-					}
-				}
-			}
-		}
+	if err := es.streamEvents(ctx); err != nil && ctx.Err() == nil {
+		es.reportError(fmt.Errorf("stream error: %w", err))
 	}
 }
 
@@ -185,15 +182,20 @@ func (es *EventStream) streamEvents(ctx context.Context) error {
 		return err
 	}
 	
-	return nil
+	select {
+	case <-es.done:
+		return nil
+	default:
+		return ErrStreamClosed
+	}
 }
 
-// processEvent parses and sends an event
+// processEvent parses and sends the events in one message.
+// Events that fail to decode are reported and skipped rather than dropping the rest.
 func (es *EventStream) processEvent(data string) {
-	var events []Event
-	if err := json.Unmarshal([]byte(data), &events); err != nil {
-		es.errors <- fmt.Errorf("failed to parse event: %w", err)
-		return
+	events, errs := decodeEvents(data)
+	for _, err := range errs {
+		es.reportError(&DecodeError{Data: data, Err: err})
 	}
 	
 	for _, event := range events {
@@ -210,6 +212,50 @@ func (es *EventStream) processEvent(data string) {
 	}
 }
 
+// reportError passes an error to the error channel without blocking the stream.
+// When nobody is draining the channel the error is logged instead of being lost.
+func (es *EventStream) reportError(err error) {
+	select {
+	case es.errors <- err:
+	default:
+		log.Printf("Event stream error (error channel full): %v", err)
+	}
+}
+
+// decodeEvents parses a JSON array of events one element at a time so that a malformed
+// event only loses itself. A truncated array keeps the events before the break.
+func decodeEvents(data string) ([]Event, []error) {
+	dec := json.NewDecoder(strings.NewReader(data))
+	
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, []error{err}
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, []error{fmt.Errorf("expected an array of events, got %v", tok)}
+	}
+	
+	var events []Event
+	var errs []error
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			// The rest of the message can't be located reliably
+			errs = append(errs, err)
+			break
+		}
+		
+		var event Event
+		if err := json.Unmarshal(raw, &event); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		events = append(events, event)
+	}
+	
+	return events, errs
+}
+
 // FilterEvents creates a filtered event stream
 func (es *EventStream) FilterEvents(types ...string) <-chan Event {
 	filtered := make(chan Event, 100)
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProcessEventSkipsBadEvents(t *testing.T) {
+	es := &EventStream{
+		events: make(chan Event, 10),
+		errors: make(chan error, 10),
+	}
+	
+	// The middle event has a data field of the wrong type
+	es.processEvent(`[{"id":"evt-1","type":"update","data":[]},{"id":"evt-2","type":"update","data":"oops"},{"id":"evt-3","type":"update","data":[]}]`)
+	
+	if len(es.events) != 2 {
+		t.Fatalf("Expected 2 good events, got %d", len(es.events))
+	}
+	if first, second := <-es.events, <-es.events; first.ID != "evt-1" || second.ID != "evt-3" {
+		t.Errorf("Expected evt-1 and evt-3, got %s and %s", first.ID, second.ID)
+	}
+	
+	var decodeErr *DecodeError
+	if err := <-es.errors; !errors.As(err, &decodeErr) {
+		t.Errorf("Expected a DecodeError, got %v", err)
+	}
+}
+
+func TestProcessEventLogsDroppedErrors(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	
+	es := &EventStream{
+		events: make(chan Event, 10),
+		errors: make(chan error, 1),
+	}
+	es.errors <- errors.New("earlier error")
+	
+	es.processEvent(`[{"id":"evt-1","type":"update","data":"oops"}]`)
+	
+	if !strings.Contains(logged.String(), "error channel full") || !strings.Contains(logged.String(), "failed to parse event") {
+		t.Errorf("Expected the dropped decode error to be logged, got: %q", logged.String())
+	}
+}
+
+func TestDecodeEventsTruncated(t *testing.T) {
+	events, errs := decodeEvents(`[{"id":"evt-1","type":"update","data":[]},{"id":"evt-2","ty`)
+	
+	if len(events) != 1 || events[0].ID != "evt-1" {
+		t.Errorf("Expected the event before the truncation to survive, got %+v", events)
+	}
+	if len(errs) != 1 {
+		t.Errorf("Expected 1 decode error, got %d", len(errs))
+	}
+	
+	if _, errs := decodeEvents(`not json`); len(errs) != 1 {
+		t.Errorf("Expected garbage to produce a single error, got %v", errs)
+	}
+}
+
+func TestEventStreamClosesWhenConnectionDrops(t *testing.T) {
+	var connections int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&connections, 1)
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: [{"id":"evt-1","type":"update","data":[]}]`+"\n\n")
+	}))
+	defer server.Close()
+	
+	c := NewClient(strings.TrimPrefix(server.URL, "https://"), "test-key", server.Client())
+	stream, err := c.StreamEvents(context.Background())
+	if err != nil {
+		t.Fatalf("StreamEvents failed: %v", err)
+	}
+	defer stream.Close()
+	
+	if event := <-stream.Events(); event.ID != "evt-1" {
+		t.Errorf("Expected evt-1, got %+v", event)
+	}
+	if err := <-stream.Errors(); !errors.Is(err, ErrStreamClosed) {
+		t.Errorf("Expected ErrStreamClosed, got %v", err)
+	}
+	
+	select {
+	case _, ok := <-stream.Events():
+		if ok {
+			t.Error("Expected the events channel to close after the drop")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the events channel to close")
+	}
+	
+	// Reconnecting is the caller's job
+	if n := atomic.LoadInt32(&connections); n != 1 {
+		t.Errorf("Expected a single connection, got %d", n)
+	}
+}
@@ -0,0 +1,24 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/kungfusheep/hue/internal/fakebridge"
+)
+
+// fakeBridge is the shared in-memory bridge with a client for this package's tests
+type fakeBridge struct {
+	*fakebridge.Bridge
+}
+
+func newFakeBridge(t *testing.T) *fakeBridge {
+	return &fakeBridge{fakebridge.New(t)}
+}
+
+// client returns a Client pointed at the fake bridge, without rate limits or retries
+func (fb *fakeBridge) client() *Client {
+	c := NewClient(fb.Host(), "test-key", fb.HTTPClient())
+	c.SetRateLimits(0, 0)
+	c.SetRetryPolicy(0, 0)
+	return c
+}
@@ -6,8 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Client represents a Philips Hue v2 API client
@@ -16,18 +20,48 @@ type Client struct {
 	username   string
 	httpClient *http.Client
 	baseURL    string
+
+	groupDebounce *groupDebouncer
+
+	maxRetries     int
+	retryBaseDelay time.Duration
+
+	lightLimiter *tokenBucket
+	groupLimiter *tokenBucket
+
+	cache *responseCache
+
+	lightGamuts sync.Map // light ID -> gamut type, which never changes for a light
 }
 
+// Default retry policy for rate-limited (429) and unavailable (503) responses
+const (
+	defaultMaxRetries     = 3
+	defaultRetryBaseDelay = 100 * time.Millisecond
+	maxRetryDelay         = 5 * time.Second
+)
+
 // NewClient creates a new Hue v2 API client
 func NewClient(bridgeIP, username string, httpClient *http.Client) *Client {
 	return &Client{
-		bridgeIP:   bridgeIP,
-		username:   username,
-		httpClient: httpClient,
-		baseURL:    fmt.Sprintf("https://%s/clip/v2", bridgeIP),
+		bridgeIP:       bridgeIP,
+		username:       username,
+		httpClient:     httpClient,
+		baseURL:        fmt.Sprintf("https://%s/clip/v2", bridgeIP),
+		maxRetries:     defaultMaxRetries,
+		retryBaseDelay: defaultRetryBaseDelay,
+		lightLimiter:   newTokenBucket(defaultLightRate),
+		groupLimiter:   newTokenBucket(defaultGroupRate),
 	}
 }
 
+// SetRetryPolicy sets how many times a 429 or 503 response is retried and the initial
+// backoff, which doubles on each attempt. Zero retries disables retrying.
+func (c *Client) SetRetryPolicy(maxRetries int, baseDelay time.Duration) {
+	c.maxRetries = maxRetries
+	c.retryBaseDelay = baseDelay
+}
+
 // TestConnection verifies the connection to the Hue bridge
 func (c *Client) TestConnection(ctx context.Context) error {
 	// Try to get the bridge configuration
@@ -48,9 +82,12 @@ func (c *Client) GetLights(ctx context.Context) ([]Light, error) {
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
+	for i := range response.Data {
+		c.rememberGamut(&response.Data[i])
+	}
 	return response.Data, nil
 }
 
@@ -67,16 +104,50 @@ func (c *Client) GetLight(ctx context.Context, id string) (*Light, error) {
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("light not found")
+		return nil, fmt.Errorf("light %w", ErrNotFound)
 	}
 	
+	c.rememberGamut(&response.Data[0])
 	return &response.Data[0], nil
 }
 
+// rememberGamut records a light's gamut type for color conversion
+func (c *Client) rememberGamut(light *Light) {
+	gamutType := ""
+	if light.Color != nil {
+		gamutType = light.Color.GamutType
+	}
+	c.lightGamuts.Store(light.ID, gamutType)
+}
+
+// lightGamutType returns a light's gamut type, fetching the light the first time it is needed.
+// Lights without color, or that cannot be fetched, return "" so colors are sent unclamped.
+func (c *Client) lightGamutType(ctx context.Context, id string) string {
+	if gamutType, ok := c.lightGamuts.Load(id); ok {
+		return gamutType.(string)
+	}
+	light, err := c.GetLight(ctx, id)
+	if err != nil || light.Color == nil {
+		return ""
+	}
+	return light.Color.GamutType
+}
+
+// RenameLight sets the name of a light service, leaving its owning device unchanged
+func (c *Client) RenameLight(ctx context.Context, id, name string) error {
+	update := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": name,
+		},
+	}
+	_, err := c.put(ctx, fmt.Sprintf("/resource/light/%s", id), update)
+	return err
+}
+
 // UpdateLight updates a light's state
 func (c *Client) UpdateLight(ctx context.Context, id string, update LightUpdate) error {
 	_, err := c.put(ctx, fmt.Sprintf("/resource/light/%s", id), update)
@@ -96,7 +167,7 @@ func (c *Client) GetGroups(ctx context.Context) ([]Group, error) {
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	return response.Data, nil
@@ -115,20 +186,22 @@ func (c *Client) GetGroup(ctx context.Context, id string) (*Group, error) {
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("group not found")
+		return nil, fmt.Errorf("group %w", ErrNotFound)
 	}
 	
 	return &response.Data[0], nil
 }
 
-// UpdateGroup updates a group's state
+// UpdateGroup updates a group's state, merging it with nearby updates when a coalesce window is set
 func (c *Client) UpdateGroup(ctx context.Context, id string, update GroupUpdate) error {
-	_, err := c.put(ctx, fmt.Sprintf("/resource/grouped_light/%s", id), update)
-	return err
+	if c.groupDebounce != nil {
+		return c.groupDebounce.send(ctx, id, update)
+	}
+	return c.sendGroupUpdate(ctx, id, update)
 }
 
 // GetScenes returns all scenes
@@ -144,7 +217,7 @@ func (c *Client) GetScenes(ctx context.Context) ([]Scene, error) {
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	return response.Data, nil
@@ -152,15 +225,62 @@ func (c *Client) GetScenes(ctx context.Context) ([]Scene, error) {
 
 // ActivateScene activates a scene
 func (c *Client) ActivateScene(ctx context.Context, id string) error {
+	return c.ActivateSceneWithOptions(ctx, id, nil, nil)
+}
+
+// Scene recall actions
+const (
+	SceneRecallActive  = "active"          // Recall the scene's stored states
+	SceneRecallDynamic = "dynamic_palette" // Slowly cycle through the scene's palette
+)
+
+// SceneRecall holds the options for recalling a scene. Nil overrides keep the stored values.
+type SceneRecall struct {
+	Action     string   // SceneRecallActive (default) or SceneRecallDynamic
+	Brightness *float64 // 0-100
+	DurationMs *int
+}
+
+// ActivateSceneWithOptions activates a scene, optionally overriding its brightness (0-100)
+// and transition duration. Nil overrides recall the scene as stored.
+func (c *Client) ActivateSceneWithOptions(ctx context.Context, id string, brightness *float64, durationMs *int) error {
+	return c.RecallScene(ctx, id, SceneRecall{Brightness: brightness, DurationMs: durationMs})
+}
+
+// RecallScene recalls a scene with the given action and overrides
+func (c *Client) RecallScene(ctx context.Context, id string, options SceneRecall) error {
+	action := options.Action
+	if action == "" {
+		action = SceneRecallActive
+	}
+	
+	recall := map[string]interface{}{
+		"action": action,
+	}
+	if options.Brightness != nil {
+		recall["dimming"] = map[string]interface{}{
+			"brightness": *options.Brightness,
+		}
+	}
+	if options.DurationMs != nil {
+		recall["duration"] = *options.DurationMs
+	}
+	
 	update := map[string]interface{}{
-		"recall": map[string]interface{}{
-			"action": "active",
-		},
+		"recall": recall,
 	}
 	_, err := c.put(ctx, fmt.Sprintf("/resource/scene/%s", id), update)
 	return err
 }
 
+// HasDynamicPalette reports whether the scene has a palette to cycle through in dynamic mode
+func (s *Scene) HasDynamicPalette() bool {
+	if s.Palette == nil {
+		return false
+	}
+	return len(s.Palette.Color)+len(s.Palette.ColorTemperature) > 1
+}
+
 // CreateScene creates a new scene
 func (c *Client) CreateScene(ctx context.Context, scene SceneCreate) (*Scene, error) {
 	var response struct {
@@ -180,7 +300,7 @@ func (c *Client) CreateScene(ctx context.Context, scene SceneCreate) (*Scene, er
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	if len(response.Data) == 0 {
@@ -204,16 +324,44 @@ func (c *Client) GetScene(ctx context.Context, id string) (*Scene, error) {
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("scene not found")
+		return nil, fmt.Errorf("scene %w", ErrNotFound)
 	}
 	
 	return &response.Data[0], nil
 }
 
+// GetActiveScene returns the scene currently active in a room or zone, given its ID or its
+// grouped_light ID. It returns nil when no scene is active, e.g. the lights were set by hand.
+func (c *Client) GetActiveScene(ctx context.Context, groupID string) (*Scene, error) {
+	owner, _, err := c.resolveGroupOwner(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	
+	scenes, err := c.GetScenes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	// If several report active, the most recently recalled wins
+	var active *Scene
+	for i := range scenes {
+		scene := &scenes[i]
+		if scene.Group.RID != owner.RID || scene.Status == nil || scene.Status.Active == "" || scene.Status.Active == "inactive" {
+			continue
+		}
+		if active == nil || scene.Status.LastRecall > active.Status.LastRecall {
+			active = scene
+		}
+	}
+	
+	return active, nil
+}
+
 // GetBridge returns bridge information
 func (c *Client) GetBridge(ctx context.Context) (*Bridge, error) {
 	var response struct {
@@ -227,11 +375,11 @@ func (c *Client) GetBridge(ctx context.Context) (*Bridge, error) {
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("bridge not found")
+		return nil, fmt.Errorf("bridge %w", ErrNotFound)
 	}
 	
 	return &response.Data[0], nil
@@ -240,7 +388,21 @@ func (c *Client) GetBridge(ctx context.Context) (*Bridge, error) {
 // HTTP helper methods
 
 func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
-	return c.request(ctx, "GET", path, nil)
+	if c.cache == nil || !cachedPaths[path] {
+		return c.request(ctx, "GET", path, nil)
+	}
+	
+	body, generation, ok := c.cache.lookup(path)
+	if ok {
+		return body, nil
+	}
+	
+	body, err := c.request(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.store(path, body, generation)
+	return body, nil
 }
 
 func (c *Client) getJSON(ctx context.Context, path string, result interface{}) error {
@@ -266,41 +428,87 @@ func (c *Client) delete(ctx context.Context, path string) ([]byte, error) {
 func (c *Client) request(ctx context.Context, method, path string, data interface{}) ([]byte, error) {
 	url := c.baseURL + path
 	
-	var body io.Reader
+	// Any change on the bridge may alter cached collections
+	if method != "GET" && c.cache != nil {
+		defer c.cache.invalidate()
+	}
+	
+	var jsonData []byte
 	if data != nil {
-		jsonData, err := json.Marshal(data)
+		var err error
+		jsonData, err = json.Marshal(data)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request: %w", err)
 		}
-		body = bytes.NewReader(jsonData)
 	}
 	
-	req, err := http.NewRequestWithContext(ctx, method, url, body)
-	if err != nil {
-		return nil, err
-	}
+	limiter := c.limiterFor(ctx, method, path)
 	
-	req.Header.Set("hue-application-key", c.username)
-	if data != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
+	for attempt := 0; ; attempt++ {
+		// Keep within the bridge's command rate
+		if err := limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+		
+		var body io.Reader
+		if data != nil {
+			body = bytes.NewReader(jsonData)
+		}
+		
+		req, err := http.NewRequestWithContext(ctx, method, url, body)
+		if err != nil {
+			return nil, err
+		}
+		
+		req.Header.Set("hue-application-key", c.username)
+		if data != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, timeoutError(err)
+		}
+		
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, timeoutError(err)
+		}
+		
+		// Back off and retry when the bridge is throttling or briefly unavailable
+		if isRetryableStatus(resp.StatusCode) && attempt < c.maxRetries {
+			select {
+			case <-time.After(c.retryDelay(attempt, resp.Header.Get("Retry-After"))):
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		
+		if resp.StatusCode >= 400 {
+			return nil, httpError(resp.StatusCode, respBody)
+		}
+		
+		return respBody, nil
 	}
-	defer resp.Body.Close()
-	
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// retryDelay returns the exponential backoff for an attempt, honoring a Retry-After in seconds
+func (c *Client) retryDelay(attempt int, retryAfter string) time.Duration {
+	delay := c.retryBaseDelay << attempt
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds > 0 {
+		delay = time.Duration(seconds) * time.Second
 	}
 	
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
 	}
-	
-	return respBody, nil
+	return delay
 }
 
 // Helper methods for common operations
@@ -326,19 +534,43 @@ func (c *Client) SetLightBrightness(ctx context.Context, id string, brightness f
 	})
 }
 
-// SetLightColor sets a light's color from hex string
+// SetLightColor sets a light's color from hex string, clamped to the light's color gamut
 func (c *Client) SetLightColor(ctx context.Context, id string, hexColor string) error {
-	x, y := hexToXY(hexColor)
 	return c.UpdateLight(ctx, id, LightUpdate{
-		Color: &Color{XY: XY{X: x, Y: y}},
+		Color: &Color{XY: c.LightColorXY(ctx, id, hexColor)},
+	})
+}
+
+// LightColorXY converts a hex color to xy coordinates within a light's color gamut
+func (c *Client) LightColorXY(ctx context.Context, id string, hexColor string) XY {
+	x, y := hexToXYForGamut(hexColor, c.lightGamutType(ctx, id))
+	return XY{X: x, Y: y}
+}
+
+// SetLightColorXY sets a light's color from CIE xy coordinates
+func (c *Client) SetLightColorXY(ctx context.Context, id string, xy XY) error {
+	return c.UpdateLight(ctx, id, LightUpdate{
+		Color: &Color{XY: xy},
+	})
+}
+
+// SetLightColorTemperature sets a light's color temperature in mirek (153-500)
+func (c *Client) SetLightColorTemperature(ctx context.Context, id string, mirek int) error {
+	return c.UpdateLight(ctx, id, LightUpdate{
+		ColorTemperature: &ColorTemperature{Mirek: mirek},
 	})
 }
 
 // SetLightEffect sets a light's effect
 func (c *Client) SetLightEffect(ctx context.Context, id string, effect string, duration int) error {
-	update := LightUpdate{
-		Effects: &Effects{Effect: effect},
-	}
+	return c.SetLightEffectWithParameters(ctx, id, effect, duration, nil)
+}
+
+// SetLightEffectWithParameters sets a light's effect, tuned by params when they are given.
+// A non-zero duration in seconds is sent as the bridge's transition time; it does not end the effect.
+func (c *Client) SetLightEffectWithParameters(ctx context.Context, id string, effect string, duration int, params *EffectParameters) error {
+	update := LightUpdate{}
+	update.Effects, update.EffectsV2 = effectUpdate(effect, params)
 	
 	if duration > 0 {
 		update.Dynamics = &Dynamics{Duration: duration * 1000} // Convert to milliseconds
@@ -347,6 +579,15 @@ func (c *Client) SetLightEffect(ctx context.Context, id string, effect string, d
 	return c.UpdateLight(ctx, id, update)
 }
 
+// effectUpdate uses the plain effects object unless there are parameters, which only
+// effects_v2 can carry
+func effectUpdate(effect string, params *EffectParameters) (*Effects, *EffectsV2) {
+	if params == nil {
+		return &Effects{Effect: effect}, nil
+	}
+	return nil, &EffectsV2{Action: EffectAction{Effect: effect, Parameters: params}}
+}
+
 // TurnOnGroup turns a group on
 func (c *Client) TurnOnGroup(ctx context.Context, id string) error {
 	return c.UpdateGroup(ctx, id, GroupUpdate{
@@ -361,6 +602,42 @@ func (c *Client) TurnOffGroup(ctx context.Context, id string) error {
 	})
 }
 
+// TurnOffAllLights turns off every light, returning how many groups were switched
+func (c *Client) TurnOffAllLights(ctx context.Context) (int, error) {
+	return c.setAllLights(ctx, false)
+}
+
+// TurnOnAllLights turns on every light, returning how many groups were switched
+func (c *Client) TurnOnAllLights(ctx context.Context) (int, error) {
+	return c.setAllLights(ctx, true)
+}
+
+// setAllLights uses the bridge-wide group when available, falling back to each group in turn
+func (c *Client) setAllLights(ctx context.Context, on bool) (int, error) {
+	groups, err := c.GetGroups(ctx)
+	if err != nil {
+		return 0, err
+	}
+	
+	update := GroupUpdate{On: &OnState{On: on}}
+	
+	for _, group := range groups {
+		if group.Owner != nil && group.Owner.RType == "bridge_home" {
+			if err := c.UpdateGroup(ctx, group.ID, update); err != nil {
+				return 0, err
+			}
+			return 1, nil
+		}
+	}
+	
+	for i, group := range groups {
+		if err := c.UpdateGroup(ctx, group.ID, update); err != nil {
+			return i, err
+		}
+	}
+	return len(groups), nil
+}
+
 // SetGroupBrightness sets a group's brightness (0-100)
 func (c *Client) SetGroupBrightness(ctx context.Context, id string, brightness float64) error {
 	return c.UpdateGroup(ctx, id, GroupUpdate{
@@ -368,7 +645,8 @@ func (c *Client) SetGroupBrightness(ctx context.Context, id string, brightness f
 	})
 }
 
-// SetGroupColor sets a group's color from hex string
+// SetGroupColor sets a group's color from hex string. The color is not clamped, since members
+// can have different gamuts and the bridge fits the color to each light.
 func (c *Client) SetGroupColor(ctx context.Context, id string, hexColor string) error {
 	x, y := hexToXY(hexColor)
 	return c.UpdateGroup(ctx, id, GroupUpdate{
@@ -376,11 +654,30 @@ func (c *Client) SetGroupColor(ctx context.Context, id string, hexColor string)
 	})
 }
 
+// SetGroupColorXY sets a group's color from CIE xy coordinates
+func (c *Client) SetGroupColorXY(ctx context.Context, id string, xy XY) error {
+	return c.UpdateGroup(ctx, id, GroupUpdate{
+		Color: &Color{XY: xy},
+	})
+}
+
+// SetGroupColorTemperature sets a group's color temperature in mirek (153-500)
+func (c *Client) SetGroupColorTemperature(ctx context.Context, id string, mirek int) error {
+	return c.UpdateGroup(ctx, id, GroupUpdate{
+		ColorTemperature: &ColorTemperature{Mirek: mirek},
+	})
+}
+
 // SetGroupEffect sets a group's effect
 func (c *Client) SetGroupEffect(ctx context.Context, id string, effect string, duration int) error {
-	update := GroupUpdate{
-		Effects: &Effects{Effect: effect},
-	}
+	return c.SetGroupEffectWithParameters(ctx, id, effect, duration, nil)
+}
+
+// SetGroupEffectWithParameters sets a group's effect, tuned by params when they are given.
+// A non-zero duration in seconds is sent as the bridge's transition time; it does not end the effect.
+func (c *Client) SetGroupEffectWithParameters(ctx context.Context, id string, effect string, duration int, params *EffectParameters) error {
+	update := GroupUpdate{}
+	update.Effects, update.EffectsV2 = effectUpdate(effect, params)
 	
 	if duration > 0 {
 		update.Dynamics = &Dynamics{Duration: duration * 1000} // Convert to milliseconds
@@ -423,7 +720,16 @@ func (c *Client) GetAllSupportedEffects(ctx context.Context) ([]string, error) {
 
 // Color conversion helpers
 
+// HexToXY converts a hex color to CIE xy coordinates
+func HexToXY(hex string) (float64, float64) {
+	return hexToXY(hex)
+}
+
 func hexToXY(hex string) (float64, float64) {
+	return hexToXYForGamut(hex, "")
+}
+
+func convertHexToXY(hex string) (float64, float64) {
 	// Remove # if present
 	hex = strings.TrimPrefix(hex, "#")
 	
@@ -473,11 +779,76 @@ func hexToXY(hex string) (float64, float64) {
 	return x, y
 }
 
-func pow(base, exp float64) float64 {
-	// Simple power function
-	result := 1.0
-	for i := 0; i < int(exp); i++ {
-		result *= base
+// XYToHex converts CIE xy coordinates and a brightness (0-100) to an approximate hex color
+func XYToHex(x, y, brightness float64) string {
+	return xyToHex(x, y, brightness)
+}
+
+func xyToHex(x, y, brightness float64) string {
+	if y <= 0 {
+		return "#000000"
 	}
-	return result
-}
\ No newline at end of file
+	
+	// Convert xy to XYZ at full luminance, brightness is applied after normalising
+	Y := 1.0
+	X := (Y / y) * x
+	Z := (Y / y) * (1 - x - y)
+	
+	// Convert to linear RGB using the inverse sRGB matrix
+	r := X*3.2404542 - Y*1.5371385 - Z*0.4985314
+	g := -X*0.9692660 + Y*1.8760108 + Z*0.0415560
+	b := X*0.0556434 - Y*0.2040259 + Z*1.0572252
+	
+	// Colors outside the sRGB gamut come back negative
+	r = math.Max(r, 0)
+	g = math.Max(g, 0)
+	b = math.Max(b, 0)
+	
+	// Scale so the brightest channel is at full intensity
+	max := math.Max(r, math.Max(g, b))
+	if max == 0 {
+		return "#000000"
+	}
+	r /= max
+	g /= max
+	b /= max
+	
+	// Reverse gamma correction
+	r = gammaCompress(r)
+	g = gammaCompress(g)
+	b = gammaCompress(b)
+	
+	scale := math.Max(0, math.Min(brightness, 100)) / 100
+	
+	return fmt.Sprintf("#%02X%02X%02X",
+		uint8(math.Round(r*scale*255)),
+		uint8(math.Round(g*scale*255)),
+		uint8(math.Round(b*scale*255)))
+}
+
+// KelvinToMirek converts a color temperature in Kelvin to mirek (1,000,000 / K), rounded to the nearest mirek
+func KelvinToMirek(kelvin int) int {
+	if kelvin <= 0 {
+		return 0
+	}
+	return int(math.Round(1e6 / float64(kelvin)))
+}
+
+// MirekToKelvin converts a color temperature in mirek to Kelvin, rounded to the nearest Kelvin
+func MirekToKelvin(mirek int) int {
+	if mirek <= 0 {
+		return 0
+	}
+	return int(math.Round(1e6 / float64(mirek)))
+}
+
+func gammaCompress(v float64) float64 {
+	if v <= 0.0031308 {
+		return 12.92 * v
+	}
+	return 1.055*pow(v, 1/2.4) - 0.055
+}
+
+func pow(base, exp float64) float64 {
+	return math.Pow(base, exp)
+}
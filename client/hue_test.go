@@ -3,8 +3,11 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -146,6 +149,29 @@ func TestUpdateLight(t *testing.T) {
 	}
 }
 
+func TestSetLightEffectWithParameters(t *testing.T) {
+	fb := newFakeBridge(t)
+	
+	speed := 0.8
+	params := &EffectParameters{Speed: &speed, Color: &Color{XY: XY{X: 0.5, Y: 0.4}}}
+	if err := fb.client().SetLightEffectWithParameters(context.Background(), "light-1", "candle", 0, params); err != nil {
+		t.Fatalf("SetLightEffectWithParameters failed: %v", err)
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1")
+	if len(puts) != 1 {
+		t.Fatalf("Expected 1 PUT, got %d", len(puts))
+	}
+	body := puts[0].Body
+	if _, ok := body["effects"]; ok {
+		t.Error("Expected parameters to be sent through effects_v2 only")
+	}
+	want := "map[action:map[effect:candle parameters:map[color:map[xy:map[x:0.5 y:0.4]] speed:0.8]]]"
+	if got := fmt.Sprint(body["effects_v2"]); got != want {
+		t.Errorf("Expected effects_v2 %s, got %s", want, got)
+	}
+}
+
 func TestSetLightEffect(t *testing.T) {
 	var requestReceived map[string]interface{}
 	
@@ -223,6 +249,85 @@ func TestHexToXY(t *testing.T) {
 	}
 }
 
+func TestHexToXYGamma(t *testing.T) {
+	// Mid-tone channels exercise the sRGB gamma curve, which uses a 2.4 exponent
+	tests := []struct {
+		hex  string
+		x, y float64
+	}{
+		{"#FF8000", 0.5430, 0.4070},
+		{"#336699", 0.2108, 0.2222},
+		{"#808080", 0.3127, 0.3290},
+	}
+	
+	for _, test := range tests {
+		x, y := convertHexToXY(test.hex)
+		if abs(x-test.x) > 0.0005 || abs(y-test.y) > 0.0005 {
+			t.Errorf("convertHexToXY(%s) = (%.4f, %.4f), want (%.4f, %.4f)", test.hex, x, y, test.x, test.y)
+		}
+	}
+}
+
+func TestKelvinMirekConversion(t *testing.T) {
+	tests := []struct {
+		kelvin int
+		mirek  int
+	}{
+		{2000, 500},  // Warmest the bridge accepts
+		{6500, 154},  // 153.8 rounds up
+		{6535, 153},  // Coolest the bridge accepts
+		{2700, 370},  // 370.37 rounds down
+		{4000, 250},
+	}
+	
+	for _, test := range tests {
+		if got := KelvinToMirek(test.kelvin); got != test.mirek {
+			t.Errorf("KelvinToMirek(%d) = %d, want %d", test.kelvin, got, test.mirek)
+		}
+	}
+	
+	for mirek, kelvin := range map[int]int{153: 6536, 500: 2000, 370: 2703, 366: 2732} {
+		if got := MirekToKelvin(mirek); got != kelvin {
+			t.Errorf("MirekToKelvin(%d) = %d, want %d", mirek, got, kelvin)
+		}
+	}
+	
+	if KelvinToMirek(0) != 0 || MirekToKelvin(-5) != 0 {
+		t.Error("Expected non-positive inputs to convert to 0")
+	}
+}
+
+func TestXYToHexRoundTrip(t *testing.T) {
+	colors := []string{"#FF0000", "#00FF00", "#0000FF", "#FFFFFF", "#FF8000", "#8000FF", "#00FFFF"}
+	
+	for _, hex := range colors {
+		x, y := hexToXY(hex)
+		got := xyToHex(x, y, 100)
+		
+		var r1, g1, b1, r2, g2, b2 uint8
+		fmt.Sscanf(strings.TrimPrefix(hex, "#"), "%02x%02x%02x", &r1, &g1, &b1)
+		fmt.Sscanf(strings.TrimPrefix(got, "#"), "%02x%02x%02x", &r2, &g2, &b2)
+		
+		if abs(float64(r1)-float64(r2)) > 2 || abs(float64(g1)-float64(g2)) > 2 || abs(float64(b1)-float64(b2)) > 2 {
+			t.Errorf("round trip %s -> (%.4f, %.4f) -> %s", hex, x, y, got)
+		}
+	}
+}
+
+func TestXYToHexBrightness(t *testing.T) {
+	if got := xyToHex(0.3127, 0.3290, 0); got != "#000000" {
+		t.Errorf("Expected black at zero brightness, got %s", got)
+	}
+	
+	x, y := hexToXY("#FF0000")
+	got := xyToHex(x, y, 50)
+	var r, g, b uint8
+	fmt.Sscanf(strings.TrimPrefix(got, "#"), "%02x%02x%02x", &r, &g, &b)
+	if abs(float64(r)-128) > 1 || g != 0 || b != 0 {
+		t.Errorf("Expected ~#800000 at half brightness, got %s", got)
+	}
+}
+
 func TestAPIErrorHandling(t *testing.T) {
 	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response := map[string]interface{}{
@@ -285,6 +390,68 @@ func TestHTTPErrorHandling(t *testing.T) {
 	}
 }
 
+func TestRetryOnRateLimit(t *testing.T) {
+	var attempts int
+	var bodies []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		
+		if attempts <= 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte("Too Many Requests"))
+			return
+		}
+		
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data":   []map[string]string{{"rid": "light-1"}},
+			"errors": []Error{},
+		})
+	}))
+	defer server.Close()
+	
+	client := NewClient(strings.TrimPrefix(server.URL, "https://"), "test-key", server.Client())
+	client.SetRetryPolicy(3, time.Millisecond)
+	
+	if err := client.TurnOnLight(context.Background(), "light-1"); err != nil {
+		t.Fatalf("Expected request to succeed after retries, got: %v", err)
+	}
+	
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+	
+	// The request body must be resent on every attempt
+	for i, body := range bodies {
+		if !strings.Contains(body, `"on":true`) {
+			t.Errorf("Attempt %d sent body %q", i+1, body)
+		}
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var attempts int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("Service Unavailable"))
+	}))
+	defer server.Close()
+	
+	client := NewClient(strings.TrimPrefix(server.URL, "https://"), "test-key", server.Client())
+	client.SetRetryPolicy(2, time.Millisecond)
+	
+	_, err := client.GetLights(context.Background())
+	if err == nil || err.Error() != "HTTP 503: Service Unavailable" {
+		t.Errorf("Expected HTTP 503 error after retries, got: %v", err)
+	}
+	
+	if attempts != 3 {
+		t.Errorf("Expected 1 attempt plus 2 retries, got %d", attempts)
+	}
+}
+
 func TestContextCancellation(t *testing.T) {
 	// Slow server that will be cancelled
 	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -317,4 +484,76 @@ func abs(x float64) float64 {
 		return -x
 	}
 	return x
-}
\ No newline at end of file
+}
+
+func TestActivateSceneWithOptions(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("scene", Scene{ID: "scene-1"})
+	c := fb.client()
+	
+	if err := c.ActivateScene(context.Background(), "scene-1"); err != nil {
+		t.Fatalf("ActivateScene failed: %v", err)
+	}
+	
+	brightness := 50.0
+	duration := 3000
+	if err := c.ActivateSceneWithOptions(context.Background(), "scene-1", &brightness, &duration); err != nil {
+		t.Fatalf("ActivateSceneWithOptions failed: %v", err)
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/scene/scene-1")
+	if len(puts) != 2 {
+		t.Fatalf("Expected 2 recalls, got %d", len(puts))
+	}
+	
+	plain := puts[0].Body["recall"].(map[string]interface{})
+	if _, ok := plain["dimming"]; ok {
+		t.Errorf("Expected a plain recall without dimming, got %v", plain)
+	}
+	if _, ok := plain["duration"]; ok {
+		t.Errorf("Expected a plain recall without duration, got %v", plain)
+	}
+	
+	recall := puts[1].Body["recall"].(map[string]interface{})
+	dimming, _ := recall["dimming"].(map[string]interface{})
+	if recall["action"] != "active" || dimming["brightness"] != 50.0 || recall["duration"] != 3000.0 {
+		t.Errorf("Expected recall at 50%% over 3000ms, got %v", recall)
+	}
+}
+
+func TestTurnOffAllLights(t *testing.T) {
+	t.Run("bridge home group", func(t *testing.T) {
+		fb := newFakeBridge(t)
+		fb.Add("grouped_light", Group{ID: "group-room", Owner: &ResourceIdentifier{RID: "room-1", RType: "room"}})
+		fb.Add("grouped_light", Group{ID: "group-home", Owner: &ResourceIdentifier{RID: "home", RType: "bridge_home"}})
+		
+		count, err := fb.client().TurnOffAllLights(context.Background())
+		if err != nil {
+			t.Fatalf("TurnOffAllLights failed: %v", err)
+		}
+		
+		puts := fb.RequestsFor("PUT", "/clip/v2/resource/grouped_light/")
+		if count != 1 || len(puts) != 1 || puts[0].Path != "/clip/v2/resource/grouped_light/group-home" {
+			t.Errorf("Expected a single update to the home group, got %d groups and %v", count, puts)
+		}
+	})
+	
+	t.Run("each group", func(t *testing.T) {
+		fb := newFakeBridge(t)
+		fb.Add("grouped_light", Group{ID: "group-1", Owner: &ResourceIdentifier{RID: "room-1", RType: "room"}})
+		fb.Add("grouped_light", Group{ID: "group-2", Owner: &ResourceIdentifier{RID: "room-2", RType: "room"}})
+		
+		count, err := fb.client().TurnOnAllLights(context.Background())
+		if err != nil {
+			t.Fatalf("TurnOnAllLights failed: %v", err)
+		}
+		
+		puts := fb.RequestsFor("PUT", "/clip/v2/resource/grouped_light/")
+		if count != 2 || len(puts) != 2 {
+			t.Fatalf("Expected both groups updated, got %d groups and %d requests", count, len(puts))
+		}
+		if on, _ := puts[0].Body["on"].(map[string]interface{}); on["on"] != true {
+			t.Errorf("Expected lights turned on, got %v", puts[0].Body)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default command rates recommended for the Hue bridge
+const (
+	defaultLightRate = 10.0 // light commands per second
+	defaultGroupRate = 1.0  // grouped_light commands per second
+)
+
+// maxRateLimitWait caps how long a command may queue behind earlier ones. Beyond it the
+// command is refused rather than reserved, so the debt a burst builds up stays bounded.
+const maxRateLimitWait = 5 * time.Second
+
+type rateLimitBypassKey struct{}
+
+// WithoutRateLimit returns a context whose commands skip the client's rate limits. Effect
+// sequences use it because they pace their own commands, and queuing a strobe or flash
+// behind the 1/s group limit would play it far slower than asked.
+func WithoutRateLimit(ctx context.Context) context.Context {
+	return context.WithValue(ctx, rateLimitBypassKey{}, true)
+}
+
+// tokenBucket is a simple token-bucket rate limiter. Tokens may go negative, in which
+// case callers wait their turn in the order they reserved, down to maxRateLimitWait.
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+// newTokenBucket returns a limiter allowing rate events per second, or nil for no limit
+func newTokenBucket(rate float64) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+
+	capacity := rate
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &tokenBucket{
+		rate:     rate,
+		capacity: capacity,
+		tokens:   capacity,
+		last:     time.Now(),
+	}
+}
+
+// wait blocks until a token is available or the context is done
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+	deficit := 1 - b.tokens
+	if wait := time.Duration(deficit / b.rate * float64(time.Second)); wait > maxRateLimitWait {
+		b.mu.Unlock()
+		return fmt.Errorf("%w: commands are queued for more than %s", ErrRateLimited, maxRateLimitWait)
+	}
+	b.tokens--
+	b.mu.Unlock()
+
+	if deficit <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(deficit / b.rate * float64(time.Second)))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Hand the reservation back
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// SetRateLimits caps PUT commands to lights and grouped lights at the given rates per
+// second, delaying requests that would exceed them and refusing ones that would wait longer
+// than maxRateLimitWait. A rate of zero removes that limit.
+// Call this before the client is shared between goroutines.
+func (c *Client) SetRateLimits(lightPerSec, groupPerSec float64) {
+	c.lightLimiter = newTokenBucket(lightPerSec)
+	c.groupLimiter = newTokenBucket(groupPerSec)
+}
+
+// limiterFor returns the rate limiter that applies to a request, if any
+func (c *Client) limiterFor(ctx context.Context, method, path string) *tokenBucket {
+	if method != "PUT" || ctx.Value(rateLimitBypassKey{}) != nil {
+		return nil
+	}
+
+	switch {
+	case strings.HasPrefix(path, "/resource/light/"):
+		return c.lightLimiter
+	case strings.HasPrefix(path, "/resource/grouped_light/"):
+		return c.groupLimiter
+	default:
+		return nil
+	}
+}
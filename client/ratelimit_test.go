@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimitsThrottleGroupPuts(t *testing.T) {
+	fb := newFakeBridge(t)
+	c := fb.client()
+	c.SetRateLimits(0, 4)
+	ctx := context.Background()
+	
+	// Lights are unlimited
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := c.TurnOnLight(ctx, "light-1"); err != nil {
+			t.Fatalf("TurnOnLight failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 300*time.Millisecond {
+		t.Errorf("Expected unlimited light commands to be fast, took %s", elapsed)
+	}
+	
+	// Groups burst up to 4, then wait 250ms per command
+	start = time.Now()
+	for i := 0; i < 6; i++ {
+		if err := c.TurnOnGroup(ctx, "group-1"); err != nil {
+			t.Fatalf("TurnOnGroup failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 450*time.Millisecond {
+		t.Errorf("Expected 6 group commands at 4/s to take ~500ms, took %s", elapsed)
+	}
+	
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/grouped_light/group-1"); len(puts) != 6 {
+		t.Errorf("Expected all 6 group commands to be sent, got %d", len(puts))
+	}
+}
+
+func TestRateLimitDoesNotDelayReads(t *testing.T) {
+	fb := newFakeBridge(t)
+	c := fb.client()
+	c.SetRateLimits(1, 1)
+	
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := c.GetLights(context.Background()); err != nil {
+			t.Fatalf("GetLights failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 300*time.Millisecond {
+		t.Errorf("Expected reads to bypass the command limiter, took %s", elapsed)
+	}
+}
+
+func TestTokenBucketHonorsContext(t *testing.T) {
+	bucket := newTokenBucket(1)
+	bucket.wait(context.Background())
+	
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := bucket.wait(ctx); err == nil {
+		t.Error("Expected wait to stop when the context is done")
+	}
+}
+
+func TestTokenBucketRefusesLongQueues(t *testing.T) {
+	bucket := newTokenBucket(1)
+	
+	// Five commands already queued at 1/s puts the next one past the cap
+	bucket.tokens = -5
+	err := bucket.wait(context.Background())
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected a queue longer than the cap to be refused, got %v", err)
+	}
+	if bucket.tokens < -5 {
+		t.Errorf("Expected a refused command not to add to the debt, tokens at %.1f", bucket.tokens)
+	}
+}
+
+func TestRateLimitBypass(t *testing.T) {
+	fb := newFakeBridge(t)
+	c := fb.client()
+	c.SetRateLimits(0, 1)
+	ctx := WithoutRateLimit(context.Background())
+	
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := c.TurnOnGroup(ctx, "group-1"); err != nil {
+			t.Fatalf("TurnOnGroup failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 300*time.Millisecond {
+		t.Errorf("Expected bypassed group commands to skip the 1/s limit, took %s", elapsed)
+	}
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegisterApplication(t *testing.T) {
+	pressed := false
+	var devicetype string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		devicetype, _ = body["devicetype"].(string)
+		if body["generateclientkey"] != true {
+			t.Errorf("Expected generateclientkey to be requested, got %v", body)
+		}
+		
+		if !pressed {
+			w.Write([]byte(`[{"error":{"type":101,"address":"","description":"link button not pressed"}}]`))
+			return
+		}
+		w.Write([]byte(`[{"success":{"username":"new-user","clientkey":"ABCDEF"}}]`))
+	}))
+	defer server.Close()
+	
+	bridgeIP := strings.TrimPrefix(server.URL, "https://")
+	ctx := context.Background()
+	
+	_, _, err := RegisterApplication(ctx, bridgeIP, "hue-mcp#test")
+	if !errors.Is(err, ErrLinkButtonNotPressed) {
+		t.Fatalf("Expected ErrLinkButtonNotPressed, got %v", err)
+	}
+	
+	pressed = true
+	username, clientKey, err := RegisterApplication(ctx, bridgeIP, "hue-mcp#test")
+	if err != nil {
+		t.Fatalf("RegisterApplication failed: %v", err)
+	}
+	if username != "new-user" || clientKey != "ABCDEF" {
+		t.Errorf("Expected new-user/ABCDEF, got %s/%s", username, clientKey)
+	}
+	if devicetype != "hue-mcp#test" {
+		t.Errorf("Expected devicetype hue-mcp#test, got %q", devicetype)
+	}
+}
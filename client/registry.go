@@ -0,0 +1,104 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// BridgeConfig describes one bridge in a multi-bridge setup
+type BridgeConfig struct {
+	Name      string `json:"name"`
+	IP        string `json:"ip"`
+	Username  string `json:"username"`
+	ClientKey string `json:"clientkey,omitempty"`
+	CertFile  string `json:"cert,omitempty"`      // PEM certificate to verify this bridge against
+	BridgeID  string `json:"bridge_id,omitempty"` // Checked against the certificate's common name
+}
+
+// ParseBridgeConfigs parses a JSON array of bridge configs, checking each has a unique name, an IP and a username
+func ParseBridgeConfigs(data []byte) ([]BridgeConfig, error) {
+	var configs []BridgeConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("failed to parse bridge config: %w", err)
+	}
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no bridges configured")
+	}
+
+	seen := make(map[string]bool)
+	for i, cfg := range configs {
+		if cfg.Name == "" || cfg.IP == "" || cfg.Username == "" {
+			return nil, fmt.Errorf("bridge %d: name, ip and username are required", i)
+		}
+		key := strings.ToLower(cfg.Name)
+		if seen[key] {
+			return nil, fmt.Errorf("bridge %d: duplicate name '%s'", i, cfg.Name)
+		}
+		seen[key] = true
+	}
+
+	return configs, nil
+}
+
+// ClientRegistry holds clients for several bridges by name. The first bridge added is the primary.
+type ClientRegistry struct {
+	mu      sync.RWMutex
+	clients map[string]*Client
+	names   []string
+}
+
+// NewClientRegistry creates an empty registry
+func NewClientRegistry() *ClientRegistry {
+	return &ClientRegistry{
+		clients: make(map[string]*Client),
+	}
+}
+
+// Add registers a client under a name. Names are matched case-insensitively.
+func (r *ClientRegistry) Add(name string, c *Client) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := strings.ToLower(name)
+	if _, exists := r.clients[key]; exists {
+		return fmt.Errorf("bridge '%s' already registered", name)
+	}
+
+	r.clients[key] = c
+	r.names = append(r.names, name)
+	return nil
+}
+
+// Get returns the client for a bridge name, or the primary client when name is empty
+func (r *ClientRegistry) Get(name string) (*Client, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.names) == 0 {
+		return nil, fmt.Errorf("no bridges configured")
+	}
+	if name == "" {
+		return r.clients[strings.ToLower(r.names[0])], nil
+	}
+
+	c, ok := r.clients[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown bridge '%s' (configured: %s)", name, strings.Join(r.names, ", "))
+	}
+	return c, nil
+}
+
+// Primary returns the first registered client, or nil if there are none
+func (r *ClientRegistry) Primary() *Client {
+	c, _ := r.Get("")
+	return c
+}
+
+// Names returns the registered bridge names in the order they were added
+func (r *ClientRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string(nil), r.names...)
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseBridgeConfigs(t *testing.T) {
+	configs, err := ParseBridgeConfigs([]byte(`[
+		{"name":"upstairs","ip":"192.168.1.10","username":"user-a","clientkey":"KEY"},
+		{"name":"downstairs","ip":"192.168.1.11","username":"user-b"}
+	]`))
+	if err != nil {
+		t.Fatalf("ParseBridgeConfigs failed: %v", err)
+	}
+	if len(configs) != 2 || configs[0].ClientKey != "KEY" || configs[1].IP != "192.168.1.11" {
+		t.Errorf("Unexpected configs: %+v", configs)
+	}
+	
+	for _, bad := range []string{
+		`[]`,
+		`[{"name":"a","ip":"1.2.3.4"}]`,
+		`[{"name":"a","ip":"1.2.3.4","username":"x"},{"name":"A","ip":"1.2.3.5","username":"y"}]`,
+		`{"name":"a"}`,
+	} {
+		if _, err := ParseBridgeConfigs([]byte(bad)); err == nil {
+			t.Errorf("Expected an error for %s", bad)
+		}
+	}
+}
+
+func TestClientRegistry(t *testing.T) {
+	registry := NewClientRegistry()
+	if registry.Primary() != nil {
+		t.Error("Expected no primary client in an empty registry")
+	}
+	
+	upstairs := NewClient("192.168.1.10", "user-a", nil)
+	downstairs := NewClient("192.168.1.11", "user-b", nil)
+	registry.Add("Upstairs", upstairs)
+	registry.Add("Downstairs", downstairs)
+	
+	if err := registry.Add("upstairs", upstairs); err == nil {
+		t.Error("Expected an error adding a duplicate name")
+	}
+	
+	if registry.Primary() != upstairs {
+		t.Error("Expected the first bridge added to be primary")
+	}
+	if c, err := registry.Get(""); err != nil || c != upstairs {
+		t.Errorf("Expected an empty name to select the primary bridge, got %v", err)
+	}
+	if c, err := registry.Get("downstairs"); err != nil || c != downstairs {
+		t.Errorf("Expected a case-insensitive lookup, got %v", err)
+	}
+	
+	_, err := registry.Get("garage")
+	if err == nil || !strings.Contains(err.Error(), "Upstairs, Downstairs") {
+		t.Errorf("Expected an unknown bridge error listing the configured names, got %v", err)
+	}
+}
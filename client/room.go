@@ -38,7 +38,7 @@ func (c *Client) GetRooms(ctx context.Context) ([]Room, error) {
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	return response.Data, nil
@@ -57,11 +57,11 @@ func (c *Client) GetRoom(ctx context.Context, id string) (*Room, error) {
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("room not found")
+		return nil, fmt.Errorf("room %w", ErrNotFound)
 	}
 	
 	return &response.Data[0], nil
@@ -80,7 +80,7 @@ func (c *Client) GetZones(ctx context.Context) ([]Zone, error) {
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	return response.Data, nil
@@ -99,11 +99,11 @@ func (c *Client) GetZone(ctx context.Context, id string) (*Zone, error) {
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("zone not found")
+		return nil, fmt.Errorf("zone %w", ErrNotFound)
 	}
 	
 	return &response.Data[0], nil
@@ -15,6 +15,14 @@ type Motion struct {
 	Owner    ResourceIdentifier `json:"owner"`
 	Enabled  bool               `json:"enabled"`
 	Motion   MotionReport       `json:"motion"`
+	Sensitivity *MotionSensitivity `json:"sensitivity,omitempty"`
+}
+
+// MotionSensitivity is a motion sensor's sensitivity setting, on sensors whose firmware exposes it
+type MotionSensitivity struct {
+	Sensitivity    int    `json:"sensitivity"`
+	SensitivityMax int    `json:"sensitivity_max,omitempty"`
+	Status         string `json:"status,omitempty"`
 }
 
 // MotionReport contains motion detection data
@@ -93,6 +101,29 @@ type ButtonReport struct {
 	EventValues    []string `json:"event_values"`
 }
 
+// Contact sensor types
+
+// ContactSensor represents a contact sensor resource (Hue Secure door/window sensors)
+type ContactSensor struct {
+	ID            string             `json:"id"`
+	IDV1          string             `json:"id_v1"`
+	Type          string             `json:"type"`
+	Owner         ResourceIdentifier `json:"owner"`
+	Enabled       bool               `json:"enabled"`
+	ContactReport *ContactReport     `json:"contact_report,omitempty"`
+}
+
+// ContactReport contains the last reported contact state
+type ContactReport struct {
+	Changed string `json:"changed"`
+	State   string `json:"state"` // contact (closed) or no_contact (open)
+}
+
+// IsOpen reports whether the sensor's two halves are apart
+func (r *ContactReport) IsOpen() bool {
+	return r.State == "no_contact"
+}
+
 // GetMotionSensors returns all motion sensors
 func (c *Client) GetMotionSensors(ctx context.Context) ([]Motion, error) {
 	var response struct {
@@ -106,7 +137,7 @@ func (c *Client) GetMotionSensors(ctx context.Context) ([]Motion, error) {
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	return response.Data, nil
@@ -125,7 +156,7 @@ func (c *Client) GetTemperatureSensors(ctx context.Context) ([]Temperature, erro
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	return response.Data, nil
@@ -144,7 +175,7 @@ func (c *Client) GetLightLevelSensors(ctx context.Context) ([]LightLevel, error)
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
 	}
 	
 	return response.Data, nil
@@ -163,8 +194,171 @@ func (c *Client) GetButtons(ctx context.Context) ([]Button, error) {
 	}
 	
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API error: %s", response.Errors[0].Description)
+		return nil, apiError(response.Errors)
+	}
+	
+	return response.Data, nil
+}
+
+// GetContactSensors returns all contact sensors
+func (c *Client) GetContactSensors(ctx context.Context) ([]ContactSensor, error) {
+	var response struct {
+		Errors []Error         `json:"errors"`
+		Data   []ContactSensor `json:"data"`
+	}
+	
+	err := c.getJSON(ctx, "/resource/contact", &response)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(response.Errors) > 0 {
+		return nil, apiError(response.Errors)
 	}
 	
 	return response.Data, nil
-}
\ No newline at end of file
+}
+
+// GetMotionSensor returns a specific motion sensor
+func (c *Client) GetMotionSensor(ctx context.Context, id string) (*Motion, error) {
+	var response struct {
+		Errors []Error  `json:"errors"`
+		Data   []Motion `json:"data"`
+	}
+	
+	err := c.getJSON(ctx, fmt.Sprintf("/resource/motion/%s", id), &response)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(response.Errors) > 0 {
+		return nil, apiError(response.Errors)
+	}
+	
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("motion sensor %w", ErrNotFound)
+	}
+	
+	return &response.Data[0], nil
+}
+
+// SetMotionSensorEnabled turns motion detection on or off for a sensor
+func (c *Client) SetMotionSensorEnabled(ctx context.Context, id string, enabled bool) error {
+	_, err := c.put(ctx, fmt.Sprintf("/resource/motion/%s", id), map[string]interface{}{
+		"enabled": enabled,
+	})
+	return err
+}
+
+// SetMotionSensitivity sets a motion sensor's sensitivity, from 0 up to the sensor's sensitivity_max.
+// Sensors that don't report a sensitivity can't be adjusted.
+func (c *Client) SetMotionSensitivity(ctx context.Context, id string, sensitivity int) error {
+	sensor, err := c.GetMotionSensor(ctx, id)
+	if err != nil {
+		return err
+	}
+	
+	if sensor.Sensitivity == nil {
+		return fmt.Errorf("motion sensor %s does not support sensitivity adjustment", id)
+	}
+	if sensitivity < 0 || (sensor.Sensitivity.SensitivityMax > 0 && sensitivity > sensor.Sensitivity.SensitivityMax) {
+		return fmt.Errorf("sensitivity must be between 0 and %d", sensor.Sensitivity.SensitivityMax)
+	}
+	
+	_, err = c.put(ctx, fmt.Sprintf("/resource/motion/%s", id), map[string]interface{}{
+		"sensitivity": map[string]int{"sensitivity": sensitivity},
+	})
+	return err
+}
+
+// GetTemperatureSensor returns a specific temperature sensor
+func (c *Client) GetTemperatureSensor(ctx context.Context, id string) (*Temperature, error) {
+	var response struct {
+		Errors []Error       `json:"errors"`
+		Data   []Temperature `json:"data"`
+	}
+	
+	err := c.getJSON(ctx, fmt.Sprintf("/resource/temperature/%s", id), &response)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(response.Errors) > 0 {
+		return nil, apiError(response.Errors)
+	}
+	
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("temperature sensor %w", ErrNotFound)
+	}
+	
+	return &response.Data[0], nil
+}
+
+// GetLightLevelSensor returns a specific light level sensor
+func (c *Client) GetLightLevelSensor(ctx context.Context, id string) (*LightLevel, error) {
+	var response struct {
+		Errors []Error      `json:"errors"`
+		Data   []LightLevel `json:"data"`
+	}
+	
+	err := c.getJSON(ctx, fmt.Sprintf("/resource/light_level/%s", id), &response)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(response.Errors) > 0 {
+		return nil, apiError(response.Errors)
+	}
+	
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("light level sensor %w", ErrNotFound)
+	}
+	
+	return &response.Data[0], nil
+}
+
+// GetButton returns a specific button
+func (c *Client) GetButton(ctx context.Context, id string) (*Button, error) {
+	var response struct {
+		Errors []Error  `json:"errors"`
+		Data   []Button `json:"data"`
+	}
+	
+	err := c.getJSON(ctx, fmt.Sprintf("/resource/button/%s", id), &response)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(response.Errors) > 0 {
+		return nil, apiError(response.Errors)
+	}
+	
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("button %w", ErrNotFound)
+	}
+	
+	return &response.Data[0], nil
+}
+
+// GetContactSensor returns a specific contact sensor
+func (c *Client) GetContactSensor(ctx context.Context, id string) (*ContactSensor, error) {
+	var response struct {
+		Errors []Error         `json:"errors"`
+		Data   []ContactSensor `json:"data"`
+	}
+	
+	err := c.getJSON(ctx, fmt.Sprintf("/resource/contact/%s", id), &response)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(response.Errors) > 0 {
+		return nil, apiError(response.Errors)
+	}
+	
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("contact sensor %w", ErrNotFound)
+	}
+	
+	return &response.Data[0], nil
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetContactSensorsParsesState(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("contact", map[string]interface{}{
+		"id":      "contact-1",
+		"type":    "contact",
+		"enabled": true,
+		"owner":   map[string]string{"rid": "device-1", "rtype": "device"},
+		"contact_report": map[string]string{
+			"changed": "2024-01-01T12:00:00.000Z",
+			"state":   "no_contact",
+		},
+	})
+	fb.Add("contact", map[string]interface{}{
+		"id":             "contact-2",
+		"type":           "contact",
+		"enabled":        true,
+		"contact_report": map[string]string{"state": "contact"},
+	})
+	
+	sensors, err := fb.client().GetContactSensors(context.Background())
+	if err != nil {
+		t.Fatalf("GetContactSensors failed: %v", err)
+	}
+	
+	if len(sensors) != 2 {
+		t.Fatalf("Expected 2 contact sensors, got %d", len(sensors))
+	}
+	if !sensors[0].ContactReport.IsOpen() || sensors[0].ContactReport.Changed != "2024-01-01T12:00:00.000Z" {
+		t.Errorf("Expected contact-1 open since 2024-01-01, got %+v", sensors[0].ContactReport)
+	}
+	if sensors[1].ContactReport.IsOpen() {
+		t.Errorf("Expected contact-2 closed, got %+v", sensors[1].ContactReport)
+	}
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LightState is the restorable state of a single light
+type LightState struct {
+	LightID    string  `json:"light_id"`
+	Name       string  `json:"name"`
+	On         bool    `json:"on"`
+	Brightness float64 `json:"brightness,omitempty"`
+	Color      *XY     `json:"color,omitempty"` // Set for lights showing a color
+	Mirek      int     `json:"mirek,omitempty"` // Set for lights showing a white color temperature
+}
+
+// Snapshot is a point-in-time capture of light states
+type Snapshot struct {
+	Name       string       `json:"name,omitempty"`
+	CapturedAt time.Time    `json:"captured_at"`
+	Lights     []LightState `json:"lights"`
+}
+
+// CaptureStates records the on/off, brightness and color or color temperature of the given lights
+func (c *Client) CaptureStates(ctx context.Context, lightIDs []string) (Snapshot, error) {
+	lights, err := c.GetLights(ctx)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to get lights: %w", err)
+	}
+
+	byID := make(map[string]*Light, len(lights))
+	for i := range lights {
+		byID[lights[i].ID] = &lights[i]
+	}
+
+	snapshot := Snapshot{CapturedAt: time.Now()}
+	for _, id := range lightIDs {
+		light, ok := byID[id]
+		if !ok {
+			return Snapshot{}, fmt.Errorf("light %s not found", id)
+		}
+		snapshot.Lights = append(snapshot.Lights, captureLightState(light))
+	}
+
+	return snapshot, nil
+}
+
+// captureLightState records a light's state, preferring color temperature when the light is showing white
+func captureLightState(light *Light) LightState {
+	state := LightState{
+		LightID:    light.ID,
+		Name:       light.Metadata.Name,
+		On:         light.On.On,
+		Brightness: light.Dimming.Brightness,
+	}
+
+	switch {
+	case inColorTemperatureMode(light):
+		state.Mirek = light.ColorTemperature.Mirek
+	case light.Color != nil && (light.Color.XY.X != 0 || light.Color.XY.Y != 0):
+		xy := light.Color.XY
+		state.Color = &xy
+	}
+
+	return state
+}
+
+// inColorTemperatureMode reports whether a light is showing a white color temperature
+// rather than a color. Bridges flag this with mirek_valid, and some report it through the
+// light's mode instead; either way there must be a mirek value to restore.
+func inColorTemperatureMode(light *Light) bool {
+	if light.ColorTemperature == nil || light.ColorTemperature.Mirek <= 0 {
+		return false
+	}
+	return light.ColorTemperature.MirekValid || light.Mode == "color_temperature"
+}
+
+// ApplyStates restores every light in a snapshot, continuing past failures and reporting them together
+func (c *Client) ApplyStates(ctx context.Context, snapshot Snapshot) error {
+	errs := c.ApplyStatesEach(ctx, snapshot)
+
+	var failures []string
+	for _, state := range snapshot.Lights {
+		if err, failed := errs[state.LightID]; failed {
+			failures = append(failures, fmt.Sprintf("%s: %v", state.LightID, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to restore %d of %d lights: %s", len(failures), len(snapshot.Lights), strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// ApplyStatesEach restores every light in a snapshot and returns the error of each light that
+// failed, keyed by light ID. Updates go through the client's rate limiter one light at a time.
+func (c *Client) ApplyStatesEach(ctx context.Context, snapshot Snapshot) map[string]error {
+	errs := make(map[string]error)
+	for _, state := range snapshot.Lights {
+		if err := c.UpdateLight(ctx, state.LightID, state.update()); err != nil {
+			errs[state.LightID] = err
+		}
+	}
+	return errs
+}
+
+// update builds the light update that returns a light to this state
+func (s LightState) update() LightUpdate {
+	update := LightUpdate{On: &OnState{On: s.On}}
+	if !s.On {
+		return update
+	}
+
+	if s.Brightness > 0 {
+		update.Dimming = &Dimming{Brightness: s.Brightness}
+	}
+	if s.Mirek > 0 {
+		update.ColorTemperature = &ColorTemperature{Mirek: s.Mirek}
+	} else if s.Color != nil {
+		update.Color = &Color{XY: *s.Color}
+	}
+
+	return update
+}
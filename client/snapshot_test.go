@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCaptureAndApplyStates(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", Light{
+		ID:       "light-color",
+		Metadata: Metadata{Name: "Color"},
+		On:       OnState{On: true},
+		Dimming:  Dimming{Brightness: 60},
+		Color:    &Color{XY: XY{X: 0.6, Y: 0.3}},
+		ColorTemperature: &ColorTemperature{Mirek: 300, MirekValid: false},
+	})
+	fb.Add("light", Light{
+		ID:               "light-white",
+		On:               OnState{On: true},
+		Dimming:          Dimming{Brightness: 40},
+		Color:            &Color{XY: XY{X: 0.45, Y: 0.4}},
+		ColorTemperature: &ColorTemperature{Mirek: 366, MirekValid: true},
+	})
+	fb.Add("light", Light{ID: "light-off", On: OnState{On: false}, Dimming: Dimming{Brightness: 80}})
+	
+	c := fb.client()
+	ctx := context.Background()
+	
+	snapshot, err := c.CaptureStates(ctx, []string{"light-color", "light-white", "light-off"})
+	if err != nil {
+		t.Fatalf("CaptureStates failed: %v", err)
+	}
+	if len(snapshot.Lights) != 3 {
+		t.Fatalf("Expected 3 light states, got %d", len(snapshot.Lights))
+	}
+	
+	colorState := snapshot.Lights[0]
+	if colorState.Color == nil || colorState.Mirek != 0 || colorState.Name != "Color" {
+		t.Errorf("Expected the color light to capture xy only, got %+v", colorState)
+	}
+	if white := snapshot.Lights[1]; white.Mirek != 366 || white.Color != nil {
+		t.Errorf("Expected the white light to capture mirek only, got %+v", white)
+	}
+	
+	if err := c.ApplyStates(ctx, snapshot); err != nil {
+		t.Fatalf("ApplyStates failed: %v", err)
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/")
+	if len(puts) != 3 {
+		t.Fatalf("Expected 3 PUTs, got %d", len(puts))
+	}
+	
+	if puts[0].Body["color"] == nil || puts[0].Body["color_temperature"] != nil {
+		t.Errorf("Expected color light restored by xy, got %v", puts[0].Body)
+	}
+	if brightness := puts[0].Body["dimming"].(map[string]interface{})["brightness"]; brightness != 60.0 {
+		t.Errorf("Expected brightness 60 restored, got %v", brightness)
+	}
+	if puts[1].Body["color_temperature"] == nil || puts[1].Body["color"] != nil {
+		t.Errorf("Expected white light restored by mirek, got %v", puts[1].Body)
+	}
+	if on := puts[2].Body["on"].(map[string]interface{})["on"]; on != false || puts[2].Body["dimming"] != nil {
+		t.Errorf("Expected the off light to only be switched off, got %v", puts[2].Body)
+	}
+}
+
+func TestCaptureStatesUnknownLight(t *testing.T) {
+	fb := newFakeBridge(t)
+	
+	if _, err := fb.client().CaptureStates(context.Background(), []string{"light-missing"}); err == nil {
+		t.Error("Expected capturing an unknown light to fail")
+	}
+}
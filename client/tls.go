@@ -0,0 +1,91 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultHTTPTimeout bounds each request to the bridge unless SetTimeout overrides it
+const DefaultHTTPTimeout = 30 * time.Second
+
+// NewClientWithTLS creates a client that verifies the bridge against a known certificate,
+// given as PEM. This can be the bridge's own self-signed certificate or the CA that signed it.
+// When bridgeID is set the certificate must also be issued to that bridge, which matters when
+// pinning a CA that signs every bridge.
+func NewClientWithTLS(bridgeIP, username string, caCert []byte, bridgeID string) (*Client, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no certificates found in the bridge certificate PEM")
+	}
+
+	httpClient := &http.Client{
+		Timeout: DefaultHTTPTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: pinnedTLSConfig(pool, bridgeID),
+		},
+	}
+	return NewClient(bridgeIP, username, httpClient), nil
+}
+
+// NewInsecureClient creates a client that accepts any certificate the bridge presents.
+// Prefer NewClientWithTLS; this exists for setups that haven't saved the bridge's certificate.
+func NewInsecureClient(bridgeIP, username string) *Client {
+	httpClient := &http.Client{
+		Timeout: DefaultHTTPTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	return NewClient(bridgeIP, username, httpClient)
+}
+
+// SetTimeout sets how long a single request to the bridge may take. Zero means no timeout.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.httpClient.Timeout = timeout
+}
+
+// pinnedTLSConfig verifies the bridge's certificate chain against the pinned certificates.
+// The bridge is reached by IP while its certificate is issued to the bridge ID, so the
+// standard hostname check can never pass and is replaced by this chain check plus, when
+// bridgeID is given, a check of the certificate's common name.
+func pinnedTLSConfig(roots *x509.CertPool, bridgeID string) *tls.Config {
+	return &tls.Config{
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return fmt.Errorf("bridge presented no certificate")
+			}
+
+			certs := make([]*x509.Certificate, len(rawCerts))
+			for i, raw := range rawCerts {
+				cert, err := x509.ParseCertificate(raw)
+				if err != nil {
+					return fmt.Errorf("failed to parse bridge certificate: %w", err)
+				}
+				certs[i] = cert
+			}
+
+			intermediates := x509.NewCertPool()
+			for _, cert := range certs[1:] {
+				intermediates.AddCert(cert)
+			}
+
+			_, err := certs[0].Verify(x509.VerifyOptions{
+				Roots:         roots,
+				Intermediates: intermediates,
+				KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+			})
+			if err != nil {
+				return fmt.Errorf("bridge certificate (CN %s) does not match the pinned certificate: %w", certs[0].Subject.CommonName, err)
+			}
+			if bridgeID != "" && !strings.EqualFold(certs[0].Subject.CommonName, bridgeID) {
+				return fmt.Errorf("bridge certificate is issued to %s, not the configured bridge %s", certs[0].Subject.CommonName, bridgeID)
+			}
+			return nil
+		},
+	}
+}
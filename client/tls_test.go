@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestNewClientWithTLSPinsCertificate(t *testing.T) {
+	fb := newFakeBridge(t)
+
+	pinned, err := NewClientWithTLS(fb.Host(), "test-key", fb.CertificatePEM(), "")
+	if err != nil {
+		t.Fatalf("NewClientWithTLS failed: %v", err)
+	}
+	if err := pinned.TestConnection(context.Background()); err != nil {
+		t.Errorf("Expected the pinned certificate to be accepted, got %v", err)
+	}
+
+	other, err := NewClientWithTLS(fb.Host(), "test-key", selfSignedPEM(t, "001788fffe000000"), "")
+	if err != nil {
+		t.Fatalf("NewClientWithTLS failed: %v", err)
+	}
+	other.SetRetryPolicy(0, 0)
+	if err := other.TestConnection(context.Background()); err == nil {
+		t.Error("Expected a different certificate to be rejected")
+	}
+
+	if _, err := NewClientWithTLS(fb.Host(), "test-key", []byte("not a certificate"), ""); err == nil {
+		t.Error("Expected invalid PEM to be rejected")
+	}
+}
+
+func TestPinnedTLSConfigChecksBridgeID(t *testing.T) {
+	certPEM := selfSignedPEM(t, "001788fffe123456")
+	block, _ := pem.Decode(certPEM)
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(certPEM)
+
+	tests := []struct {
+		bridgeID string
+		wantErr  bool
+	}{
+		{"", false},
+		{"001788fffe123456", false},
+		{"001788FFFE123456", false},
+		{"001788fffe000000", true},
+	}
+	for _, tt := range tests {
+		err := pinnedTLSConfig(roots, tt.bridgeID).VerifyPeerCertificate([][]byte{block.Bytes}, nil)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("bridge ID %q: got error %v, want error %v", tt.bridgeID, err, tt.wantErr)
+		}
+	}
+}
+
+// selfSignedPEM creates a throwaway self-signed certificate with the given common name
+func selfSignedPEM(t *testing.T, commonName string) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -30,6 +30,15 @@ type Light struct {
 	Mode     string    `json:"mode"`
 }
 
+// IsOnOffOnly reports whether a light can only be switched on and off, like a smart plug.
+// The bridge leaves out dimming for these, so their brightness reads as zero.
+func (l *Light) IsOnOffOnly() bool {
+	if l.Metadata.Archetype == "plug" {
+		return true
+	}
+	return l.Dimming.Brightness == 0 && l.Dimming.MinDimLevel == 0 && l.Color == nil && l.ColorTemperature == nil
+}
+
 // Group represents a grouped light resource
 type Group struct {
 	ID       string    `json:"id"`
@@ -57,6 +66,13 @@ type Scene struct {
 	Palette  *ScenePalette       `json:"palette,omitempty"`
 	Speed    float64             `json:"speed"`
 	AutoDynamic bool             `json:"auto_dynamic"`
+	Status   *SceneStatus        `json:"status,omitempty"`
+}
+
+// SceneStatus reports whether a scene is currently active on its group
+type SceneStatus struct {
+	Active     string `json:"active"` // inactive, static or dynamic_palette
+	LastRecall string `json:"last_recall,omitempty"`
 }
 
 // Bridge represents bridge information
@@ -110,7 +126,7 @@ type Gamut struct {
 // ColorTemperature represents color temperature settings
 type ColorTemperature struct {
 	Mirek       int     `json:"mirek"`
-	MirekValid  bool    `json:"mirek_valid"`
+	MirekValid  bool    `json:"mirek_valid,omitempty"`
 	MirekSchema *MirekSchema `json:"mirek_schema,omitempty"`
 }
 
@@ -135,6 +151,24 @@ type Effects struct {
 	EffectValues []string `json:"effect_values,omitempty"`
 }
 
+// EffectsV2 sets an effect along with parameters tuning it
+type EffectsV2 struct {
+	Action EffectAction `json:"action"`
+}
+
+// EffectAction names an effect and its parameters
+type EffectAction struct {
+	Effect     string            `json:"effect"`
+	Parameters *EffectParameters `json:"parameters,omitempty"`
+}
+
+// EffectParameters tunes an effect; effects ignore parameters they don't use
+type EffectParameters struct {
+	Color            *Color            `json:"color,omitempty"`
+	ColorTemperature *ColorTemperature `json:"color_temperature,omitempty"`
+	Speed            *float64          `json:"speed,omitempty"` // 0-1
+}
+
 // Alert represents alert effects
 type Alert struct {
 	ActionValues []string `json:"action_values,omitempty"`
@@ -181,6 +215,7 @@ type LightUpdate struct {
 	ColorTemperature *ColorTemperature `json:"color_temperature,omitempty"`
 	Dynamics         *Dynamics         `json:"dynamics,omitempty"`
 	Effects          *Effects          `json:"effects,omitempty"`
+	EffectsV2        *EffectsV2        `json:"effects_v2,omitempty"`
 	Alert            *Alert            `json:"alert,omitempty"`
 }
 
@@ -192,6 +227,7 @@ type GroupUpdate struct {
 	ColorTemperature *ColorTemperature `json:"color_temperature,omitempty"`
 	Dynamics         *Dynamics         `json:"dynamics,omitempty"`
 	Effects          *Effects          `json:"effects,omitempty"`
+	EffectsV2        *EffectsV2        `json:"effects_v2,omitempty"`
 	Alert            *Alert            `json:"alert,omitempty"`
 }
 
@@ -201,7 +237,9 @@ type SceneCreate struct {
 	Metadata Metadata           `json:"metadata"`
 	Group    ResourceIdentifier `json:"group"`
 	Actions  []SceneAction      `json:"actions"`
+	Palette  *ScenePalette      `json:"palette,omitempty"`
 	Speed    float64            `json:"speed,omitempty"`
+	AutoDynamic bool            `json:"auto_dynamic,omitempty"`
 }
 
 // SceneUpdate represents parameters for updating a scene
@@ -228,4 +266,29 @@ type ZoneCreate struct {
 	Type     string               `json:"type"`
 	Metadata Metadata             `json:"metadata"`
 	Children []ResourceIdentifier `json:"children"`
-}
\ No newline at end of file
+}
+
+// RoomCreate represents parameters for creating a room
+type RoomCreate struct {
+	Type     string               `json:"type"`
+	Metadata Metadata             `json:"metadata"`
+	Children []ResourceIdentifier `json:"children"`
+}
+
+// DeviceSoftwareUpdate represents the device_software_update resource of a device
+type DeviceSoftwareUpdate struct {
+	ID       string             `json:"id"`
+	Type     string             `json:"type"`
+	Owner    ResourceIdentifier `json:"owner"`
+	State    string             `json:"state"` // no_update, update_pending, ready_to_install or installing
+	Problems []string           `json:"problems,omitempty"`
+}
+
+// DevicePower represents the device_power resource of a battery powered device
+type DevicePower struct {
+	ID         string             `json:"id"`
+	IDV1       string             `json:"id_v1"`
+	Type       string             `json:"type"`
+	Owner      ResourceIdentifier `json:"owner"`
+	PowerState PowerState         `json:"power_state"`
+}
@@ -29,17 +29,36 @@ var discoverCmd = &cobra.Command{
 	Use:   "discover",
 	Short: "Discover Hue bridges on your network",
 	Long: `Discover Philips Hue bridges using the official Philips discovery service.
-This will find all bridges registered to your network and test their connectivity.`,
+This will find all bridges registered to your network and test their connectivity.
+Bridges are also looked up locally via mDNS, so discovery works without internet access.`,
 	RunE: runDiscover,
 }
 
 func runDiscover(cmd *cobra.Command, args []string) error {
 	fmt.Println("🔍 Discovering Hue bridges...")
 	
+	// Look on the local network at the same time, for bridges the cloud service doesn't know
+	type mdnsResult struct {
+		bridges []DiscoveredBridge
+		err     error
+	}
+	local := make(chan mdnsResult, 1)
+	go func() {
+		bridges, err := discoverBridgesMDNS(3 * time.Second)
+		local <- mdnsResult{bridges, err}
+	}()
+	
 	// Discover bridges using official API
-	bridges, err := discoverBridges()
-	if err != nil {
-		fmt.Printf("❌ %v\n\n", err)
+	cloudBridges, cloudErr := discoverBridges()
+	mdns := <-local
+	
+	bridges := mergeBridges(cloudBridges, mdns.bridges)
+	if cloudErr != nil && len(bridges) == 0 {
+		fmt.Printf("❌ %v\n", cloudErr)
+		if mdns.err != nil {
+			fmt.Printf("❌ Local discovery: %v\n", mdns.err)
+		}
+		fmt.Println()
 		fmt.Println("🔧 Manual discovery alternatives:")
 		fmt.Println("   1. Check your router's admin page for connected devices")
 		fmt.Println("   2. Use a network scanner: nmap -sn 192.168.1.0/24")
@@ -92,8 +111,8 @@ func runDiscover(cmd *cobra.Command, args []string) error {
 		primaryBridge := bridges[0]
 		fmt.Println("📋 To use this bridge:")
 		fmt.Printf("   export HUE_BRIDGE_IP=\"%s\"\n", primaryBridge.InternalIPAddress)
-		fmt.Println("   # Get API username by pressing the bridge button and running:")
-		fmt.Printf("   curl -X POST http://%s/api -d '{\"devicetype\":\"hue#cli\"}'\n", primaryBridge.InternalIPAddress)
+		fmt.Println("   # Get API username by running this and pressing the bridge button:")
+		fmt.Printf("   hue pair --bridge %s\n", primaryBridge.InternalIPAddress)
 	}
 
 	return nil
@@ -240,6 +240,18 @@ var stopCmd = &cobra.Command{
 	},
 }
 
+// stopAllCmd stops every running effect
+var stopAllCmd = &cobra.Command{
+	Use:   "stop-all",
+	Short: "Stop all running effects",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stopped := mcp.GetScheduler().StopAll()
+		
+		printMessage("Stopped %d running sequences", stopped)
+		return nil
+	},
+}
+
 // listSequencesCmd lists all running sequences
 var listSequencesCmd = &cobra.Command{
 	Use:   "list",
@@ -260,11 +272,16 @@ var listSequencesCmd = &cobra.Command{
 		fmt.Printf("Active sequences (%d):\n\n", len(sequences))
 		for id, seq := range sequences {
 			status := "stopped"
-			if seq.Running {
+			if seq.Paused {
+				status = "paused"
+			} else if seq.Running {
 				status = "running"
 			}
 			fmt.Printf("- %s: %s [%s]\n", id, seq.Name, status)
 			fmt.Printf("  Commands: %d | Loop: %v\n", len(seq.Commands), seq.Loop)
+			if seq.Running {
+				fmt.Printf("  Progress: %s\n", seq.Progress(time.Now()))
+			}
 		}
 		
 		return nil
@@ -293,6 +310,7 @@ func init() {
 	effectsCmd.AddCommand(pulseCmd)
 	effectsCmd.AddCommand(strobeCmd)
 	effectsCmd.AddCommand(stopCmd)
+	effectsCmd.AddCommand(stopAllCmd)
 	effectsCmd.AddCommand(listSequencesCmd)
 	
 	// Add to root
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// mDNS service advertised by Hue bridges on the local network
+const (
+	hueMDNSService = "_hue._tcp.local"
+	mdnsAddress    = "224.0.0.251:5353"
+
+	dnsTypeA   = 1
+	dnsTypePTR = 12
+	dnsTypeTXT = 16
+	dnsTypeSRV = 33
+)
+
+// discoverBridgesMDNS asks the local network for bridges advertising _hue._tcp.
+// It works without internet access or cloud registration.
+func discoverBridgesMDNS(timeout time.Duration) ([]DiscoveredBridge, error) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4zero})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mDNS socket: %w", err)
+	}
+	defer conn.Close()
+
+	dest, err := net.ResolveUDPAddr("udp4", mdnsAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// Queries from a port other than 5353 get unicast replies straight back to us
+	if _, err := conn.WriteToUDP(buildMDNSQuery(hueMDNSService), dest); err != nil {
+		return nil, fmt.Errorf("failed to send mDNS query: %w", err)
+	}
+
+	var bridges []DiscoveredBridge
+	deadline := time.Now().Add(timeout)
+	buf := make([]byte, 9000)
+
+	for {
+		conn.SetReadDeadline(deadline)
+		n, from, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				break
+			}
+			return bridges, fmt.Errorf("failed to read mDNS response: %w", err)
+		}
+
+		found, err := parseMDNSBridges(buf[:n], from.IP)
+		if err != nil {
+			continue
+		}
+		bridges = mergeBridges(bridges, found)
+	}
+
+	return bridges, nil
+}
+
+// buildMDNSQuery builds a DNS query for the PTR records of a service
+func buildMDNSQuery(service string) []byte {
+	msg := make([]byte, 12)
+	binary.BigEndian.PutUint16(msg[4:], 1) // one question
+
+	for _, label := range strings.Split(service, ".") {
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	msg = append(msg, 0)
+
+	msg = binary.BigEndian.AppendUint16(msg, dnsTypePTR)
+	msg = binary.BigEndian.AppendUint16(msg, 1) // IN
+	return msg
+}
+
+// mdnsInstance collects the records describing one advertised bridge
+type mdnsInstance struct {
+	host string
+	txt  map[string]string
+}
+
+// parseMDNSBridges extracts bridges from an mDNS response. The bridge ID comes from the
+// bridgeid TXT entry and the address from an A record, falling back to the sender.
+func parseMDNSBridges(msg []byte, sender net.IP) ([]DiscoveredBridge, error) {
+	if len(msg) < 12 {
+		return nil, fmt.Errorf("mDNS message too short")
+	}
+
+	questions := int(binary.BigEndian.Uint16(msg[4:]))
+	records := int(binary.BigEndian.Uint16(msg[6:])) + int(binary.BigEndian.Uint16(msg[8:])) + int(binary.BigEndian.Uint16(msg[10:]))
+
+	offset := 12
+	for i := 0; i < questions; i++ {
+		_, next, err := readDNSName(msg, offset)
+		if err != nil {
+			return nil, err
+		}
+		offset = next + 4
+	}
+
+	var instanceNames []string
+	instances := make(map[string]*mdnsInstance)
+	addresses := make(map[string]net.IP)
+	instance := func(name string) *mdnsInstance {
+		if instances[name] == nil {
+			instances[name] = &mdnsInstance{txt: make(map[string]string)}
+		}
+		return instances[name]
+	}
+
+	for i := 0; i < records; i++ {
+		name, next, err := readDNSName(msg, offset)
+		if err != nil {
+			return nil, err
+		}
+		if next+10 > len(msg) {
+			return nil, fmt.Errorf("mDNS record truncated")
+		}
+
+		rtype := binary.BigEndian.Uint16(msg[next:])
+		length := int(binary.BigEndian.Uint16(msg[next+8:]))
+		start := next + 10
+		end := start + length
+		if end > len(msg) {
+			return nil, fmt.Errorf("mDNS record truncated")
+		}
+		offset = end
+
+		switch rtype {
+		case dnsTypePTR:
+			if !strings.EqualFold(name, hueMDNSService) {
+				continue
+			}
+			target, _, err := readDNSName(msg, start)
+			if err != nil {
+				return nil, err
+			}
+			if instances[target] == nil {
+				instanceNames = append(instanceNames, target)
+			}
+			instance(target)
+		case dnsTypeSRV:
+			if length < 7 {
+				continue
+			}
+			host, _, err := readDNSName(msg, start+6)
+			if err != nil {
+				return nil, err
+			}
+			instance(name).host = host
+		case dnsTypeTXT:
+			inst := instance(name)
+			for pos := start; pos < end; {
+				size := int(msg[pos])
+				pos++
+				if pos+size > end {
+					break
+				}
+				if key, value, ok := strings.Cut(string(msg[pos:pos+size]), "="); ok {
+					inst.txt[strings.ToLower(key)] = value
+				}
+				pos += size
+			}
+		case dnsTypeA:
+			if length == 4 {
+				addresses[strings.ToLower(name)] = net.IP(msg[start:end])
+			}
+		}
+	}
+
+	var bridges []DiscoveredBridge
+	for _, name := range instanceNames {
+		inst := instances[name]
+
+		ip := addresses[strings.ToLower(inst.host)]
+		if ip == nil {
+			ip = sender
+		}
+		if ip == nil {
+			continue
+		}
+
+		bridges = append(bridges, DiscoveredBridge{
+			ID:                strings.ToLower(inst.txt["bridgeid"]),
+			InternalIPAddress: ip.String(),
+		})
+	}
+
+	return bridges, nil
+}
+
+// readDNSName reads a possibly compressed domain name, returning it and the offset after it
+func readDNSName(msg []byte, offset int) (string, int, error) {
+	var labels []string
+	next := -1
+
+	for jumps := 0; ; {
+		if offset >= len(msg) {
+			return "", 0, fmt.Errorf("DNS name out of range")
+		}
+
+		size := int(msg[offset])
+		switch {
+		case size == 0:
+			if next < 0 {
+				next = offset + 1
+			}
+			return strings.Join(labels, "."), next, nil
+		case size&0xC0 == 0xC0:
+			if offset+1 >= len(msg) {
+				return "", 0, fmt.Errorf("DNS name pointer truncated")
+			}
+			if jumps++; jumps > 10 {
+				return "", 0, fmt.Errorf("DNS name has too many pointers")
+			}
+			if next < 0 {
+				next = offset + 2
+			}
+			offset = int(binary.BigEndian.Uint16(msg[offset:]) & 0x3FFF)
+		default:
+			if offset+1+size > len(msg) {
+				return "", 0, fmt.Errorf("DNS label truncated")
+			}
+			labels = append(labels, string(msg[offset+1:offset+1+size]))
+			offset += 1 + size
+		}
+	}
+}
+
+// mergeBridges combines bridge lists, dropping duplicates by bridge ID (or IP when the ID is unknown)
+func mergeBridges(lists ...[]DiscoveredBridge) []DiscoveredBridge {
+	var merged []DiscoveredBridge
+	seenIDs := make(map[string]bool)
+	seenIPs := make(map[string]bool)
+
+	for _, list := range lists {
+		for _, bridge := range list {
+			id := strings.ToLower(bridge.ID)
+			if (id != "" && seenIDs[id]) || (id == "" && seenIPs[bridge.InternalIPAddress]) {
+				continue
+			}
+			if id != "" {
+				seenIDs[id] = true
+			}
+			seenIPs[bridge.InternalIPAddress] = true
+			merged = append(merged, bridge)
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+)
+
+// dnsName encodes a domain name without compression
+func dnsName(name string) []byte {
+	var out []byte
+	for _, label := range strings.Split(name, ".") {
+		out = append(out, byte(len(label)))
+		out = append(out, label...)
+	}
+	return append(out, 0)
+}
+
+// dnsRecord encodes a resource record with the given owner name bytes
+func dnsRecord(name []byte, rtype uint16, rdata []byte) []byte {
+	out := append([]byte{}, name...)
+	out = binary.BigEndian.AppendUint16(out, rtype)
+	out = binary.BigEndian.AppendUint16(out, 1)
+	out = binary.BigEndian.AppendUint32(out, 120)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(rdata)))
+	return append(out, rdata...)
+}
+
+func TestParseMDNSBridges(t *testing.T) {
+	msg := make([]byte, 12)
+	binary.BigEndian.PutUint16(msg[2:], 0x8400)
+	binary.BigEndian.PutUint16(msg[6:], 1) // answers
+	binary.BigEndian.PutUint16(msg[10:], 3) // additional
+	
+	// PTR _hue._tcp.local -> instance, with the service name at offset 12 reused via a pointer
+	instance := append([]byte{byte(len("Hue Bridge - 696373"))}, "Hue Bridge - 696373"...)
+	instance = append(instance, 0xC0, 12)
+	msg = append(msg, dnsRecord(dnsName(hueMDNSService), dnsTypePTR, instance)...)
+	
+	srv := []byte{0, 0, 0, 0, 0x01, 0xBB}
+	srv = append(srv, dnsName("huebridge.local")...)
+	msg = append(msg, dnsRecord(dnsName("Hue Bridge - 696373._hue._tcp.local"), dnsTypeSRV, srv)...)
+	
+	var txt []byte
+	for _, entry := range []string{"bridgeid=001788FFFE696373", "modelid=BSB002"} {
+		txt = append(txt, byte(len(entry)))
+		txt = append(txt, entry...)
+	}
+	msg = append(msg, dnsRecord(dnsName("Hue Bridge - 696373._hue._tcp.local"), dnsTypeTXT, txt)...)
+	msg = append(msg, dnsRecord(dnsName("huebridge.local"), dnsTypeA, []byte{192, 168, 1, 20})...)
+	
+	bridges, err := parseMDNSBridges(msg, net.IPv4(10, 0, 0, 1))
+	if err != nil {
+		t.Fatalf("parseMDNSBridges failed: %v", err)
+	}
+	if len(bridges) != 1 {
+		t.Fatalf("Expected 1 bridge, got %d", len(bridges))
+	}
+	if bridges[0].ID != "001788fffe696373" || bridges[0].InternalIPAddress != "192.168.1.20" {
+		t.Errorf("Unexpected bridge: %+v", bridges[0])
+	}
+}
+
+func TestMergeBridgesDeduplicates(t *testing.T) {
+	cloud := []DiscoveredBridge{{ID: "001788fffe696373", InternalIPAddress: "192.168.1.20"}}
+	local := []DiscoveredBridge{
+		{ID: "001788FFFE696373", InternalIPAddress: "192.168.1.20"},
+		{ID: "", InternalIPAddress: "192.168.1.20"},
+		{ID: "ecb5fafffe000001", InternalIPAddress: "192.168.1.30"},
+	}
+	
+	merged := mergeBridges(cloud, local)
+	if len(merged) != 2 {
+		t.Fatalf("Expected 2 bridges after merging, got %+v", merged)
+	}
+	if merged[1].ID != "ecb5fafffe000001" {
+		t.Errorf("Expected the local-only bridge to be kept, got %+v", merged[1])
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/kungfusheep/hue/client"
+)
+
+var (
+	pairBridgeIP string
+	pairAppName  string
+	pairTimeout  time.Duration
+)
+
+// pairCmd registers this application with a bridge to obtain an API username
+var pairCmd = &cobra.Command{
+	Use:   "pair",
+	Short: "Pair with a Hue bridge to get an API username",
+	Long: `Register with a Hue bridge and print the credentials to use as HUE_USERNAME.
+
+Press the link button on the bridge when prompted. The bridge is polled until the
+button is pressed or the timeout passes.`,
+	RunE: runPair,
+}
+
+func runPair(cmd *cobra.Command, args []string) error {
+	bridgeIP := pairBridgeIP
+	if bridgeIP == "" {
+		bridgeIP = os.Getenv("HUE_BRIDGE_IP")
+	}
+	if bridgeIP == "" {
+		return fmt.Errorf("bridge IP is required: use --bridge or set HUE_BRIDGE_IP (run 'hue discover' to find it)")
+	}
+
+	appName := pairAppName
+	if !strings.Contains(appName, "#") {
+		host, _ := os.Hostname()
+		if host == "" {
+			host = "cli"
+		}
+		appName = appName + "#" + host
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pairTimeout)
+	defer cancel()
+
+	printMessage("🔗 Press the link button on your Hue bridge (%s)...", bridgeIP)
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		username, clientKey, err := client.RegisterApplication(ctx, bridgeIP, appName)
+		if err == nil {
+			return printPairResult(bridgeIP, username, clientKey)
+		}
+		if !errors.Is(err, client.ErrLinkButtonNotPressed) {
+			return fmt.Errorf("pairing failed: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("link button was not pressed within %v", pairTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+func printPairResult(bridgeIP, username, clientKey string) error {
+	if jsonOutput {
+		printJSON(map[string]string{
+			"bridge_ip":  bridgeIP,
+			"username":   username,
+			"client_key": clientKey,
+		})
+		return nil
+	}
+
+	printMessage("✅ Paired with bridge %s\n", bridgeIP)
+	fmt.Printf("export HUE_BRIDGE_IP=\"%s\"\n", bridgeIP)
+	fmt.Printf("export HUE_USERNAME=\"%s\"\n", username)
+	if clientKey != "" {
+		printMessage("\nClient key (needed for entertainment streaming): %s", clientKey)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(pairCmd)
+
+	pairCmd.Flags().StringVar(&pairBridgeIP, "bridge", "", "Bridge IP address (default from HUE_BRIDGE_IP)")
+	pairCmd.Flags().StringVar(&pairAppName, "name", "hue-mcp", "Application name registered with the bridge")
+	pairCmd.Flags().DurationVar(&pairTimeout, "timeout", 30*time.Second, "How long to wait for the link button")
+}
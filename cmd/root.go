@@ -12,8 +12,9 @@ import (
 
 var (
 	// Global flags
-	jsonOutput bool
-	quiet      bool
+	jsonOutput      bool
+	quiet           bool
+	groupPreference string
 	
 	// Shared Hue client
 	hueClient *client.Client
@@ -29,7 +30,7 @@ Control lights, groups, scenes, and effects directly from your terminal.
 Perfect for scripting, testing, or quick light adjustments.`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		// Skip client init for commands that don't need it
-		if cmd.Name() == "help" || cmd.Name() == "discover" {
+		if cmd.Name() == "help" || cmd.Name() == "discover" || cmd.Name() == "pair" {
 			return
 		}
 		
@@ -57,6 +58,13 @@ func initializeClient() {
 		os.Exit(1)
 	}
 	
+	if groupPreference != "" {
+		if err := mcp.SetGroupPreference(groupPreference); err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+	}
+	
 	// Initialize scheduler
 	mcp.InitScheduler(hueClient)
 }
@@ -65,6 +73,7 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress non-essential output")
+	rootCmd.PersistentFlags().StringVar(&groupPreference, "group-preference", "", "When a room and zone share a name: rooms, zones or error (default from HUE_GROUP_PREFERENCE, else rooms)")
 }
 
 // Helper functions for output
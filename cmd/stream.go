@@ -42,13 +42,6 @@ func runStream(cmd *cobra.Command, args []string) error {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
-	// Start event stream
-	eventStream, err := hueClient.StreamEvents(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to start event stream: %w", err)
-	}
-	defer eventStream.Close()
-
 	fmt.Println("🔴 Streaming live events (Ctrl+C to stop)...")
 	fmt.Println()
 
@@ -59,14 +52,49 @@ func runStream(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Filtering for: %s\n\n", streamFilter)
 	}
 
-	// Event loop
+	// Reconnect whenever the bridge drops the stream
 	for {
+		eventStream, err := hueClient.StreamEvents(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start event stream: %w", err)
+		}
+
+		stopped := watchStream(eventStream, filters, sigChan)
+		eventStream.Close()
+		if stopped {
+			fmt.Println("\n✋ Stopping event stream...")
+			return nil
+		}
+
+		printError("Event stream disconnected, reconnecting in %v", streamReconnectDelay)
 		select {
+		case <-time.After(streamReconnectDelay):
 		case <-sigChan:
 			fmt.Println("\n✋ Stopping event stream...")
 			return nil
+		}
+	}
+}
+
+// streamReconnectDelay is how long to wait before reconnecting a dropped stream
+const streamReconnectDelay = 5 * time.Second
+
+// watchStream prints events until the stream closes or an interrupt arrives.
+// It reports whether it was interrupted.
+func watchStream(eventStream *client.EventStream, filters []string, sigChan <-chan os.Signal) bool {
+	events := eventStream.Events()
+	errs := eventStream.Errors()
 
-		case event := <-eventStream.Events():
+	for events != nil || errs != nil {
+		select {
+		case <-sigChan:
+			return true
+
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
 			if shouldShowEvent(event, filters) {
 				if streamRaw {
 					printJSON(event)
@@ -75,10 +103,15 @@ func runStream(cmd *cobra.Command, args []string) error {
 				}
 			}
 
-		case err := <-eventStream.Errors():
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
 			printError("Stream error: %v", err)
 		}
 	}
+	return false
 }
 
 func shouldShowEvent(event client.Event, filters []string) bool {
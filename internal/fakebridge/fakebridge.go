@@ -0,0 +1,190 @@
+// Package fakebridge provides an in-memory CLIP v2 bridge for tests.
+package fakebridge
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Request captures a request made against the fake bridge
+type Request struct {
+	Method string
+	Path   string
+	Body   map[string]interface{}
+	Time   time.Time
+}
+
+// Bridge is an in-memory CLIP v2 bridge. Resources added to it are served from
+// /clip/v2/resource/<type>, and PUT, POST and DELETE requests update them.
+type Bridge struct {
+	t         testing.TB
+	mu        sync.Mutex
+	server    *httptest.Server
+	resources map[string][]map[string]interface{}
+	requests  []Request
+	failures  map[string]bool
+	nextID    int
+}
+
+// New starts a fake bridge that is shut down when the test finishes
+func New(t testing.TB) *Bridge {
+	b := &Bridge{
+		t:         t,
+		resources: make(map[string][]map[string]interface{}),
+		failures:  make(map[string]bool),
+	}
+	b.server = httptest.NewTLSServer(http.HandlerFunc(b.handle))
+	t.Cleanup(b.server.Close)
+	return b
+}
+
+// Host returns the bridge address to pass to client.NewClient
+func (b *Bridge) Host() string {
+	return strings.TrimPrefix(b.server.URL, "https://")
+}
+
+// HTTPClient returns an HTTP client that trusts the bridge's certificate
+func (b *Bridge) HTTPClient() *http.Client {
+	return b.server.Client()
+}
+
+// CertificatePEM returns the bridge's self-signed certificate, for pinning
+func (b *Bridge) CertificatePEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: b.server.Certificate().Raw})
+}
+
+// Add stores a resource of the given type
+func (b *Bridge) Add(rtype string, resource interface{}) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		b.t.Fatalf("failed to marshal %s: %v", rtype, err)
+	}
+
+	var obj map[string]interface{}
+	json.Unmarshal(data, &obj)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.resources[rtype] = append(b.resources[rtype], obj)
+}
+
+// Fail makes every later request with the method and exact path answer with a bridge error
+func (b *Bridge) Fail(method, path string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures[method+" "+path] = true
+}
+
+// RequestsFor returns recorded requests matching a method and path prefix
+func (b *Bridge) RequestsFor(method, pathPrefix string) []Request {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var matches []Request
+	for _, req := range b.requests {
+		if req.Method == method && strings.HasPrefix(req.Path, pathPrefix) {
+			matches = append(matches, req)
+		}
+	}
+	return matches
+}
+
+// apiError matches the bridge's error objects
+type apiError struct {
+	Description string `json:"description"`
+}
+
+func (b *Bridge) handle(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/clip/v2/resource/")
+	parts := strings.SplitN(path, "/", 2)
+	rtype := parts[0]
+	id := ""
+	if len(parts) > 1 {
+		id = parts[1]
+	}
+
+	var body map[string]interface{}
+	if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+		json.Unmarshal(data, &body)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.requests = append(b.requests, Request{Method: r.Method, Path: r.URL.Path, Body: body, Time: time.Now()})
+
+	if b.failures[r.Method+" "+r.URL.Path] {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data":   []interface{}{},
+			"errors": []apiError{{Description: "Simulated failure"}},
+		})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		data := []map[string]interface{}{}
+		for _, obj := range b.resources[rtype] {
+			if id == "" || obj["id"] == id {
+				data = append(data, obj)
+			}
+		}
+		if id != "" && len(data) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data":   []interface{}{},
+				"errors": []apiError{{Description: "Not Found"}},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data, "errors": []apiError{}})
+
+	case http.MethodPost:
+		b.nextID++
+		newID := fmt.Sprintf("new-%s-%d", rtype, b.nextID)
+		if body == nil {
+			body = map[string]interface{}{}
+		}
+		body["id"] = newID
+		b.resources[rtype] = append(b.resources[rtype], body)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data":   []map[string]string{{"rid": newID, "rtype": rtype}},
+			"errors": []apiError{},
+		})
+
+	case http.MethodPut:
+		for _, obj := range b.resources[rtype] {
+			if obj["id"] == id {
+				for k, v := range body {
+					obj[k] = v
+				}
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data":   []map[string]string{{"rid": id, "rtype": rtype}},
+			"errors": []apiError{},
+		})
+
+	case http.MethodDelete:
+		var kept []map[string]interface{}
+		for _, obj := range b.resources[rtype] {
+			if obj["id"] != id {
+				kept = append(kept, obj)
+			}
+		}
+		b.resources[rtype] = kept
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data":   []map[string]string{{"rid": id, "rtype": rtype}},
+			"errors": []apiError{},
+		})
+	}
+}
@@ -2,10 +2,13 @@ package main
 
 import (
 	"context"
-	"crypto/tls"
+	"fmt"
 	"log"
-	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -24,7 +27,7 @@ func main() {
 	}
 
 	// Check if it's a CLI command
-	cliCommands := []string{"lights", "groups", "effects", "scenes", "hue-scenes", "sensors", "batch", "stream", "discover", "help"}
+	cliCommands := []string{"lights", "groups", "effects", "scenes", "hue-scenes", "sensors", "batch", "stream", "discover", "pair", "help"}
 	for _, cmd := range cliCommands {
 		if os.Args[1] == cmd {
 			runCLI()
@@ -38,18 +41,69 @@ func main() {
 
 // runCLI initializes and runs the CLI interface
 func runCLI() {
-	// Initialize Hue client
-	hueClient := initHueClient()
-	
-	// Initialize scheduler
-	mcpserver.InitScheduler(hueClient)
+	// Discovery and pairing find the bridge and create credentials, so they run without a client
+	var hueClient *client.Client
+	if os.Args[1] != "pair" && os.Args[1] != "discover" {
+		// Initialize Hue client
+		hueClient = initHueClient()
+		
+		// Initialize scheduler
+		mcpserver.InitScheduler(hueClient)
+	}
 	
 	// Run CLI with the initialized client
 	cmd.Execute(hueClient)
 }
 
-// initHueClient creates and initializes a Hue client (shared by MCP and CLI)
+// initHueClient creates and initializes the primary Hue client (shared by MCP and CLI)
 func initHueClient() *client.Client {
+	return initBridges().Primary()
+}
+
+// initBridges creates a client for every configured bridge. HUE_BRIDGES holds a JSON array of
+// {name, ip, username, clientkey} objects, or the path of a file containing one. Without it the
+// single bridge from HUE_BRIDGE_IP and HUE_USERNAME is used.
+func initBridges() *client.ClientRegistry {
+	// Rooms win over zones with the same name unless configured otherwise
+	if preference := os.Getenv("HUE_GROUP_PREFERENCE"); preference != "" {
+		if err := mcpserver.SetGroupPreference(preference); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	bridges := client.NewClientRegistry()
+
+	if spec := os.Getenv("HUE_BRIDGES"); spec != "" {
+		data := []byte(spec)
+		if !strings.HasPrefix(strings.TrimSpace(spec), "[") {
+			var err error
+			if data, err = os.ReadFile(spec); err != nil {
+				log.Fatalf("Failed to read HUE_BRIDGES file: %v", err)
+			}
+		}
+
+		configs, err := client.ParseBridgeConfigs(data)
+		if err != nil {
+			log.Fatalf("Invalid HUE_BRIDGES: %v", err)
+		}
+
+		for i, cfg := range configs {
+			hueClient, err := newHueClient(cfg.IP, cfg.Username, cfg.CertFile, cfg.BridgeID)
+			if err != nil {
+				// Only the primary bridge is essential
+				if i == 0 {
+					log.Fatalf("Failed to connect to Hue bridge %s: %v", cfg.Name, err)
+				}
+				log.Printf("Warning: bridge %s is not reachable: %v", cfg.Name, err)
+				if hueClient == nil {
+					continue
+				}
+			}
+			bridges.Add(cfg.Name, hueClient)
+		}
+		return bridges
+	}
+
 	// Get configuration from environment
 	bridgeIP := os.Getenv("HUE_BRIDGE_IP")
 	if bridgeIP == "" {
@@ -61,48 +115,105 @@ func initHueClient() *client.Client {
 		log.Fatal("HUE_USERNAME environment variable is required")
 	}
 
-	// Create HTTP client that skips certificate verification for self-signed certs
-	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		},
+	hueClient, err := newHueClient(bridgeIP, username, os.Getenv("HUE_BRIDGE_CERT"), os.Getenv("HUE_BRIDGE_ID"))
+	if err != nil {
+		log.Fatalf("Failed to connect to Hue bridge: %v", err)
 	}
+	bridges.Add("default", hueClient)
+	return bridges
+}
 
-	// Initialize Hue client
-	hueClient := client.NewClient(bridgeIP, username, httpClient)
+// newHueClient creates a client for one bridge and checks that it can be reached. The bridge's
+// certificate is verified against certFile and, when bridgeID is set, must be issued to that
+// bridge. Without a certificate the bridge is not verified, with a warning unless
+// HUE_INSECURE_TLS=true says that is intended.
+func newHueClient(bridgeIP, username, certFile, bridgeID string) (*client.Client, error) {
+	var hueClient *client.Client
+	switch {
+	case certFile != "":
+		cert, err := os.ReadFile(certFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bridge certificate: %w", err)
+		}
+		hueClient, err = client.NewClientWithTLS(bridgeIP, username, cert, bridgeID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bridge certificate %s: %w", certFile, err)
+		}
+	case os.Getenv("HUE_INSECURE_TLS") == "true":
+		hueClient = client.NewInsecureClient(bridgeIP, username)
+	default:
+		log.Printf("Warning: no certificate for bridge %s, so it is not verified. Set HUE_BRIDGE_CERT (or \"cert\" in HUE_BRIDGES) to its PEM certificate, or HUE_INSECURE_TLS=true to silence this warning", bridgeIP)
+		hueClient = client.NewInsecureClient(bridgeIP, username)
+	}
 
-	// Test connection
-	ctx := context.Background()
-	if err := hueClient.TestConnection(ctx); err != nil {
-		log.Fatalf("Failed to connect to Hue bridge: %v", err)
+	// Optionally change the per-request timeout from the 30s default
+	if timeoutMs := os.Getenv("HUE_HTTP_TIMEOUT_MS"); timeoutMs != "" {
+		ms, err := strconv.Atoi(timeoutMs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HUE_HTTP_TIMEOUT_MS: %w", err)
+		}
+		hueClient.SetTimeout(time.Duration(ms) * time.Millisecond)
+	}
+
+	// Optionally serve repeated light/room/zone/device listings from a short-lived cache
+	if ttlMs := os.Getenv("HUE_CACHE_TTL_MS"); ttlMs != "" {
+		ms, err := strconv.Atoi(ttlMs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HUE_CACHE_TTL_MS: %w", err)
+		}
+		hueClient.SetCacheTTL(time.Duration(ms) * time.Millisecond)
+	}
+
+	// Optionally merge rapid successive group changes into a single request
+	if windowMs := os.Getenv("HUE_GROUP_COALESCE_MS"); windowMs != "" {
+		ms, err := strconv.Atoi(windowMs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HUE_GROUP_COALESCE_MS: %w", err)
+		}
+		hueClient.SetGroupCoalesceWindow(time.Duration(ms) * time.Millisecond)
 	}
 
-	return hueClient
+	// Test connection
+	return hueClient, hueClient.TestConnection(context.Background())
 }
 
 // runMCPServer runs the MCP server (original main function)
 func runMCPServer() {
-	// Initialize Hue client using shared function
-	hueClient := initHueClient()
+	// Initialize a client for each configured bridge; the primary serves everything not bridge-aware
+	bridges := initBridges()
+	hueClient := bridges.Primary()
 
 	// Initialize scheduler
 	mcpserver.InitScheduler(hueClient)
 
+	serverOptions := []server.ServerOption{
+		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(true, false),
+	}
+
+	// Optionally keep an audit trail of every control command
+	if auditPath := os.Getenv("HUE_AUDIT_LOG"); auditPath != "" {
+		audit, err := mcpserver.OpenAuditLog(auditPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer audit.Close()
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(audit.Middleware()))
+	}
+
 	// Create MCP server
 	srv := server.NewMCPServer(
 		"Philips Hue v2 MCP Server",
 		"1.0.0",
-		server.WithToolCapabilities(true),
-		server.WithResourceCapabilities(true, false),
+		serverOptions...,
 	)
 
 	// Register tools
-	registerLightTools(srv, hueClient)
-	registerGroupTools(srv, hueClient)
-	registerSceneTools(srv, hueClient)
+	registerLightTools(srv, bridges)
+	registerGroupTools(srv, bridges)
+	registerSceneTools(srv, bridges)
 	registerEffectTools(srv, hueClient)
-	registerSystemTools(srv, hueClient)
+	registerSystemTools(srv, bridges)
 	registerRoomTools(srv, hueClient)
 	registerSensorTools(srv, hueClient)
 	registerEntertainmentTools(srv, hueClient)
@@ -110,99 +221,273 @@ func runMCPServer() {
 	registerSchedulerTools(srv, hueClient)
 	registerEventTools(srv, hueClient)
 	registerCRUDTools(srv, hueClient)
+	registerAutomationTools(srv, hueClient)
+	registerMacroTools(srv, hueClient)
+
+	// Serve until stdin closes or the process is asked to stop
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
 	// Start server in stdio mode for Claude Desktop
 	log.Println("Starting Hue MCP server...")
-	if err := server.ServeStdio(srv); err != nil {
-		log.Fatalf("Server error: %v", err)
+	serveErr := server.NewStdioServer(srv).Listen(ctx, os.Stdin, os.Stdout)
+
+	// Stop effects and streams so lights aren't left mid-effect, without letting a stuck bridge hang the exit
+	log.Println("Shutting down Hue MCP server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	mcpserver.Shutdown(shutdownCtx)
+	cancel()
+
+	if serveErr != nil {
+		log.Fatalf("Server error: %v", serveErr)
 	}
 }
 
+// shutdownTimeout bounds how long stopping effects and streams may take on exit
+const shutdownTimeout = 5 * time.Second
+
+// bridgeArg lets light, group and scene tools target a bridge other than the primary
+var bridgeArg = mcp.WithString("bridge", mcp.Description("Name of the bridge to use when several are configured (default: the first)"))
+
+// formatArg lets list tools return a compact JSON array instead of text
+var formatArg = mcp.WithString("format", mcp.Description("Output format: text (default) or json for a compact array of objects"))
+
+// Paging arguments for list tools that can return long lists in big homes
+var (
+	nameFilterArg = mcp.WithString("name_filter", mcp.Description("Only list entries whose name contains this text (case-insensitive)"))
+	offsetArg     = mcp.WithNumber("offset", mcp.Description("Number of entries to skip (default: 0)"))
+	limitArg      = mcp.WithNumber("limit", mcp.Description("Maximum number of entries to return (default: all). The total is reported so you can page through the rest"))
+)
+
 // registerLightTools adds individual light control tools
-func registerLightTools(srv *server.MCPServer, client *client.Client) {
+func registerLightTools(srv *server.MCPServer, bridges *client.ClientRegistry) {
 	// Light on/off
 	lightOnTool := mcp.NewTool("light_on",
 		mcp.WithDescription("Turn a light on"),
 		mcp.WithString("light_id", mcp.Required(), mcp.Description("The ID of the light")),
+		bridgeArg,
 	)
-	srv.AddTool(lightOnTool, mcpserver.HandleLightOn(client))
+	srv.AddTool(lightOnTool, mcpserver.WithBridge(bridges, mcpserver.HandleLightOn))
 
 	lightOffTool := mcp.NewTool("light_off",
 		mcp.WithDescription("Turn a light off"),
 		mcp.WithString("light_id", mcp.Required(), mcp.Description("The ID of the light")),
+		bridgeArg,
 	)
-	srv.AddTool(lightOffTool, mcpserver.HandleLightOff(client))
+	srv.AddTool(lightOffTool, mcpserver.WithBridge(bridges, mcpserver.HandleLightOff))
 
 	// Brightness control
 	brightnessTool := mcp.NewTool("light_brightness",
-		mcp.WithDescription("Set light brightness"),
+		mcp.WithDescription("Set light brightness. Values below the light's minimum dim level snap to that minimum, or turn the light off with off_below_min."),
 		mcp.WithString("light_id", mcp.Required(), mcp.Description("The ID of the light")),
 		mcp.WithNumber("brightness", mcp.Required(), mcp.Description("Brightness percentage (0-100)")),
+		mcp.WithBoolean("off_below_min", mcp.Description("Turn the light off instead of snapping to its minimum when brightness is below it")),
+		bridgeArg,
 	)
-	srv.AddTool(brightnessTool, mcpserver.HandleLightBrightness(client))
+	srv.AddTool(brightnessTool, mcpserver.WithBridge(bridges, mcpserver.HandleLightBrightness))
 
 	// Color control
 	colorTool := mcp.NewTool("light_color",
-		mcp.WithDescription("Set light color"),
+		mcp.WithDescription("Set light color from a color or exact CIE xy coordinates (one of color or xy is required)"),
 		mcp.WithString("light_id", mcp.Required(), mcp.Description("The ID of the light")),
-		mcp.WithString("color", mcp.Required(), mcp.Description("Color as hex code (e.g., #FF0000) or color name")),
+		mcp.WithString("color", mcp.Description("Color as hex (#FF0000 or #F00), rgb(255,0,0), hsv(0,100,100) or color name")),
+		mcp.WithString("xy", mcp.Description("CIE xy coordinates as \"x,y\", each 0-1 (e.g. \"0.45,0.41\")")),
+		bridgeArg,
 	)
-	srv.AddTool(colorTool, mcpserver.HandleLightColor(client))
+	srv.AddTool(colorTool, mcpserver.WithBridge(bridges, mcpserver.HandleLightColor))
+
+	// Combined state
+	lightStateTool := mcp.NewTool("set_light_state",
+		mcp.WithDescription("Set several light properties at once in a single request (avoids flicker from separate calls). Brightness below the light's minimum dim level snaps to that minimum, or turns the light off with off_below_min."),
+		mcp.WithString("light_id", mcp.Required(), mcp.Description("The ID or name of the light")),
+		mcp.WithBoolean("on", mcp.Description("Turn the light on or off")),
+		mcp.WithNumber("brightness", mcp.Description("Brightness percentage (0-100)")),
+		mcp.WithBoolean("off_below_min", mcp.Description("Turn the light off instead of snapping to its minimum when brightness is below it")),
+		mcp.WithString("color", mcp.Description("Color as hex, rgb(...), hsv(...) or color name (not with color_temp)")),
+		mcp.WithNumber("color_temp", mcp.Description("Color temperature in mirek, 153 (cool) to 500 (warm) (not with color)")),
+		mcp.WithNumber("kelvin", mcp.Description("Color temperature in Kelvin, 2000 (warm) to 6535 (cool), instead of color_temp")),
+		mcp.WithNumber("transition_ms", mcp.Description("Transition duration in milliseconds")),
+		bridgeArg,
+	)
+	srv.AddTool(lightStateTool, mcpserver.WithBridge(bridges, mcpserver.HandleSetLightState))
 }
 
 // registerGroupTools adds group control tools
-func registerGroupTools(srv *server.MCPServer, client *client.Client) {
+func registerGroupTools(srv *server.MCPServer, bridges *client.ClientRegistry) {
 	// Group on/off
 	groupOnTool := mcp.NewTool("group_on",
 		mcp.WithDescription("Turn a group of lights on"),
 		mcp.WithString("group_id", mcp.Required(), mcp.Description("The ID of the group")),
+		bridgeArg,
 	)
-	srv.AddTool(groupOnTool, mcpserver.HandleGroupOn(client))
+	srv.AddTool(groupOnTool, mcpserver.WithBridge(bridges, mcpserver.HandleGroupOn))
 
 	groupOffTool := mcp.NewTool("group_off",
 		mcp.WithDescription("Turn a group of lights off"),
 		mcp.WithString("group_id", mcp.Required(), mcp.Description("The ID of the group")),
+		bridgeArg,
+	)
+	srv.AddTool(groupOffTool, mcpserver.WithBridge(bridges, mcpserver.HandleGroupOff))
+
+	// Every light at once
+	allOffTool := mcp.NewTool("all_lights_off",
+		mcp.WithDescription("Turn off every light in the home"),
+		bridgeArg,
 	)
-	srv.AddTool(groupOffTool, mcpserver.HandleGroupOff(client))
+	srv.AddTool(allOffTool, mcpserver.WithBridge(bridges, mcpserver.HandleAllLightsOff))
+
+	allOnTool := mcp.NewTool("all_lights_on",
+		mcp.WithDescription("Turn on every light in the home"),
+		bridgeArg,
+	)
+	srv.AddTool(allOnTool, mcpserver.WithBridge(bridges, mcpserver.HandleAllLightsOn))
 
 	// Group brightness
 	groupBrightnessTool := mcp.NewTool("group_brightness",
 		mcp.WithDescription("Set group brightness"),
 		mcp.WithString("group_id", mcp.Required(), mcp.Description("Brightness percentage (0-100)")),
+		bridgeArg,
 	)
-	srv.AddTool(groupBrightnessTool, mcpserver.HandleGroupBrightness(client))
+	srv.AddTool(groupBrightnessTool, mcpserver.WithBridge(bridges, mcpserver.HandleGroupBrightness))
 
 	// Group color
 	groupColorTool := mcp.NewTool("group_color",
-		mcp.WithDescription("Set group color"),
+		mcp.WithDescription("Set group color from a color or exact CIE xy coordinates (one of color or xy is required)"),
 		mcp.WithString("group_id", mcp.Required(), mcp.Description("The ID of the group")),
-		mcp.WithString("color", mcp.Required(), mcp.Description("Color as hex code or name")),
+		mcp.WithString("color", mcp.Description("Color as hex (#FF0000 or #F00), rgb(255,0,0), hsv(0,100,100) or color name")),
+		mcp.WithString("xy", mcp.Description("CIE xy coordinates as \"x,y\", each 0-1 (e.g. \"0.45,0.41\")")),
+		mcp.WithBoolean("only_on", mcp.Description("Only affect lights that are already on")),
+		mcp.WithBoolean("per_light", mcp.Description("Set the color light by light, converted for each light's gamut, so mixed bulbs match (one request per light)")),
+		bridgeArg,
+	)
+	srv.AddTool(groupColorTool, mcpserver.WithBridge(bridges, mcpserver.HandleGroupColor))
+
+	// Combined state
+	groupStateTool := mcp.NewTool("set_group_state",
+		mcp.WithDescription("Set several group properties at once in a single request (avoids flicker from separate calls)"),
+		mcp.WithString("group_id", mcp.Required(), mcp.Description("The ID or name of the room, zone or group")),
+		mcp.WithBoolean("on", mcp.Description("Turn the group on or off")),
+		mcp.WithNumber("brightness", mcp.Description("Brightness percentage (0-100)")),
+		mcp.WithString("color", mcp.Description("Color as hex, rgb(...), hsv(...) or color name (not with color_temp)")),
+		mcp.WithNumber("color_temp", mcp.Description("Color temperature in mirek, 153 (cool) to 500 (warm) (not with color)")),
+		mcp.WithNumber("kelvin", mcp.Description("Color temperature in Kelvin, 2000 (warm) to 6535 (cool), instead of color_temp")),
+		mcp.WithNumber("transition_ms", mcp.Description("Transition duration in milliseconds")),
+		bridgeArg,
 	)
-	srv.AddTool(groupColorTool, mcpserver.HandleGroupColor(client))
+	srv.AddTool(groupStateTool, mcpserver.WithBridge(bridges, mcpserver.HandleSetGroupState))
+
+	whitePresetTool := mcp.NewTool("white_preset",
+		mcp.WithDescription("Turn a light or group on at a white color temperature and brightness in one request. Defaults to a warm reading light: 3000K at 80%."),
+		mcp.WithString("light_id", mcp.Description("The ID or name of the light (or use group_id)")),
+		mcp.WithString("group_id", mcp.Description("The ID or name of the room, zone or group (or use light_id)")),
+		mcp.WithNumber("kelvin", mcp.Description("Color temperature in Kelvin, 2000 (warm) to 6535 (cool), default 3000")),
+		mcp.WithNumber("brightness", mcp.Description("Brightness percentage (0-100), default 80")),
+		mcp.WithNumber("transition_ms", mcp.Description("Transition duration in milliseconds")),
+		bridgeArg,
+	)
+	srv.AddTool(whitePresetTool, mcpserver.WithBridge(bridges, mcpserver.HandleWhitePreset))
+
+	// Group state
+	getGroupStateTool := mcp.NewTool("get_group_state",
+		mcp.WithDescription("Get the current state of a room, zone or group: on/off, brightness, color, color temperature and how many of its lights are on"),
+		mcp.WithString("group_id", mcp.Required(), mcp.Description("The ID or name of the room, zone or group")),
+		bridgeArg,
+	)
+	srv.AddTool(getGroupStateTool, mcpserver.WithBridge(bridges, mcpserver.HandleGetGroupState))
 }
 
 // registerSceneTools adds scene management tools
-func registerSceneTools(srv *server.MCPServer, client *client.Client) {
+func registerSceneTools(srv *server.MCPServer, bridges *client.ClientRegistry) {
 	// List scenes
 	listScenesTool := mcp.NewTool("list_scenes",
 		mcp.WithDescription("List all available scenes"),
+		formatArg,
+		nameFilterArg,
+		offsetArg,
+		limitArg,
+		bridgeArg,
 	)
-	srv.AddTool(listScenesTool, mcpserver.HandleListScenes(client))
+	srv.AddTool(listScenesTool, mcpserver.WithBridge(bridges, mcpserver.HandleListScenes))
 
 	// Activate scene
 	activateSceneTool := mcp.NewTool("activate_scene",
-		mcp.WithDescription("Activate a scene"),
+		mcp.WithDescription("Activate a scene, optionally at a different brightness or with a slower transition"),
 		mcp.WithString("scene_id", mcp.Required(), mcp.Description("The ID of the scene")),
+		mcp.WithNumber("brightness", mcp.Description("Override the scene's brightness (0-100)")),
+		mcp.WithNumber("transition_ms", mcp.Description("Transition duration in milliseconds")),
+		mcp.WithString("mode", mcp.Description("static (default) or dynamic to slowly cycle through the scene's palette, as in the built-in animated scenes like Candlelight")),
+		bridgeArg,
+	)
+	srv.AddTool(activateSceneTool, mcpserver.WithBridge(bridges, mcpserver.HandleActivateScene))
+
+	// Active scene
+	activeSceneTool := mcp.NewTool("active_scene",
+		mcp.WithDescription("Show which scene a room or zone is currently in"),
+		mcp.WithString("group_id", mcp.Required(), mcp.Description("Room or zone name, or a group ID")),
+		bridgeArg,
 	)
-	srv.AddTool(activateSceneTool, mcpserver.HandleActivateScene(client))
+	srv.AddTool(activeSceneTool, mcpserver.WithBridge(bridges, mcpserver.HandleActiveScene))
+
+	// Get scene
+	getSceneTool := mcp.NewTool("get_scene",
+		mcp.WithDescription("Show what a scene does: the room or zone it belongs to and the on/off state, brightness and color it sets on each light"),
+		mcp.WithString("scene_id", mcp.Required(), mcp.Description("Scene name or ID")),
+		bridgeArg,
+	)
+	srv.AddTool(getSceneTool, mcpserver.WithBridge(bridges, mcpserver.HandleGetScene))
+
+	// Scenes using a light
+	scenesUsingLightTool := mcp.NewTool("scenes_using_light",
+		mcp.WithDescription("List the scenes that set a light, directly or through its room or zone. Check this before deleting or moving a light."),
+		mcp.WithString("light_id", mcp.Required(), mcp.Description("Light name or ID")),
+		bridgeArg,
+	)
+	srv.AddTool(scenesUsingLightTool, mcpserver.WithBridge(bridges, mcpserver.HandleScenesUsingLight))
+
+	// Recall scene by number
+	recallSceneByIndexTool := mcp.NewTool("recall_scene_by_index",
+		mcp.WithDescription("Activate a scene by the number shown in list_scenes. Numbers stay the same for the whole session."),
+		mcp.WithNumber("index", mcp.Required(), mcp.Description("Scene number from list_scenes")),
+		bridgeArg,
+	)
+	srv.AddTool(recallSceneByIndexTool, mcpserver.WithBridge(bridges, mcpserver.HandleRecallSceneByIndex))
 
 	// Create scene
 	createSceneTool := mcp.NewTool("create_scene",
 		mcp.WithDescription("Create a new scene from current light states"),
 		mcp.WithString("name", mcp.Required(), mcp.Description("Name for the scene")),
-		mcp.WithString("group_id", mcp.Required(), mcp.Description("Group to capture")),
+		mcp.WithString("group_id", mcp.Required(), mcp.Description("Group, room or zone ID to capture")),
+		bridgeArg,
+	)
+	srv.AddTool(createSceneTool, mcpserver.WithBridge(bridges, mcpserver.HandleCreateScene))
+
+	// Create palette scene
+	createPaletteSceneTool := mcp.NewTool("create_palette_scene",
+		mcp.WithDescription("Create a multi-color scene from a palette. The colors are spread across the lights, and activating the scene in dynamic mode slowly shifts between them."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name for the scene")),
+		mcp.WithString("group_id", mcp.Required(), mcp.Description("Room, zone or group name or ID")),
+		mcp.WithString("colors", mcp.Required(), mcp.Description("JSON array of up to 9 colors, e.g. [\"crimson\", \"#FF8000\", \"hsv(280,80,100)\"]")),
+		mcp.WithNumber("brightness", mcp.Description("Brightness percentage (1-100, default: 100)")),
+		mcp.WithBoolean("auto_dynamic", mcp.Description("Start dynamic mode on every activation, not only when activated with mode 'dynamic' (default: false)")),
+		bridgeArg,
+	)
+	srv.AddTool(createPaletteSceneTool, mcpserver.WithBridge(bridges, mcpserver.HandleCreatePaletteScene))
+
+	// Scene preview
+	previewSceneTool := mcp.NewTool("preview_scene",
+		mcp.WithDescription("Try a scene briefly: activates it, then restores the lights' previous states after duration_ms. Returns a preview ID for stop_preview."),
+		mcp.WithString("scene_id", mcp.Required(), mcp.Description("The ID or name of the scene")),
+		mcp.WithNumber("duration_ms", mcp.Description("How long to show the scene before reverting (default: 5000)")),
+		bridgeArg,
+	)
+	srv.AddTool(previewSceneTool, mcpserver.WithBridge(bridges, mcpserver.HandlePreviewScene))
+
+	stopPreviewTool := mcp.NewTool("stop_preview",
+		mcp.WithDescription("End a scene preview early, keeping the scene (default) or restoring the previous states now"),
+		mcp.WithString("preview_id", mcp.Required(), mcp.Description("The preview ID returned by preview_scene")),
+		mcp.WithBoolean("restore", mcp.Description("Restore the previous light states instead of keeping the scene")),
 	)
-	srv.AddTool(createSceneTool, mcpserver.HandleCreateScene(client))
+	srv.AddTool(stopPreviewTool, mcpserver.HandleStopPreview(bridges.Primary()))
 }
 
 // registerEffectTools adds native effect tools
@@ -223,36 +508,66 @@ func registerEffectTools(srv *server.MCPServer, client *client.Client) {
 			mcp.Description("Effect to apply"),
 			mcp.Enum(supportedEffects...),
 		),
-		mcp.WithNumber("duration", mcp.Description("Duration in seconds (0 for infinite)")),
+		mcp.WithNumber("duration", mcp.Description("Seconds before the effect is stopped automatically (0 runs until changed)")),
+		mcp.WithNumber("speed", mcp.Description("Effect speed from 0 (slowest) to 1 (fastest), e.g. a faster candle flicker")),
+		mcp.WithString("color", mcp.Description("Color for effects that take one, in hex (#FF8800) or a color name")),
 	)
 	srv.AddTool(lightEffectTool, mcpserver.HandleLightEffect(client))
 
 	// Set effect on group
 	groupEffectTool := mcp.NewTool("group_effect",
-		mcp.WithDescription("Set a dynamic effect on a group"),
-		mcp.WithString("group_id", mcp.Required(), mcp.Description("The ID of the group")),
+		mcp.WithDescription("Set a dynamic effect on a group. Fails, naming them, if some of the group's lights don't support the effect unless only_supporting is set"),
+		mcp.WithString("group_id", mcp.Required(), mcp.Description("The ID or name of the group")),
 		mcp.WithString("effect", mcp.Required(),
 			mcp.Description("Effect to apply"),
 			mcp.Enum(supportedEffects...),
 		),
-		mcp.WithNumber("duration", mcp.Description("Duration in seconds (0 for infinite)")),
+		mcp.WithNumber("duration", mcp.Description("Seconds before the effect is stopped automatically (0 runs until changed)")),
+		mcp.WithNumber("speed", mcp.Description("Effect speed from 0 (slowest) to 1 (fastest), e.g. a faster candle flicker")),
+		mcp.WithString("color", mcp.Description("Color for effects that take one, in hex (#FF8800) or a color name")),
+		mcp.WithBoolean("only_on", mcp.Description("Only affect lights that are already on")),
+		mcp.WithBoolean("only_supporting", mcp.Description("Apply the effect to the lights that support it instead of failing when some don't")),
 	)
 	srv.AddTool(groupEffectTool, mcpserver.HandleGroupEffect(client))
+
+	// List effects
+	listEffectsTool := mcp.NewTool("list_effects",
+		mcp.WithDescription("List the effects a light supports and its active effect, or every effect supported by any light"),
+		mcp.WithString("light_id", mcp.Description("Light ID or name (omit to list effects across all lights)")),
+	)
+	srv.AddTool(listEffectsTool, mcpserver.HandleListEffects(client))
 }
 
 // registerSystemTools adds system and discovery tools
-func registerSystemTools(srv *server.MCPServer, client *client.Client) {
+func registerSystemTools(srv *server.MCPServer, bridges *client.ClientRegistry) {
+	client := bridges.Primary()
+
 	// List lights
 	listLightsTool := mcp.NewTool("list_lights",
 		mcp.WithDescription("List all available lights"),
+		formatArg,
+		nameFilterArg,
+		offsetArg,
+		limitArg,
+		bridgeArg,
 	)
-	srv.AddTool(listLightsTool, mcpserver.HandleListLights(client))
+	srv.AddTool(listLightsTool, mcpserver.WithBridge(bridges, mcpserver.HandleListLights))
 
 	// List groups
 	listGroupsTool := mcp.NewTool("list_groups",
 		mcp.WithDescription("List all available groups/rooms"),
+		formatArg,
+		bridgeArg,
 	)
-	srv.AddTool(listGroupsTool, mcpserver.HandleListGroups(client))
+	srv.AddTool(listGroupsTool, mcpserver.WithBridge(bridges, mcpserver.HandleListGroups))
+
+	// Search by name
+	searchResourcesTool := mcp.NewTool("search_resources",
+		mcp.WithDescription("Find lights, rooms, zones and scenes whose name contains a query (case-insensitive). Cheaper than listing everything."),
+		mcp.WithString("query", mcp.Required(), mcp.Description("Part of the name to search for")),
+		mcp.WithString("type", mcp.Description("Only search one type: light, group (rooms and zones), room, zone or scene")),
+	)
+	srv.AddTool(searchResourcesTool, mcpserver.HandleSearchResources(client))
 
 	// Get light state
 	getLightStateTool := mcp.NewTool("get_light_state",
@@ -267,12 +582,38 @@ func registerSystemTools(srv *server.MCPServer, client *client.Client) {
 	)
 	srv.AddTool(bridgeInfoTool, mcpserver.HandleBridgeInfo(client))
 
+	// Bridge update status
+	bridgeUpdateStatusTool := mcp.NewTool("bridge_update_status",
+		mcp.WithDescription("Show the bridge's software version and whether a firmware update is available or installing"),
+	)
+	srv.AddTool(bridgeUpdateStatusTool, mcpserver.HandleBridgeUpdateStatus(client))
+
+	// System summary
+	systemSummaryTool := mcp.NewTool("system_summary",
+		mcp.WithDescription("One-shot overview: light counts and how many are on, estimated power draw, room/zone/scene counts and sensor states"),
+	)
+	srv.AddTool(systemSummaryTool, mcpserver.HandleSystemSummary(client))
+
 	// Identify light
 	identifyLightTool := mcp.NewTool("identify_light",
 		mcp.WithDescription("Make a light blink to identify it"),
 		mcp.WithString("light_id", mcp.Required(), mcp.Description("The ID of the light")),
 	)
 	srv.AddTool(identifyLightTool, mcpserver.HandleIdentifyLight(client))
+
+	identifyGroupTool := mcp.NewTool("identify_group",
+		mcp.WithDescription("Make every light in a room, zone or group breathe to find which bulbs belong to it. Lights are listed in the order they blink."),
+		mcp.WithString("group_id", mcp.Required(), mcp.Description("The ID or name of the room, zone or group")),
+		mcp.WithNumber("stagger_ms", mcp.Description("Delay between lights for a wave (0-5000, default: 0 for all together)")),
+	)
+	srv.AddTool(identifyGroupTool, mcpserver.HandleIdentifyGroup(client))
+
+	// Color names
+	listColorsTool := mcp.NewTool("list_colors",
+		mcp.WithDescription("List the color names accepted by color tools (the CSS named colors plus warm/cool white)"),
+		mcp.WithString("filter", mcp.Description("Only list names containing this text (e.g. 'blue')")),
+	)
+	srv.AddTool(listColorsTool, mcpserver.HandleListColors(client))
 }
 
 // registerRoomTools adds room and zone control tools
@@ -280,15 +621,30 @@ func registerRoomTools(srv *server.MCPServer, client *client.Client) {
 	// List rooms
 	listRoomsTool := mcp.NewTool("list_rooms",
 		mcp.WithDescription("List all rooms with their lights"),
+		formatArg,
 	)
 	srv.AddTool(listRoomsTool, mcpserver.HandleListRooms(client))
 
+	// Lights grouped by room
+	listLightsByRoomTool := mcp.NewTool("list_lights_by_room",
+		mcp.WithDescription("List lights nested under the room they are in, with on/off and brightness; lights in no room are listed as unassigned"),
+	)
+	srv.AddTool(listLightsByRoomTool, mcpserver.HandleListLightsByRoom(client))
+
 	// List zones
 	listZonesTool := mcp.NewTool("list_zones",
 		mcp.WithDescription("List all zones"),
+		formatArg,
 	)
 	srv.AddTool(listZonesTool, mcpserver.HandleListZones(client))
 
+	// Zone state
+	getZoneStateTool := mcp.NewTool("get_zone_state",
+		mcp.WithDescription("Get the current state of a zone: on/off, brightness, color and each of its member lights"),
+		mcp.WithString("zone_id", mcp.Required(), mcp.Description("Zone name or ID")),
+	)
+	srv.AddTool(getZoneStateTool, mcpserver.HandleGetZoneState(client))
+
 	// List devices
 	listDevicesTool := mcp.NewTool("list_devices",
 		mcp.WithDescription("List all devices with their details"),
@@ -311,6 +667,25 @@ func registerSensorTools(srv *server.MCPServer, client *client.Client) {
 	)
 	srv.AddTool(listMotionTool, mcpserver.HandleListMotionSensors(client))
 
+	enableMotionTool := mcp.NewTool("enable_motion_sensor",
+		mcp.WithDescription("Turn a motion sensor back on so it triggers its automations"),
+		mcp.WithString("sensor_id", mcp.Required(), mcp.Description("Motion sensor ID from list_motion_sensors")),
+	)
+	srv.AddTool(enableMotionTool, mcpserver.HandleEnableMotionSensor(client))
+
+	disableMotionTool := mcp.NewTool("disable_motion_sensor",
+		mcp.WithDescription("Turn a motion sensor off so it stops triggering lights (e.g. during a movie)"),
+		mcp.WithString("sensor_id", mcp.Required(), mcp.Description("Motion sensor ID from list_motion_sensors")),
+	)
+	srv.AddTool(disableMotionTool, mcpserver.HandleDisableMotionSensor(client))
+
+	motionSensitivityTool := mcp.NewTool("set_motion_sensitivity",
+		mcp.WithDescription("Set a motion sensor's sensitivity, on sensors whose firmware supports it (see get_sensor for the range)"),
+		mcp.WithString("sensor_id", mcp.Required(), mcp.Description("Motion sensor ID from list_motion_sensors")),
+		mcp.WithNumber("sensitivity", mcp.Required(), mcp.Description("Sensitivity from 0 up to the sensor's maximum")),
+	)
+	srv.AddTool(motionSensitivityTool, mcpserver.HandleSetMotionSensitivity(client))
+
 	// Temperature sensors
 	listTempTool := mcp.NewTool("list_temperature_sensors",
 		mcp.WithDescription("List all temperature sensors and their readings"),
@@ -328,6 +703,26 @@ func registerSensorTools(srv *server.MCPServer, client *client.Client) {
 		mcp.WithDescription("List all buttons (dimmer switches) and their last events"),
 	)
 	srv.AddTool(listButtonsTool, mcpserver.HandleListButtons(client))
+
+	// Contact sensors
+	listContactTool := mcp.NewTool("list_contact_sensors",
+		mcp.WithDescription("List contact sensors (door/window sensors) and whether each is open or closed"),
+	)
+	srv.AddTool(listContactTool, mcpserver.HandleListContactSensors(client))
+
+	// Single sensor detail
+	getSensorTool := mcp.NewTool("get_sensor",
+		mcp.WithDescription("Get one sensor by ID with its current reading and the device and room it belongs to"),
+		mcp.WithString("sensor_id", mcp.Required(), mcp.Description("Sensor ID from one of the list_*_sensors tools")),
+		mcp.WithString("type", mcp.Required(), mcp.Description("Sensor type: motion, temperature, light_level, button or contact")),
+	)
+	srv.AddTool(getSensorTool, mcpserver.HandleGetSensor(client))
+
+	// Battery levels
+	listBatteryTool := mcp.NewTool("list_battery_levels",
+		mcp.WithDescription("List battery levels of sensors and remotes, flagging any below 20%"),
+	)
+	srv.AddTool(listBatteryTool, mcpserver.HandleListBatteryLevels(client))
 }
 
 // registerEntertainmentTools adds entertainment configuration tools
@@ -357,6 +752,7 @@ func registerEntertainmentTools(srv *server.MCPServer, client *client.Client) {
 		mcp.WithDescription("Start UDP streaming for real-time color updates"),
 		mcp.WithString("config_id", mcp.Required(), mcp.Description("The ID of the entertainment configuration")),
 		mcp.WithString("update_rate_ms", mcp.Description("Update rate in milliseconds (default: 50)")),
+		mcp.WithString("ease_ms", mcp.Description("Tween toward each new color over this many milliseconds instead of jumping (default: off)")),
 	)
 	srv.AddTool(startStreamTool, mcpserver.HandleStartStreaming(client))
 
@@ -388,6 +784,49 @@ func registerEntertainmentTools(srv *server.MCPServer, client *client.Client) {
 		mcp.WithString("duration", mcp.Description("Duration in seconds (default: 10)")),
 	)
 	srv.AddTool(rainbowTool, mcpserver.HandleRainbowEffect(client))
+
+	// Chase effect
+	chaseTool := mcp.NewTool("chase_effect",
+		mcp.WithDescription("Send a bright dot with a fading tail chasing around the streaming lights"),
+		mcp.WithString("config_id", mcp.Required(), mcp.Description("The ID of the entertainment configuration")),
+		mcp.WithString("color", mcp.Required(), mcp.Description("Color of the dot (name, hex, rgb() or hsv())")),
+		mcp.WithNumber("duration", mcp.Description("Duration in seconds (default: 10)")),
+		mcp.WithNumber("tail_length", mcp.Description("Number of fading lights behind the dot (default: 2)")),
+		mcp.WithNumber("speed", mcp.Description("Lights the dot moves per second (default: 4)")),
+	)
+	srv.AddTool(chaseTool, mcpserver.HandleChaseEffect(client))
+
+	// Color wipe
+	wipeTool := mcp.NewTool("color_wipe",
+		mcp.WithDescription("Fill the streaming lights one by one with a color"),
+		mcp.WithString("config_id", mcp.Required(), mcp.Description("The ID of the entertainment configuration")),
+		mcp.WithString("color", mcp.Required(), mcp.Description("Color to wipe in (name, hex, rgb() or hsv())")),
+		mcp.WithNumber("duration", mcp.Description("Seconds taken to fill every light (default: 10)")),
+	)
+	srv.AddTool(wipeTool, mcpserver.HandleColorWipe(client))
+
+	// Audio sync
+	startAudioTool := mcp.NewTool("start_audio_sync",
+		mcp.WithDescription("Make streaming lights pulse to music. Bass, mid and treble are mapped to brightness and color across the entertainment lights; feed it with push_audio_level"),
+		mcp.WithString("config_id", mcp.Required(), mcp.Description("The ID of the entertainment configuration (must already be streaming)")),
+	)
+	srv.AddTool(startAudioTool, mcpserver.HandleStartAudioSync(client))
+
+	stopAudioTool := mcp.NewTool("stop_audio_sync",
+		mcp.WithDescription("Stop audio sync and return the stream to normal color updates"),
+		mcp.WithString("config_id", mcp.Required(), mcp.Description("The ID of the entertainment configuration")),
+	)
+	srv.AddTool(stopAudioTool, mcpserver.HandleStopAudioSync(client))
+
+	pushAudioTool := mcp.NewTool("push_audio_level",
+		mcp.WithDescription("Feed an audio amplitude sample, or explicit band levels, to a running audio sync"),
+		mcp.WithString("config_id", mcp.Required(), mcp.Description("The ID of the entertainment configuration")),
+		mcp.WithNumber("level", mcp.Description("Overall amplitude 0-1, split into bands automatically")),
+		mcp.WithNumber("bass", mcp.Description("Bass level 0-1 (overrides level)")),
+		mcp.WithNumber("mid", mcp.Description("Mid level 0-1 (overrides level)")),
+		mcp.WithNumber("treble", mcp.Description("Treble level 0-1 (overrides level)")),
+	)
+	srv.AddTool(pushAudioTool, mcpserver.HandlePushAudioLevel(client))
 }
 
 // registerBatchTools adds batch request capability for efficiency
@@ -395,13 +834,24 @@ func registerBatchTools(srv *server.MCPServer, client *client.Client) {
 	// Batch commands
 	batchTool := mcp.NewTool("batch_commands",
 		mcp.WithDescription("Execute multiple lighting commands in sequence with timing control. By default runs asynchronously (returns immediately) so you can continue working while lights change. Perfect for creating simple animations or coordinated lighting changes across multiple lights. Can optionally cache complex scenes for instant recall later!"),
-		mcp.WithString("commands", mcp.Required(), mcp.Description("JSON array of commands. Example: [{\"action\":\"light_on\",\"target_id\":\"abc123\"}, {\"action\":\"light_color\",\"target_id\":\"abc123\",\"value\":\"#FF0000\"}, {\"action\":\"light_brightness\",\"target_id\":\"abc123\",\"value\":\"75\"}]")),
+		mcp.WithString("commands", mcp.Required(), mcp.Description("JSON array of commands. Example: [{\"action\":\"light_on\",\"target_id\":\"abc123\"}, {\"action\":\"light_color\",\"target_id\":\"abc123\",\"value\":\"#FF0000\"}, {\"action\":\"light_brightness\",\"target_id\":\"abc123\",\"value\":\"75\"}]. light_color_temp and group_color_temp take a value in mirek (153-500). target_id may be an ID or a light/group/scene name. With parallel, add \"at_ms\" to a command to fire it that many milliseconds after the start")),
 		mcp.WithNumber("delay_ms", mcp.Description("Milliseconds to wait between each command - use for timing effects (default: 100)")),
 		mcp.WithBoolean("async", mcp.Description("Run in background (true) or wait for completion (false). Default true = non-blocking")),
+		mcp.WithBoolean("dry_run", mcp.Description("Preview the resolved commands and their timing without touching the lights")),
+		mcp.WithBoolean("auto_snapshot", mcp.Description("Snapshot the lights this batch touches first, so restore_state can undo it")),
+		mcp.WithBoolean("atomic", mcp.Description("Stop at the first failed command and roll the lights back to the auto snapshot (requires auto_snapshot; runs synchronously)")),
+		mcp.WithBoolean("parallel", mcp.Description("Fire commands simultaneously instead of one after another. Commands sharing an optional \"at_ms\" offset (default 0) run together; delay_ms is ignored")),
 		mcp.WithString("cache_name", mcp.Description("Optional: Save this sequence as a named scene for instant recall later (e.g., 'alien_artifact_discovery')")),
 		mcp.WithString("cache_description", mcp.Description("Optional: Description of the cached scene to help remember its purpose")),
 	)
 	srv.AddTool(batchTool, mcpserver.HandleBatchCommands(client))
+
+	// Batch status
+	batchStatusTool := mcp.NewTool("get_batch_status",
+		mcp.WithDescription("Check the outcome of an async batch_commands run: per-command success or failure and whether it has finished"),
+		mcp.WithString("batch_id", mcp.Required(), mcp.Description("Batch ID returned by batch_commands")),
+	)
+	srv.AddTool(batchStatusTool, mcpserver.HandleGetBatchStatus(client))
 }
 
 // registerSchedulerTools adds scheduler and sequence tools
@@ -413,6 +863,7 @@ func registerSchedulerTools(srv *server.MCPServer, client *client.Client) {
 		mcp.WithString("color", mcp.Description("Flash color in hex format, e.g. #FF0000 for red, #00FF00 for green (default: #FFFFFF white)")),
 		mcp.WithNumber("flash_count", mcp.Description("How many times to flash (default: 3)")),
 		mcp.WithNumber("flash_duration_ms", mcp.Description("How long each flash lasts in milliseconds - shorter = more strobe-like (default: 200)")),
+		mcp.WithBoolean("only_on", mcp.Description("Only affect lights that are already on")),
 	)
 	srv.AddTool(flashTool, mcpserver.HandleFlashEffect(client))
 
@@ -424,6 +875,7 @@ func registerSchedulerTools(srv *server.MCPServer, client *client.Client) {
 		mcp.WithNumber("max_brightness", mcp.Description("How bright to go (0-100%, default: 100)")),
 		mcp.WithNumber("pulse_duration_ms", mcp.Description("Time for one complete pulse cycle in milliseconds - longer = slower breathing (default: 2000)")),
 		mcp.WithNumber("pulse_count", mcp.Description("Number of pulse cycles to perform (default: 5)")),
+		mcp.WithBoolean("only_on", mcp.Description("Only affect lights that are already on")),
 	)
 	srv.AddTool(pulseTool, mcpserver.HandlePulseEffect(client))
 
@@ -433,9 +885,20 @@ func registerSchedulerTools(srv *server.MCPServer, client *client.Client) {
 		mcp.WithString("target_id", mcp.Required(), mcp.Description("Light or group ID to animate")),
 		mcp.WithString("colors", mcp.Description("JSON array of hex colors to cycle through, e.g. [\"#FF0000\",\"#00FF00\",\"#0000FF\"] for RGB. Leave empty for rainbow!")),
 		mcp.WithNumber("transition_time_ms", mcp.Description("Smooth transition time between colors in milliseconds (default: 1000)")),
+		mcp.WithBoolean("only_on", mcp.Description("Only affect lights that are already on")),
 	)
 	srv.AddTool(colorLoopTool, mcpserver.HandleColorLoopEffect(client))
 
+	// White loop
+	whiteLoopTool := mcp.NewTool("white_loop",
+		mcp.WithDescription("Cycle through white color temperatures in a continuous loop, e.g. slowly drifting between 2700K and 4000K for a focus session. Loops until stopped."),
+		mcp.WithString("target_id", mcp.Required(), mcp.Description("Light or group ID to animate")),
+		mcp.WithString("kelvins", mcp.Description("JSON array of color temperatures in Kelvin (2000-6535) to cycle through (default: [2700,4000])")),
+		mcp.WithNumber("transition_time_ms", mcp.Description("Time between temperatures in milliseconds (default: 30000)")),
+		mcp.WithBoolean("only_on", mcp.Description("Only affect lights that are already on")),
+	)
+	srv.AddTool(whiteLoopTool, mcpserver.HandleWhiteLoopEffect(client))
+
 	// Strobe effect
 	strobeTool := mcp.NewTool("strobe_effect",
 		mcp.WithDescription("Create a rapid strobe/disco effect. ⚠️ Warning: Very fast flashing - not suitable for those sensitive to strobing lights. Great for parties or dramatic effects!"),
@@ -443,6 +906,7 @@ func registerSchedulerTools(srv *server.MCPServer, client *client.Client) {
 		mcp.WithString("color", mcp.Description("Strobe color in hex format (default: #FFFFFF white)")),
 		mcp.WithNumber("strobe_rate_ms", mcp.Description("Time between flashes in milliseconds - lower = faster strobe (default: 100, minimum safe: 50)")),
 		mcp.WithNumber("duration_ms", mcp.Description("How long to run the strobe effect in milliseconds (default: 5000 = 5 seconds)")),
+		mcp.WithBoolean("only_on", mcp.Description("Only affect lights that are already on")),
 	)
 	srv.AddTool(strobeTool, mcpserver.HandleStrobeEffect(client))
 
@@ -452,9 +916,74 @@ func registerSchedulerTools(srv *server.MCPServer, client *client.Client) {
 		mcp.WithString("target_id", mcp.Required(), mcp.Description("Light or group ID to alert with")),
 		mcp.WithString("alert_color", mcp.Description("Alert flash color in hex format (default: #FF0000 red for urgency)")),
 		mcp.WithString("normal_color", mcp.Description("Color to return to after alert (default: #FFFFFF white)")),
+		mcp.WithBoolean("only_on", mcp.Description("Only affect lights that are already on")),
 	)
 	srv.AddTool(alertTool, mcpserver.HandleAlertEffect(client))
 
+	// Fade effect
+	fadeTool := mcp.NewTool("fade_effect",
+		mcp.WithDescription("Smoothly fade a light from one color and brightness to another, blending the color at every step. Give start_kelvin and end_kelvin instead of colors to fade between white color temperatures."),
+		mcp.WithString("target_id", mcp.Required(), mcp.Description("Light or group ID to fade")),
+		mcp.WithString("start_color", mcp.Description("Starting color in hex format (e.g., #FF0000) or a color name")),
+		mcp.WithString("end_color", mcp.Description("Ending color in hex format (e.g., #0000FF) or a color name")),
+		mcp.WithNumber("start_kelvin", mcp.Description("Starting white color temperature in Kelvin, 2000-6535 (instead of start_color)")),
+		mcp.WithNumber("end_kelvin", mcp.Description("Ending white color temperature in Kelvin, 2000-6535 (instead of end_color)")),
+		mcp.WithNumber("start_brightness", mcp.Description("Starting brightness 0-100 (default: 100)")),
+		mcp.WithNumber("end_brightness", mcp.Description("Ending brightness 0-100 (default: 100)")),
+		mcp.WithNumber("duration_ms", mcp.Description("Total fade time in milliseconds (default: 2000)")),
+		mcp.WithNumber("steps", mcp.Description("Number of intermediate steps (default: 10)")),
+		mcp.WithBoolean("only_on", mcp.Description("Only affect lights that are already on")),
+	)
+	srv.AddTool(fadeTool, mcpserver.HandleFadeEffect(client))
+
+	// Sunrise and sunset
+	sunriseTool := mcp.NewTool("sunrise_effect",
+		mcp.WithDescription("Gradual wake-up light: turns the light on at minimum brightness in deep red, then brightens through warm amber to full daylight."),
+		mcp.WithString("target_id", mcp.Required(), mcp.Description("Light or group ID to apply the effect to")),
+		mcp.WithNumber("duration_minutes", mcp.Description("Length of the sunrise in minutes (default: 30)")),
+		mcp.WithNumber("steps", mcp.Description("Number of transition steps (default: 30)")),
+	)
+	srv.AddTool(sunriseTool, mcpserver.HandleSunriseEffect(client))
+	
+	sunsetTool := mcp.NewTool("sunset_effect",
+		mcp.WithDescription("Gradual evening fade: dims the light from neutral white down to a warm, dim glow. The light stays on."),
+		mcp.WithString("target_id", mcp.Required(), mcp.Description("Light or group ID to apply the effect to")),
+		mcp.WithNumber("duration_minutes", mcp.Description("Length of the sunset in minutes (default: 30)")),
+		mcp.WithNumber("steps", mcp.Description("Number of transition steps (default: 30)")),
+	)
+	srv.AddTool(sunsetTool, mcpserver.HandleSunsetEffect(client))
+	
+	// Wind down
+	windDownTool := mcp.NewTool("wind_down",
+		mcp.WithDescription("Sleep aid: gradually dim and warm a group's lights over a number of minutes, then turn them off. Starts at no more than 60% and never brightens lights that are already dimmer."),
+		mcp.WithString("group_id", mcp.Required(), mcp.Description("Group ID to wind down")),
+		mcp.WithNumber("duration_minutes", mcp.Description("How long the wind down takes in minutes (default: 30)")),
+	)
+	srv.AddTool(windDownTool, mcpserver.HandleWindDown(client))
+
+	// Wake up and sleep mode
+	wakeUpTool := mcp.NewTool("wake_up",
+		mcp.WithDescription("Repeatable wake-up: turns the light on at minimum brightness and warm white, then brightens while shifting to a cooler white."),
+		mcp.WithString("target_id", mcp.Required(), mcp.Description("Light or group ID to wake up")),
+		mcp.WithNumber("duration_minutes", mcp.Description("Length of the wake up in minutes (default: 30)")),
+		mcp.WithNumber("start_kelvin", mcp.Description("Starting color temperature in Kelvin, 2000-6535 (default: 2000)")),
+		mcp.WithNumber("end_kelvin", mcp.Description("Final color temperature in Kelvin, 2000-6535 (default: 4000)")),
+		mcp.WithNumber("end_brightness", mcp.Description("Final brightness 1-100 (default: 100)")),
+		mcp.WithNumber("steps", mcp.Description("Number of transition steps (default: 30)")),
+	)
+	srv.AddTool(wakeUpTool, mcpserver.HandleWakeUp(client))
+
+	sleepModeTool := mcp.NewTool("sleep_mode",
+		mcp.WithDescription("Go-to-sleep ramp: dims the light while shifting from cool to warm white, then turns it off."),
+		mcp.WithString("target_id", mcp.Required(), mcp.Description("Light or group ID to put to sleep")),
+		mcp.WithNumber("duration_minutes", mcp.Description("Length of the ramp in minutes, including the final off (default: 30)")),
+		mcp.WithNumber("start_kelvin", mcp.Description("Starting color temperature in Kelvin, 2000-6535 (default: 4000)")),
+		mcp.WithNumber("end_kelvin", mcp.Description("Final color temperature in Kelvin, 2000-6535 (default: 2000)")),
+		mcp.WithNumber("start_brightness", mcp.Description("Starting brightness 1-100 (default: 100)")),
+		mcp.WithNumber("steps", mcp.Description("Number of transition steps (default: 30)")),
+	)
+	srv.AddTool(sleepModeTool, mcpserver.HandleSleepMode(client))
+
 	// Stop sequence
 	stopSequenceTool := mcp.NewTool("stop_sequence",
 		mcp.WithDescription("Stop one or more running light sequences or effects. Use list_sequences first to see active sequence IDs."),
@@ -463,6 +992,25 @@ func registerSchedulerTools(srv *server.MCPServer, client *client.Client) {
 	)
 	srv.AddTool(stopSequenceTool, mcpserver.HandleStopSequence(client))
 
+	// Stop all sequences
+	stopAllSequencesTool := mcp.NewTool("stop_all_sequences",
+		mcp.WithDescription("Stop every running light sequence or effect at once, no IDs needed"),
+	)
+	srv.AddTool(stopAllSequencesTool, mcpserver.HandleStopAllSequences(client))
+
+	// Pause and resume sequences
+	pauseSequenceTool := mcp.NewTool("pause_sequence",
+		mcp.WithDescription("Pause a running sequence, freezing lights on the current step until resumed"),
+		mcp.WithString("sequence_id", mcp.Required(), mcp.Description("ID of the sequence to pause")),
+	)
+	srv.AddTool(pauseSequenceTool, mcpserver.HandlePauseSequence(client))
+
+	resumeSequenceTool := mcp.NewTool("resume_sequence",
+		mcp.WithDescription("Resume a paused sequence from the step it stopped at"),
+		mcp.WithString("sequence_id", mcp.Required(), mcp.Description("ID of the sequence to resume")),
+	)
+	srv.AddTool(resumeSequenceTool, mcpserver.HandleResumeSequence(client))
+
 	// List sequences
 	listSequencesTool := mcp.NewTool("list_sequences",
 		mcp.WithDescription("Show all currently running light effects and sequences with their IDs. Useful for managing multiple effects."),
@@ -472,10 +1020,31 @@ func registerSchedulerTools(srv *server.MCPServer, client *client.Client) {
 	// Custom sequence
 	customSequenceTool := mcp.NewTool("custom_sequence",
 		mcp.WithDescription("Create complex custom lighting sequences with precise timing. Build sunrise simulations, scene transitions, party modes, or any multi-step lighting choreography. Sequences can include color changes, brightness fades, on/off states, and delays."),
-		mcp.WithString("sequence", mcp.Required(), mcp.Description("JSON sequence definition. Example: {\"name\":\"Sunrise\",\"loop\":false,\"commands\":[{\"type\":\"light\",\"action\":\"color\",\"target\":\"light_id\",\"params\":{\"color\":\"#FF4500\"},\"delay\":1000},{\"type\":\"light\",\"action\":\"brightness\",\"target\":\"light_id\",\"params\":{\"brightness\":100},\"delay\":2000}]}")),
+		mcp.WithString("sequence", mcp.Required(), mcp.Description("JSON sequence definition. Targets may be IDs or names, delays are in milliseconds. Example: {\"name\":\"Sunrise\",\"loop\":false,\"commands\":[{\"type\":\"light\",\"action\":\"color\",\"target\":\"light_id\",\"params\":{\"color\":\"#FF4500\"},\"delay\":1000},{\"type\":\"light\",\"action\":\"brightness\",\"target\":\"light_id\",\"params\":{\"brightness\":100},\"delay\":2000}]}")),
+		mcp.WithBoolean("dry_run", mcp.Description("Preview the resolved commands and their timing without touching the lights")),
 	)
 	srv.AddTool(customSequenceTool, mcpserver.HandleCustomSequence(client))
 	
+	// Time-based scheduling
+	scheduleSequenceTool := mcp.NewTool("schedule_sequence",
+		mcp.WithDescription("Schedule a sequence to run later, either once at a specific time or repeatedly on a cron expression. Schedules survive restarts. Example: activate a scene at 18:30 daily with cron '30 18 * * *'."),
+		mcp.WithString("sequence", mcp.Required(), mcp.Description("JSON sequence definition, same format as custom_sequence")),
+		mcp.WithString("at", mcp.Description("Run once at this local time: HH:MM (next occurrence), YYYY-MM-DD HH:MM or RFC3339")),
+		mcp.WithString("cron", mcp.Description("Run on a 5-field cron expression in local time (minute hour day month weekday), e.g. '0 23 * * *'")),
+	)
+	srv.AddTool(scheduleSequenceTool, mcpserver.HandleScheduleSequence(client))
+	
+	listSchedulesTool := mcp.NewTool("list_schedules",
+		mcp.WithDescription("List scheduled sequences and when they next run"),
+	)
+	srv.AddTool(listSchedulesTool, mcpserver.HandleListSchedules(client))
+	
+	cancelScheduleTool := mcp.NewTool("cancel_schedule",
+		mcp.WithDescription("Cancel a schedule. Also stops any run of it that is still in progress."),
+		mcp.WithString("schedule_id", mcp.Required(), mcp.Description("Schedule ID to cancel")),
+	)
+	srv.AddTool(cancelScheduleTool, mcpserver.HandleCancelSchedule(client))
+	
 	// Scene cache tools
 	recallSceneTool := mcp.NewTool("recall_scene",
 		mcp.WithDescription("Instantly recall a previously cached lighting scene. Perfect for quickly setting up complex atmospheres in RPGs or recreating favorite lighting moods."),
@@ -483,6 +1052,47 @@ func registerSchedulerTools(srv *server.MCPServer, client *client.Client) {
 	)
 	srv.AddTool(recallSceneTool, mcpserver.HandleRecallScene(client))
 	
+	snapshotLightsTool := mcp.NewTool("snapshot_lights",
+		mcp.WithDescription("Capture the current on/off, brightness and color or color temperature of specific lights, from any rooms, into a cached scene that can be restored with recall_scene."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name for the cached scene")),
+		mcp.WithString("light_ids", mcp.Required(), mcp.Description("JSON array of light IDs or names, e.g. [\"Desk Lamp\",\"Hallway\"]")),
+		mcp.WithString("description", mcp.Description("Optional description of the snapshot")),
+	)
+	srv.AddTool(snapshotLightsTool, mcpserver.HandleSnapshotLights(client))
+
+	// Snapshot and restore light state
+	snapshotStateTool := mcp.NewTool("snapshot_state",
+		mcp.WithDescription("Record the on/off, brightness and color of a group or set of lights as a named safety net before changing them"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name for the snapshot")),
+		mcp.WithString("group_id", mcp.Description("Room or zone name or ID to snapshot")),
+		mcp.WithString("light_ids", mcp.Description("JSON array of light IDs or names to snapshot, e.g. [\"Desk Lamp\",\"Floor Lamp\"]")),
+	)
+	srv.AddTool(snapshotStateTool, mcpserver.HandleSnapshotState(client))
+
+	restoreStateTool := mcp.NewTool("restore_state",
+		mcp.WithDescription("Put lights back the way a snapshot recorded them (undo)"),
+		mcp.WithString("name", mcp.Description("Snapshot name (default: the most recent snapshot)")),
+	)
+	srv.AddTool(restoreStateTool, mcpserver.HandleRestoreState(client))
+
+	// Whole-home snapshots, saved to disk
+	globalSnapshotTool := mcp.NewTool("global_snapshot",
+		mcp.WithDescription("Save the state of every light in the home under a name (persisted across restarts), e.g. before a party"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name for the snapshot; an existing snapshot with this name is replaced")),
+	)
+	srv.AddTool(globalSnapshotTool, mcpserver.HandleGlobalSnapshot(client))
+
+	globalRestoreTool := mcp.NewTool("global_restore",
+		mcp.WithDescription("Put every light back the way a global snapshot recorded it, reporting any lights that failed"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the global snapshot")),
+	)
+	srv.AddTool(globalRestoreTool, mcpserver.HandleGlobalRestore(client))
+
+	listGlobalSnapshotsTool := mcp.NewTool("list_global_snapshots",
+		mcp.WithDescription("List saved global snapshots with when they were captured"),
+	)
+	srv.AddTool(listGlobalSnapshotsTool, mcpserver.HandleListGlobalSnapshots(client))
+	
 	listCachedScenesTool := mcp.NewTool("list_cached_scenes",
 		mcp.WithDescription("List all available cached lighting scenes with their descriptions and usage statistics. Helps you remember what atmospheres you've created."),
 	)
@@ -524,14 +1134,52 @@ func registerEventTools(srv *server.MCPServer, client *client.Client) {
 		mcp.WithDescription("Get recent events from the stream"),
 		mcp.WithNumber("limit", mcp.Description("Maximum number of events to return (default: 50)")),
 		mcp.WithString("type", mcp.Description("Filter by event type (e.g., 'light', 'motion', 'button')")),
+		mcp.WithString("resource_id", mcp.Description("Only show events for this resource ID, e.g. one motion sensor (combines with type)")),
 	)
 	srv.AddTool(recentEventsTool, mcpserver.HandleGetRecentEvents(client))
 	
+	// Event webhook
+	setEventWebhookTool := mcp.NewTool("set_event_webhook",
+		mcp.WithDescription("POST every event from the event stream as JSON to a URL, to pass Hue events on to other systems. Delivery is best effort with a short timeout and no retries."),
+		mcp.WithString("url", mcp.Required(), mcp.Description("http or https URL to send events to")),
+	)
+	srv.AddTool(setEventWebhookTool, mcpserver.HandleSetEventWebhook(client))
+	
+	clearEventWebhookTool := mcp.NewTool("clear_event_webhook",
+		mcp.WithDescription("Stop sending events to the webhook"),
+	)
+	srv.AddTool(clearEventWebhookTool, mcpserver.HandleClearEventWebhook(client))
+	
 	// Get stream status
 	streamStatusTool := mcp.NewTool("get_event_stream_status",
 		mcp.WithDescription("Get the current status of the event stream"),
 	)
 	srv.AddTool(streamStatusTool, mcpserver.HandleGetEventStreamStatus(client))
+	
+	// Event-driven automations
+	createAutomationTool := mcp.NewTool("create_automation",
+		mcp.WithDescription("Create a rule that runs an action when a matching event arrives on the event stream (e.g. turn on a scene when motion is detected). Rules are saved to disk and only run while the event stream is running."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Automation name (replaces an existing automation with the same name)")),
+		mcp.WithString("event_type", mcp.Required(), mcp.Description("Event resource type to match: motion, button, temperature, light_level, light, grouped_light")),
+		mcp.WithString("resource_id", mcp.Description("Only match events from this resource ID (e.g. a specific motion sensor)")),
+		mcp.WithString("condition", mcp.Description("Condition on the event: field op value, with fields motion, button, temperature, light_level, on, brightness (e.g. 'motion==true', 'button==short_release', 'light_level<10000')")),
+		mcp.WithString("action_type", mcp.Required(), mcp.Description("Action to run: scene, cached_scene or sequence")),
+		mcp.WithString("action_target", mcp.Description("Scene name/ID or cached scene name for scene actions")),
+		mcp.WithString("sequence", mcp.Description("Sequence JSON for sequence actions (same format as custom_sequence)")),
+		mcp.WithNumber("debounce_seconds", mcp.Description("Minimum seconds between runs so a flapping sensor doesn't retrigger (default: 10)")),
+	)
+	srv.AddTool(createAutomationTool, mcpserver.HandleCreateAutomation(client))
+	
+	listAutomationsTool := mcp.NewTool("list_automations",
+		mcp.WithDescription("List event-driven automations"),
+	)
+	srv.AddTool(listAutomationsTool, mcpserver.HandleListAutomations(client))
+	
+	deleteAutomationTool := mcp.NewTool("delete_automation",
+		mcp.WithDescription("Delete an event-driven automation"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Automation name")),
+	)
+	srv.AddTool(deleteAutomationTool, mcpserver.HandleDeleteAutomation(client))
 }
 
 // registerCRUDTools adds create, update, delete tools
@@ -540,12 +1188,12 @@ func registerCRUDTools(srv *server.MCPServer, client *client.Client) {
 	createSceneFromStateTool := mcp.NewTool("create_scene_from_state",
 		mcp.WithDescription("Create a new scene capturing current light states"),
 		mcp.WithString("name", mcp.Required(), mcp.Description("Name for the scene")),
-		mcp.WithString("group_id", mcp.Required(), mcp.Description("Group/room ID to capture")),
+		mcp.WithString("group_id", mcp.Required(), mcp.Description("Group, room or zone ID to capture")),
 	)
 	srv.AddTool(createSceneFromStateTool, mcpserver.HandleCreateSceneFromState(client))
 	
 	updateSceneTool := mcp.NewTool("update_scene",
-		mcp.WithDescription("Update a scene's metadata"),
+		mcp.WithDescription("Update a scene's name or transition speed; either can be changed on its own"),
 		mcp.WithString("scene_id", mcp.Required(), mcp.Description("Scene ID to update")),
 		mcp.WithString("name", mcp.Description("New name for the scene")),
 		mcp.WithNumber("speed", mcp.Description("Transition speed (0.0-1.0)")),
@@ -558,6 +1206,27 @@ func registerCRUDTools(srv *server.MCPServer, client *client.Client) {
 	)
 	srv.AddTool(deleteSceneTool, mcpserver.HandleDeleteScene(client))
 	
+	moveSceneTool := mcp.NewTool("move_scene",
+		mcp.WithDescription("Move a scene to a different room or zone (recreates the scene with a new ID)"),
+		mcp.WithString("scene_id", mcp.Required(), mcp.Description("Scene ID to move")),
+		mcp.WithString("group_id", mcp.Required(), mcp.Description("Room, zone or group ID to move the scene to")),
+	)
+	srv.AddTool(moveSceneTool, mcpserver.HandleMoveScene(client))
+	
+	duplicateSceneTool := mcp.NewTool("duplicate_scene",
+		mcp.WithDescription("Copy a scene's light settings and palette into a new scene in the same room or zone, e.g. to build a variant"),
+		mcp.WithString("scene_id", mcp.Required(), mcp.Description("Scene name or ID to copy")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name for the new scene")),
+	)
+	srv.AddTool(duplicateSceneTool, mcpserver.HandleDuplicateScene(client))
+	
+	applySceneToGroupTool := mcp.NewTool("apply_scene_to_group",
+		mcp.WithDescription("Give another room or zone a scene's look by copying its palette colors and brightness onto that group's lights. Best effort: this sets the lights directly rather than recalling the scene, so dynamic palettes are not reproduced"),
+		mcp.WithString("scene_id", mcp.Required(), mcp.Description("Scene name or ID whose look to copy")),
+		mcp.WithString("group_id", mcp.Required(), mcp.Description("Room or zone name, or a group ID, to apply it to")),
+	)
+	srv.AddTool(applySceneToGroupTool, mcpserver.HandleApplySceneToGroup(client))
+	
 	// Group management
 	addLightToGroupTool := mcp.NewTool("add_light_to_group",
 		mcp.WithDescription("Add a light to a group/room"),
@@ -573,6 +1242,13 @@ func registerCRUDTools(srv *server.MCPServer, client *client.Client) {
 	)
 	srv.AddTool(removeLightFromGroupTool, mcpserver.HandleRemoveLightFromGroup(client))
 	
+	moveLightToRoomTool := mcp.NewTool("move_light_to_room",
+		mcp.WithDescription("Move a light out of its current room and into another in one step, putting it back if the move fails part way"),
+		mcp.WithString("light_id", mcp.Required(), mcp.Description("Light name or ID to move")),
+		mcp.WithString("room_id", mcp.Required(), mcp.Description("Room name or ID to move the light to")),
+	)
+	srv.AddTool(moveLightToRoomTool, mcpserver.HandleMoveLightToRoom(client))
+	
 	// Zone CRUD
 	createZoneTool := mcp.NewTool("create_zone",
 		mcp.WithDescription("Create a new zone with specified lights"),
@@ -595,6 +1271,22 @@ func registerCRUDTools(srv *server.MCPServer, client *client.Client) {
 	)
 	srv.AddTool(deleteZoneTool, mcpserver.HandleDeleteZone(client))
 	
+	// Room CRUD
+	createRoomTool := mcp.NewTool("create_room",
+		mcp.WithDescription("Create a new room. Rooms contain devices; lights are added through the device that owns them."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name for the room")),
+		mcp.WithString("archetype", mcp.Description("Room type, e.g. living_room, bedroom, kitchen, office (default: other)")),
+		mcp.WithString("device_ids", mcp.Description("Comma-separated device IDs to put in the room")),
+		mcp.WithString("light_ids", mcp.Description("Comma-separated light IDs whose devices should be put in the room")),
+	)
+	srv.AddTool(createRoomTool, mcpserver.HandleCreateRoom(client))
+	
+	deleteRoomTool := mcp.NewTool("delete_room",
+		mcp.WithDescription("Delete a room (its devices become unassigned)"),
+		mcp.WithString("room_id", mcp.Required(), mcp.Description("Room ID to delete")),
+	)
+	srv.AddTool(deleteRoomTool, mcpserver.HandleDeleteRoom(client))
+	
 	// Room update
 	updateRoomTool := mcp.NewTool("update_room",
 		mcp.WithDescription("Update a room's name"),
@@ -602,4 +1294,68 @@ func registerCRUDTools(srv *server.MCPServer, client *client.Client) {
 		mcp.WithString("name", mcp.Required(), mcp.Description("New name for the room")),
 	)
 	srv.AddTool(updateRoomTool, mcpserver.HandleUpdateRoom(client))
+	
+	// Renaming
+	renameDeviceTool := mcp.NewTool("rename_device",
+		mcp.WithDescription("Rename a device (the bulb, plug or sensor as shown in the Hue app). This is usually what users mean by renaming a light. A light ID is also accepted and renames the device that owns it."),
+		mcp.WithString("device_id", mcp.Required(), mcp.Description("Device ID, or a light ID whose device should be renamed")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("New name for the device")),
+	)
+	srv.AddTool(renameDeviceTool, mcpserver.HandleRenameDevice(client))
+	
+	renameLightTool := mcp.NewTool("rename_light",
+		mcp.WithDescription("Rename only the light service of a device. The Hue app shows the device name, so prefer rename_device unless the light name specifically needs to differ."),
+		mcp.WithString("light_id", mcp.Required(), mcp.Description("Light ID or name")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("New name for the light")),
+	)
+	srv.AddTool(renameLightTool, mcpserver.HandleRenameLight(client))
+}
+
+// registerAutomationTools adds tools for the bridge's native automations
+func registerAutomationTools(srv *server.MCPServer, client *client.Client) {
+	listAutomationsTool := mcp.NewTool("list_native_automations",
+		mcp.WithDescription("List the bridge's native automations (wake up, timers, motion rules) with their type and enabled state"),
+	)
+	srv.AddTool(listAutomationsTool, mcpserver.HandleListNativeAutomations(client))
+	
+	enableAutomationTool := mcp.NewTool("enable_native_automation",
+		mcp.WithDescription("Enable a native bridge automation"),
+		mcp.WithString("automation_id", mcp.Required(), mcp.Description("Automation ID from list_native_automations")),
+	)
+	srv.AddTool(enableAutomationTool, mcpserver.HandleEnableNativeAutomation(client))
+	
+	disableAutomationTool := mcp.NewTool("disable_native_automation",
+		mcp.WithDescription("Disable a native bridge automation (e.g. temporarily turn off a motion rule)"),
+		mcp.WithString("automation_id", mcp.Required(), mcp.Description("Automation ID from list_native_automations")),
+	)
+	srv.AddTool(disableAutomationTool, mcpserver.HandleDisableNativeAutomation(client))
+}
+
+// registerMacroTools adds tools for defining and running macros
+func registerMacroTools(srv *server.MCPServer, client *client.Client) {
+	defineMacroTool := mcp.NewTool("define_macro",
+		mcp.WithDescription("Define a named macro: an ordered list of steps run with one command (e.g. 'goodnight' or 'good morning'). Macros are saved to disk."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Macro name")),
+		mcp.WithString("description", mcp.Description("What the macro does")),
+		mcp.WithString("steps", mcp.Required(), mcp.Description("JSON array of steps. Types: scene (target), cached_scene (target), effect (target, value, duration), command (action, target, value - same actions as batch_commands), delay (delay_ms), all_off. Example: [{\"type\":\"command\",\"action\":\"group_brightness\",\"target\":\"group_id\",\"value\":\"20\"},{\"type\":\"delay\",\"delay_ms\":60000},{\"type\":\"all_off\"}]")),
+	)
+	srv.AddTool(defineMacroTool, mcpserver.HandleDefineMacro(client))
+	
+	runMacroTool := mcp.NewTool("run_macro",
+		mcp.WithDescription("Run a saved macro"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Macro name")),
+		mcp.WithBoolean("wait", mcp.Description("Wait for the macro to finish instead of running it in the background (default: false)")),
+	)
+	srv.AddTool(runMacroTool, mcpserver.HandleRunMacro(client))
+	
+	listMacrosTool := mcp.NewTool("list_macros",
+		mcp.WithDescription("List saved macros"),
+	)
+	srv.AddTool(listMacrosTool, mcpserver.HandleListMacros(client))
+	
+	deleteMacroTool := mcp.NewTool("delete_macro",
+		mcp.WithDescription("Delete a saved macro"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Macro name")),
+	)
+	srv.AddTool(deleteMacroTool, mcpserver.HandleDeleteMacro(client))
 }
@@ -0,0 +1,166 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// AuditEntry records one successful control command
+type AuditEntry struct {
+	Time   time.Time              `json:"time"`
+	Tool   string                 `json:"tool"`
+	Target string                 `json:"target,omitempty"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// auditTargetArgs are the arguments naming what a tool acts on, in order of preference
+var auditTargetArgs = []string{"light_id", "group_id", "target_id", "scene_id", "scene_name", "sequence_id", "schedule_id", "config_id", "sensor_id", "zone_id", "room_id", "name"}
+
+// AuditLogger writes audit entries as JSON lines
+type AuditLogger struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewAuditLogger creates a logger writing to w
+func NewAuditLogger(w io.Writer) *AuditLogger {
+	return &AuditLogger{w: w}
+}
+
+// OpenAuditLog appends audit entries to a file, or to stderr when path is "-"
+func OpenAuditLog(path string) (*AuditLogger, error) {
+	if path == "-" {
+		return NewAuditLogger(os.Stderr), nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &AuditLogger{w: f, closer: f}, nil
+}
+
+// Close closes the underlying file, if any
+func (a *AuditLogger) Close() error {
+	if a.closer != nil {
+		return a.closer.Close()
+	}
+	return nil
+}
+
+// Record writes an entry
+func (a *AuditLogger) Record(entry AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, err = a.w.Write(append(data, '\n'))
+	return err
+}
+
+// Middleware records every successful control tool call. Read-only tools (list_*, get_*,
+// *_status and the like), dry runs and high-frequency streaming tools are skipped so the
+// log only shows commands that changed something.
+func (a *AuditLogger) Middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := request.GetArguments()
+			if !isAuditedCall(request.Params.Name, args) {
+				return next(ctx, request)
+			}
+
+			resolved := &auditResolutions{ids: make(map[string]string)}
+			result, err := next(context.WithValue(ctx, auditResolutionsKey{}, resolved), request)
+			if err != nil || result == nil || result.IsError {
+				return result, err
+			}
+
+			entry := AuditEntry{
+				Time:   time.Now(),
+				Tool:   request.Params.Name,
+				Params: args,
+			}
+			for _, key := range auditTargetArgs {
+				if target, ok := args[key].(string); ok && target != "" {
+					entry.Target = resolved.lookup(target)
+					break
+				}
+			}
+
+			if err := a.Record(entry); err != nil {
+				log.Printf("Failed to write audit entry: %v", err)
+			}
+			return result, err
+		}
+	}
+}
+
+// auditResolutionsKey is the context key for the name resolutions made during a tool call
+type auditResolutionsKey struct{}
+
+// auditResolutions maps the names a tool call resolved to their resource IDs
+type auditResolutions struct {
+	mu  sync.Mutex
+	ids map[string]string
+}
+
+// lookup returns the ID a name resolved to, or the name itself when it was not resolved
+func (r *auditResolutions) lookup(nameOrID string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id, ok := r.ids[nameOrID]; ok {
+		return id
+	}
+	return nameOrID
+}
+
+// noteResolvedTarget records a name resolution so the audit entry shows the resolved ID
+func noteResolvedTarget(ctx context.Context, nameOrID, id string) {
+	r, ok := ctx.Value(auditResolutionsKey{}).(*auditResolutions)
+	if !ok {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids[nameOrID] = id
+}
+
+// isAuditedCall reports whether a tool call changes state and is worth recording
+func isAuditedCall(name string, args map[string]interface{}) bool {
+	if dryRun, _ := args["dry_run"].(bool); dryRun {
+		return false
+	}
+	return !isReadOnlyTool(name) && !isStreamingTool(name)
+}
+
+// isStreamingTool reports whether a tool is called many times a second while streaming
+func isStreamingTool(name string) bool {
+	switch name {
+	case "push_audio_level", "send_colors":
+		return true
+	}
+	return false
+}
+
+// isReadOnlyTool reports whether a tool only queries state
+func isReadOnlyTool(name string) bool {
+	switch name {
+	case "active_scene", "bridge_info", "export_scene", "search_resources":
+		return true
+	}
+	return strings.HasPrefix(name, "list_") || strings.HasPrefix(name, "get_") || strings.HasSuffix(name, "_status")
+}
@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestAuditMiddlewareRecordsControlCommands(t *testing.T) {
+	fb := newFakeBridge(t)
+	hueClient := fb.client()
+	
+	var buf bytes.Buffer
+	audit := NewAuditLogger(&buf)
+	
+	call := func(name string, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]interface{}) {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = args
+		audit.Middleware()(handler)(context.Background(), request)
+	}
+	
+	call("light_brightness", HandleLightBrightness(hueClient), map[string]interface{}{"light_id": "light-1", "brightness": 40.0})
+	call("list_lights", HandleListLights(hueClient), map[string]interface{}{})
+	call("light_on", HandleLightOn(hueClient), map[string]interface{}{}) // fails: no light_id
+	
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 1 {
+		t.Fatalf("Expected exactly 1 audit entry, got %d:\n%s", len(lines), buf.String())
+	}
+	
+	var entry AuditEntry
+	if err := json.Unmarshal(lines[0], &entry); err != nil {
+		t.Fatalf("Audit entry is not JSON: %v", err)
+	}
+	if entry.Tool != "light_brightness" || entry.Target != "light-1" {
+		t.Errorf("Expected light_brightness on light-1, got %+v", entry)
+	}
+	if entry.Params["brightness"] != 40.0 {
+		t.Errorf("Expected the brightness parameter to be recorded, got %v", entry.Params)
+	}
+	if entry.Time.IsZero() {
+		t.Error("Expected the entry to be timestamped")
+	}
+}
+
+func TestAuditMiddlewareRecordsResolvedTarget(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk Lamp"}})
+	
+	var buf bytes.Buffer
+	audit := NewAuditLogger(&buf)
+	
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "set_light_state"
+	request.Params.Arguments = map[string]interface{}{"light_id": "desk lamp", "on": true}
+	audit.Middleware()(HandleSetLightState(fb.client()))(context.Background(), request)
+	
+	var entry AuditEntry
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &entry); err != nil {
+		t.Fatalf("Expected one JSON audit entry, got %q: %v", buf.String(), err)
+	}
+	if entry.Target != "light-1" {
+		t.Errorf("Expected the resolved light ID as target, got %q", entry.Target)
+	}
+}
+
+func TestAuditSkipsQueriesDryRunsAndStreaming(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]interface{}
+		want bool
+	}{
+		{"light_on", map[string]interface{}{}, true},
+		{"batch_commands", map[string]interface{}{}, true},
+		{"batch_commands", map[string]interface{}{"dry_run": true}, false},
+		{"custom_sequence", map[string]interface{}{"dry_run": true}, false},
+		{"search_resources", map[string]interface{}{}, false},
+		{"push_audio_level", map[string]interface{}{}, false},
+		{"send_colors", map[string]interface{}{}, false},
+	}
+	
+	for _, tt := range tests {
+		if got := isAuditedCall(tt.name, tt.args); got != tt.want {
+			t.Errorf("isAuditedCall(%s, %v) = %v, want %v", tt.name, tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,458 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/kungfusheep/hue/scheduler"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Automation action types
+const (
+	AutomationActionScene       = "scene"        // Activate a native scene
+	AutomationActionCachedScene = "cached_scene" // Recall a cached scene
+	AutomationActionSequence    = "sequence"     // Run a custom sequence
+)
+
+// defaultAutomationDebounce keeps a flapping sensor from retriggering constantly
+const defaultAutomationDebounce = 10 * time.Second
+
+// AutomationTrigger matches events from the event stream
+type AutomationTrigger struct {
+	EventType  string `json:"event_type"`            // Resource type, e.g. motion, button, light_level
+	ResourceID string `json:"resource_id,omitempty"` // Only events for this resource
+	Condition  string `json:"condition,omitempty"`   // e.g. motion==true, button==short_release, light_level<10000
+}
+
+// AutomationAction is what runs when a trigger matches
+type AutomationAction struct {
+	Type     string              `json:"type"`
+	Target   string              `json:"target,omitempty"` // Scene name/ID or cached scene name
+	Sequence *scheduler.Sequence `json:"sequence,omitempty"`
+}
+
+// Automation maps an event match to an action
+type Automation struct {
+	Name            string            `json:"name"`
+	Trigger         AutomationTrigger `json:"trigger"`
+	Action          AutomationAction  `json:"action"`
+	DebounceSeconds float64           `json:"debounce_seconds"`
+	CreatedAt       time.Time         `json:"created_at"`
+
+	lastFired time.Time
+}
+
+// AutomationStore manages automations persisted to disk
+type AutomationStore struct {
+	automations map[string]*Automation
+	path        string
+	mu          sync.Mutex
+}
+
+var (
+	globalAutomationStore *AutomationStore
+	automationStoreOnce   sync.Once
+)
+
+// GetAutomationStore returns the global automation store, loading it from disk on first use
+func GetAutomationStore() *AutomationStore {
+	automationStoreOnce.Do(func() {
+		path, err := dataFile("automations.json")
+		if err != nil {
+			log.Printf("Automations will not be persisted: %v", err)
+		}
+		globalAutomationStore = newAutomationStore(path)
+	})
+	return globalAutomationStore
+}
+
+// newAutomationStore creates a store backed by the given file
+func newAutomationStore(path string) *AutomationStore {
+	store := &AutomationStore{
+		automations: make(map[string]*Automation),
+		path:        path,
+	}
+
+	if path != "" {
+		var automations []*Automation
+		if err := loadJSONFile(path, &automations); err != nil {
+			log.Printf("Failed to load automations from %s: %v", path, err)
+		}
+		for _, automation := range automations {
+			store.automations[automation.Name] = automation
+		}
+	}
+
+	return store
+}
+
+// SaveAutomation validates and stores an automation, replacing any with the same name
+func (as *AutomationStore) SaveAutomation(automation *Automation) error {
+	if err := validateAutomation(automation); err != nil {
+		return err
+	}
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	if automation.CreatedAt.IsZero() {
+		automation.CreatedAt = time.Now()
+	}
+	as.automations[automation.Name] = automation
+
+	return as.persist()
+}
+
+// ListAutomations returns all automations sorted by name
+func (as *AutomationStore) ListAutomations() []*Automation {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	automations := make([]*Automation, 0, len(as.automations))
+	for _, automation := range as.automations {
+		automations = append(automations, automation)
+	}
+	sort.Slice(automations, func(i, j int) bool {
+		return automations[i].Name < automations[j].Name
+	})
+	return automations
+}
+
+// DeleteAutomation removes an automation
+func (as *AutomationStore) DeleteAutomation(name string) error {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	if _, exists := as.automations[name]; !exists {
+		return fmt.Errorf("automation '%s' not found", name)
+	}
+	delete(as.automations, name)
+
+	return as.persist()
+}
+
+// Match returns the automations triggered by an event, marking them fired.
+// Automations still inside their debounce window are skipped.
+func (as *AutomationStore) Match(data client.EventData, now time.Time) []*Automation {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	var matched []*Automation
+	for _, automation := range as.automations {
+		if !automation.Trigger.Matches(data) {
+			continue
+		}
+
+		debounce := time.Duration(automation.DebounceSeconds * float64(time.Second))
+		if !automation.lastFired.IsZero() && now.Sub(automation.lastFired) < debounce {
+			continue
+		}
+
+		automation.lastFired = now
+		matched = append(matched, automation)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].Name < matched[j].Name
+	})
+	return matched
+}
+
+// persist writes the automations to disk; callers must hold the lock
+func (as *AutomationStore) persist() error {
+	if as.path == "" {
+		return nil
+	}
+
+	automations := make([]*Automation, 0, len(as.automations))
+	for _, automation := range as.automations {
+		automations = append(automations, automation)
+	}
+	sort.Slice(automations, func(i, j int) bool {
+		return automations[i].Name < automations[j].Name
+	})
+
+	if err := saveJSONFile(as.path, automations); err != nil {
+		return fmt.Errorf("failed to save automations: %w", err)
+	}
+	return nil
+}
+
+// validateAutomation checks the trigger and action are complete
+func validateAutomation(automation *Automation) error {
+	if automation.Name == "" {
+		return fmt.Errorf("automation name cannot be empty")
+	}
+	if automation.Trigger.EventType == "" {
+		return fmt.Errorf("event_type is required")
+	}
+	if automation.Trigger.Condition != "" {
+		if _, _, _, err := parseCondition(automation.Trigger.Condition); err != nil {
+			return err
+		}
+	}
+	if automation.DebounceSeconds < 0 {
+		return fmt.Errorf("debounce_seconds cannot be negative")
+	}
+
+	switch automation.Action.Type {
+	case AutomationActionScene, AutomationActionCachedScene:
+		if automation.Action.Target == "" {
+			return fmt.Errorf("%s action requires a target", automation.Action.Type)
+		}
+	case AutomationActionSequence:
+		if automation.Action.Sequence == nil || len(automation.Action.Sequence.Commands) == 0 {
+			return fmt.Errorf("sequence action requires a sequence with commands")
+		}
+	default:
+		return fmt.Errorf("unknown action type '%s' (expected scene, cached_scene or sequence)", automation.Action.Type)
+	}
+
+	return nil
+}
+
+// Matches reports whether an event's data satisfies the trigger
+func (t AutomationTrigger) Matches(data client.EventData) bool {
+	if data.Type != t.EventType {
+		return false
+	}
+	if t.ResourceID != "" && data.ID != t.ResourceID {
+		return false
+	}
+	if t.Condition == "" {
+		return true
+	}
+
+	field, op, want, err := parseCondition(t.Condition)
+	if err != nil {
+		return false
+	}
+
+	got, ok := eventField(data, field)
+	if !ok {
+		return false
+	}
+	return compareCondition(got, op, want)
+}
+
+// conditionOps are checked longest first so <= isn't read as <
+var conditionOps = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// parseCondition splits a condition such as motion==true into field, operator and value
+func parseCondition(condition string) (field, op, value string, err error) {
+	for _, candidate := range conditionOps {
+		if idx := strings.Index(condition, candidate); idx > 0 {
+			field = strings.TrimSpace(condition[:idx])
+			value = strings.TrimSpace(condition[idx+len(candidate):])
+			if value == "" {
+				break
+			}
+			return field, candidate, value, nil
+		}
+	}
+	return "", "", "", fmt.Errorf("invalid condition '%s' (expected e.g. motion==true or light_level<10000)", condition)
+}
+
+// eventField reads a named value from event data: motion, button, temperature, light_level, on or brightness
+func eventField(data client.EventData, field string) (string, bool) {
+	switch field {
+	case "motion":
+		if data.Motion == nil {
+			return "", false
+		}
+		if data.Motion.MotionReport != nil {
+			return strconv.FormatBool(data.Motion.MotionReport.Motion), true
+		}
+		return strconv.FormatBool(data.Motion.Motion), true
+	case "button":
+		if data.Button == nil || data.Button.ButtonReport == nil {
+			return "", false
+		}
+		return data.Button.ButtonReport.Event, true
+	case "temperature":
+		if data.Temperature == nil {
+			return "", false
+		}
+		return strconv.FormatFloat(data.Temperature.Temperature, 'f', -1, 64), true
+	case "light_level":
+		if data.Light == nil {
+			return "", false
+		}
+		return strconv.Itoa(data.Light.LightLevel), true
+	case "on":
+		if data.On == nil {
+			return "", false
+		}
+		return strconv.FormatBool(data.On.On), true
+	case "brightness":
+		if data.Dimming == nil {
+			return "", false
+		}
+		return strconv.FormatFloat(data.Dimming.Brightness, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// compareCondition compares numerically when both sides are numbers, otherwise as strings
+func compareCondition(got, op, want string) bool {
+	a, errA := strconv.ParseFloat(got, 64)
+	b, errB := strconv.ParseFloat(want, 64)
+	if errA == nil && errB == nil {
+		switch op {
+		case "==":
+			return a == b
+		case "!=":
+			return a != b
+		case "<":
+			return a < b
+		case ">":
+			return a > b
+		case "<=":
+			return a <= b
+		case ">=":
+			return a >= b
+		}
+		return false
+	}
+
+	switch op {
+	case "==":
+		return strings.EqualFold(got, want)
+	case "!=":
+		return !strings.EqualFold(got, want)
+	}
+	return false
+}
+
+// runAutomationAction performs an automation's action
+func runAutomationAction(ctx context.Context, hueClient *client.Client, automation *Automation) error {
+	action := automation.Action
+	switch action.Type {
+	case AutomationActionScene:
+		_, err := runMacroStep(ctx, hueClient, MacroStep{Type: MacroStepScene, Target: action.Target})
+		return err
+	case AutomationActionCachedScene:
+		_, err := runMacroStep(ctx, hueClient, MacroStep{Type: MacroStepCachedScene, Target: action.Target})
+		return err
+	case AutomationActionSequence:
+		if globalScheduler == nil {
+			return fmt.Errorf("scheduler not initialized")
+		}
+		seq := &scheduler.Sequence{
+			Name:     action.Sequence.Name,
+			Commands: action.Sequence.Commands,
+			Loop:     action.Sequence.Loop,
+		}
+		_, err := globalScheduler.ExecuteSequence(seq)
+		return err
+	}
+	return fmt.Errorf("unknown action type '%s'", action.Type)
+}
+
+// HandleCreateAutomation returns a handler for creating or replacing an automation
+func HandleCreateAutomation(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		name, ok := args["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+
+		automation := &Automation{Name: name, DebounceSeconds: defaultAutomationDebounce.Seconds()}
+		automation.Trigger.EventType, _ = args["event_type"].(string)
+		automation.Trigger.ResourceID, _ = args["resource_id"].(string)
+		automation.Trigger.Condition, _ = args["condition"].(string)
+		automation.Action.Type, _ = args["action_type"].(string)
+		automation.Action.Target, _ = args["action_target"].(string)
+
+		if d, ok := args["debounce_seconds"].(float64); ok {
+			automation.DebounceSeconds = d
+		}
+
+		if seqJSON, ok := args["sequence"].(string); ok && seqJSON != "" {
+			var seq scheduler.Sequence
+			if err := json.Unmarshal([]byte(seqJSON), &seq); err != nil {
+				return toolError("Failed to parse sequence JSON", err), nil
+			}
+			if err := validateAndResolveSequence(ctx, hueClient, &seq); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid sequence: %v", err)), nil
+			}
+			automation.Action.Sequence = &seq
+		}
+
+		if err := GetAutomationStore().SaveAutomation(automation); err != nil {
+			return toolError("Failed to save automation", err), nil
+		}
+
+		result := fmt.Sprintf("Automation '%s' saved: %s", name, describeAutomation(automation))
+		if eventManager == nil || !eventManager.isStreaming() {
+			result += "\nAutomations only run while the event stream is running (start_event_stream)."
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+// HandleListAutomations returns a handler for listing automations
+func HandleListAutomations(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		automations := GetAutomationStore().ListAutomations()
+		if len(automations) == 0 {
+			return mcp.NewToolResultText("No automations defined"), nil
+		}
+
+		var result strings.Builder
+		result.WriteString(fmt.Sprintf("Found %d automations:\n", len(automations)))
+		for _, automation := range automations {
+			result.WriteString(fmt.Sprintf("- %s: %s\n", automation.Name, describeAutomation(automation)))
+		}
+
+		return mcp.NewToolResultText(result.String()), nil
+	}
+}
+
+// HandleDeleteAutomation returns a handler for deleting an automation
+func HandleDeleteAutomation(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		name, ok := args["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+
+		if err := GetAutomationStore().DeleteAutomation(name); err != nil {
+			return toolError("Failed to delete automation", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Automation '%s' deleted", name)), nil
+	}
+}
+
+// describeAutomation summarises a rule as "when ... then ..."
+func describeAutomation(automation *Automation) string {
+	when := automation.Trigger.EventType
+	if automation.Trigger.ResourceID != "" {
+		when += " " + automation.Trigger.ResourceID
+	}
+	if automation.Trigger.Condition != "" {
+		when += " where " + automation.Trigger.Condition
+	}
+
+	then := fmt.Sprintf("%s %s", automation.Action.Type, automation.Action.Target)
+	if automation.Action.Type == AutomationActionSequence && automation.Action.Sequence != nil {
+		then = fmt.Sprintf("sequence of %d commands", len(automation.Action.Sequence.Commands))
+	}
+
+	return fmt.Sprintf("when %s then %s (debounce %gs)", when, then, automation.DebounceSeconds)
+}
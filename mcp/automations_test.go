@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestAutomationTriggerConditions(t *testing.T) {
+	motion := client.EventData{ID: "motion-1", Type: "motion", Motion: &client.MotionReport{Motion: true}}
+	dark := client.EventData{ID: "lux-1", Type: "light_level", Light: &client.LightLevelReport{LightLevel: 8000}}
+	
+	tests := []struct {
+		name    string
+		trigger AutomationTrigger
+		data    client.EventData
+		want    bool
+	}{
+		{"type only", AutomationTrigger{EventType: "motion"}, motion, true},
+		{"wrong type", AutomationTrigger{EventType: "button"}, motion, false},
+		{"wrong resource", AutomationTrigger{EventType: "motion", ResourceID: "motion-2"}, motion, false},
+		{"motion true", AutomationTrigger{EventType: "motion", Condition: "motion==true"}, motion, true},
+		{"motion false", AutomationTrigger{EventType: "motion", Condition: "motion == false"}, motion, false},
+		{"numeric less than", AutomationTrigger{EventType: "light_level", Condition: "light_level<10000"}, dark, true},
+		{"numeric at least", AutomationTrigger{EventType: "light_level", Condition: "light_level>=10000"}, dark, false},
+		{"missing field", AutomationTrigger{EventType: "motion", Condition: "brightness>10"}, motion, false},
+	}
+	
+	for _, tt := range tests {
+		if got := tt.trigger.Matches(tt.data); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestAutomationDebounceFiresSceneOnce(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("scene", client.Scene{ID: "scene-1", Metadata: client.Metadata{Name: "Hallway Night"}})
+	
+	store := newAutomationStore("")
+	err := store.SaveAutomation(&Automation{
+		Name:            "hallway",
+		Trigger:         AutomationTrigger{EventType: "motion", ResourceID: "motion-1", Condition: "motion==true"},
+		Action:          AutomationAction{Type: AutomationActionScene, Target: "Hallway Night"},
+		DebounceSeconds: 60,
+	})
+	if err != nil {
+		t.Fatalf("SaveAutomation failed: %v", err)
+	}
+	
+	em := &EventManager{client: fb.client(), automations: store}
+	event := client.Event{Type: "update", Data: []client.EventData{
+		{ID: "motion-1", Type: "motion", Motion: &client.MotionReport{Motion: true}},
+	}}
+	
+	em.runAutomations(event)
+	em.runAutomations(event)
+	
+	deadline := time.Now().Add(2 * time.Second)
+	for len(fb.RequestsFor("PUT", "/clip/v2/resource/scene/")) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/scene/scene-1"); len(puts) != 1 {
+		t.Errorf("Expected the scene to be activated once, got %d activations", len(puts))
+	}
+}
+
+func TestAutomationStoreRejectsInvalidRules(t *testing.T) {
+	store := newAutomationStore("")
+	
+	invalid := []*Automation{
+		{Name: "no-event", Action: AutomationAction{Type: AutomationActionScene, Target: "x"}},
+		{Name: "bad-condition", Trigger: AutomationTrigger{EventType: "motion", Condition: "motion"}, Action: AutomationAction{Type: AutomationActionScene, Target: "x"}},
+		{Name: "no-target", Trigger: AutomationTrigger{EventType: "motion"}, Action: AutomationAction{Type: AutomationActionScene}},
+		{Name: "bad-action", Trigger: AutomationTrigger{EventType: "motion"}, Action: AutomationAction{Type: "launch"}},
+	}
+	
+	for _, automation := range invalid {
+		if err := store.SaveAutomation(automation); err == nil {
+			t.Errorf("Expected %s to be rejected", automation.Name)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxRetainedBatches bounds how many async batch outcomes are kept for get_batch_status
+const maxRetainedBatches = 50
+
+// BatchStatus records the outcome of each command in an async batch as it runs
+type BatchStatus struct {
+	ID         string
+	Total      int
+	Results    []BatchResult
+	Complete   bool
+	Cancelled  bool
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// BatchRegistry keeps the most recent async batches keyed by batch ID
+type BatchRegistry struct {
+	batches map[string]*BatchStatus
+	order   []string
+	limit   int
+	mu      sync.RWMutex
+}
+
+// Global batch registry instance
+var globalBatchRegistry = newBatchRegistry(maxRetainedBatches)
+
+func newBatchRegistry(limit int) *BatchRegistry {
+	return &BatchRegistry{
+		batches: make(map[string]*BatchStatus),
+		limit:   limit,
+	}
+}
+
+// Start registers a batch, evicting the oldest once the limit is reached.
+// Starting an already registered batch is a no-op.
+func (r *BatchRegistry) Start(batchID string, total int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.batches[batchID]; exists {
+		return
+	}
+
+	for len(r.order) >= r.limit {
+		delete(r.batches, r.order[0])
+		r.order = r.order[1:]
+	}
+
+	r.batches[batchID] = &BatchStatus{ID: batchID, Total: total, StartedAt: time.Now()}
+	r.order = append(r.order, batchID)
+}
+
+// Record appends a command result to a batch
+func (r *BatchRegistry) Record(batchID string, result BatchResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if status, exists := r.batches[batchID]; exists {
+		status.Results = append(status.Results, result)
+	}
+}
+
+// Finish marks a batch as complete, or cancelled if it stopped early
+func (r *BatchRegistry) Finish(batchID string, cancelled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if status, exists := r.batches[batchID]; exists {
+		status.Complete = true
+		status.Cancelled = cancelled
+		status.FinishedAt = time.Now()
+	}
+}
+
+// Get returns a copy of a batch's status
+func (r *BatchRegistry) Get(batchID string) (BatchStatus, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	status, exists := r.batches[batchID]
+	if !exists {
+		return BatchStatus{}, false
+	}
+
+	copied := *status
+	copied.Results = append([]BatchResult(nil), status.Results...)
+	return copied, true
+}
+
+// HandleGetBatchStatus reports the per-command outcome of an async batch
+func HandleGetBatchStatus(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		batchID, ok := args["batch_id"].(string)
+		if !ok || batchID == "" {
+			return mcp.NewToolResultError("batch_id is required"), nil
+		}
+
+		status, exists := globalBatchRegistry.Get(batchID)
+		if !exists {
+			return mcp.NewToolResultError(fmt.Sprintf("Batch %s not found (only the last %d batches are kept)", batchID, maxRetainedBatches)), nil
+		}
+
+		successful := 0
+		for _, result := range status.Results {
+			if result.Success {
+				successful++
+			}
+		}
+
+		var result strings.Builder
+		switch {
+		case status.Cancelled:
+			result.WriteString(fmt.Sprintf("Batch %s cancelled after %d of %d commands\n", batchID, len(status.Results), status.Total))
+		case status.Complete:
+			result.WriteString(fmt.Sprintf("Batch %s complete in %s\n", batchID, status.FinishedAt.Sub(status.StartedAt).Round(time.Millisecond)))
+		default:
+			result.WriteString(fmt.Sprintf("Batch %s running: %d of %d commands done\n", batchID, len(status.Results), status.Total))
+		}
+		result.WriteString(fmt.Sprintf("%d successful, %d failed\n", successful, len(status.Results)-successful))
+
+		for _, cmdResult := range status.Results {
+			if cmdResult.Success {
+				result.WriteString(fmt.Sprintf("✅ %d: %s\n", cmdResult.Index, cmdResult.Message))
+			} else {
+				result.WriteString(fmt.Sprintf("❌ %s\n", cmdResult.Message))
+			}
+		}
+
+		return mcp.NewToolResultText(result.String()), nil
+	}
+}
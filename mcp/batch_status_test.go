@@ -0,0 +1,125 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestHandleGetBatchStatusReportsAsyncResults(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Lamp"}})
+	
+	result := callTool(t, HandleBatchCommands(fb.client()), map[string]interface{}{
+		"commands": `[{"action":"light_on","target_id":"light-1"},{"action":"light_off","target_id":"light-1"}]`,
+		"delay_ms": float64(0),
+	})
+	if result.IsError {
+		t.Fatalf("batch_commands failed: %s", resultText(result))
+	}
+	
+	batchID := regexp.MustCompile(`batch_\d+_\d+`).FindString(resultText(result))
+	if batchID == "" {
+		t.Fatalf("Expected a batch ID in:\n%s", resultText(result))
+	}
+	
+	var text string
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		text = resultText(callTool(t, HandleGetBatchStatus(fb.client()), map[string]interface{}{"batch_id": batchID}))
+		if strings.Contains(text, "complete") {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	
+	if !strings.Contains(text, "2 successful, 0 failed") || !strings.Contains(text, "✅ 1: Light light-1 turned off") {
+		t.Errorf("Expected both commands to succeed, got:\n%s", text)
+	}
+	
+	// Failures that only show up at run time are recorded against their command
+	commands := []map[string]interface{}{
+		{"action": "light_on", "target_id": "light-1"},
+		{"action": "light_brightness", "target_id": "light-1", "value": "bright"},
+	}
+	failingID := fmt.Sprintf("batch-failing-%d", time.Now().UnixNano())
+	ExecuteBatchAsync(context.Background(), fb.client(), commands, 0, failingID)
+	
+	text = resultText(callTool(t, HandleGetBatchStatus(fb.client()), map[string]interface{}{"batch_id": failingID}))
+	if !strings.Contains(text, "complete") || !strings.Contains(text, "1 successful, 1 failed") {
+		t.Errorf("Expected a completed batch with one failure, got:\n%s", text)
+	}
+	if !strings.Contains(text, "❌ Command 1 (light_brightness): invalid brightness value") {
+		t.Errorf("Expected the failing command to be identified, got:\n%s", text)
+	}
+	
+	missing := callTool(t, HandleGetBatchStatus(fb.client()), map[string]interface{}{"batch_id": "batch_0_0"})
+	if !missing.IsError {
+		t.Error("Expected an unknown batch to be an error")
+	}
+}
+
+func TestParallelAsyncBatchRecordsCancellation(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Lamp"}})
+	
+	commands := []map[string]interface{}{
+		{"action": "light_on", "target_id": "light-1"},
+		{"action": "light_off", "target_id": "light-1", "at_ms": float64(5000)},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	batchID := fmt.Sprintf("batch-cancelled-%d", time.Now().UnixNano())
+	
+	done := make(chan struct{})
+	go func() {
+		ExecuteBatchParallelAsync(ctx, fb.client(), commands, batchID)
+		close(done)
+	}()
+	
+	// Cancel once the first step has run, while the batch waits for the second
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && len(fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1")) == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the batch to stop when its context was cancelled")
+	}
+	
+	text := resultText(callTool(t, HandleGetBatchStatus(fb.client()), map[string]interface{}{"batch_id": batchID}))
+	if !strings.Contains(text, "cancelled after 1 of 2 commands") {
+		t.Errorf("Expected the batch to be reported cancelled, got:\n%s", text)
+	}
+}
+
+func TestBatchRegistryEvictsOldest(t *testing.T) {
+	registry := newBatchRegistry(3)
+	for i := 0; i < 5; i++ {
+		registry.Start(fmt.Sprintf("batch-%d", i), 1)
+	}
+	
+	for i := 0; i < 2; i++ {
+		if _, ok := registry.Get(fmt.Sprintf("batch-%d", i)); ok {
+			t.Errorf("Expected batch-%d to be evicted", i)
+		}
+	}
+	for i := 2; i < 5; i++ {
+		if _, ok := registry.Get(fmt.Sprintf("batch-%d", i)); !ok {
+			t.Errorf("Expected batch-%d to be retained", i)
+		}
+	}
+	
+	// Restarting a known batch keeps its results
+	registry.Record("batch-4", BatchResult{Success: true, Message: "ok"})
+	registry.Start("batch-4", 1)
+	if status, _ := registry.Get("batch-4"); len(status.Results) != 1 {
+		t.Errorf("Expected results to survive a repeated Start, got %d", len(status.Results))
+	}
+}
@@ -0,0 +1,84 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// HandleListNativeAutomations returns a handler for listing the bridge's native automations
+func HandleListNativeAutomations(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		behaviors, err := hueClient.GetBehaviorInstances(ctx)
+		if err != nil {
+			return toolError("Failed to list native automations", err), nil
+		}
+
+		// Script names describe the automation type; they're optional extra detail
+		scriptNames := make(map[string]string)
+		if scripts, err := hueClient.GetBehaviorScripts(ctx); err == nil {
+			for _, script := range scripts {
+				scriptNames[script.ID] = script.Metadata.Name
+			}
+		}
+
+		var result strings.Builder
+		result.WriteString(fmt.Sprintf("Found %d native automations:\n", len(behaviors)))
+		for _, behavior := range behaviors {
+			automationType := scriptNames[behavior.ScriptID]
+			if automationType == "" {
+				automationType = behavior.ScriptID
+			}
+
+			enabled := "enabled"
+			if !behavior.Enabled {
+				enabled = "disabled"
+			}
+
+			result.WriteString(fmt.Sprintf("- %s [%s] (%s) (ID: %s)\n",
+				behavior.Metadata.Name, automationType, enabled, behavior.ID))
+
+			if behavior.LastError != "" {
+				result.WriteString(fmt.Sprintf("  Last error: %s\n", behavior.LastError))
+			}
+		}
+
+		return mcp.NewToolResultText(result.String()), nil
+	}
+}
+
+// HandleEnableNativeAutomation returns a handler for enabling a native automation
+func HandleEnableNativeAutomation(hueClient *client.Client) server.ToolHandlerFunc {
+	return handleSetNativeAutomation(hueClient, true)
+}
+
+// HandleDisableNativeAutomation returns a handler for disabling a native automation
+func HandleDisableNativeAutomation(hueClient *client.Client) server.ToolHandlerFunc {
+	return handleSetNativeAutomation(hueClient, false)
+}
+
+func handleSetNativeAutomation(hueClient *client.Client, enabled bool) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		automationID, ok := args["automation_id"].(string)
+		if !ok || automationID == "" {
+			return mcp.NewToolResultError("automation_id is required"), nil
+		}
+
+		state := "enabled"
+		if !enabled {
+			state = "disabled"
+		}
+
+		err := hueClient.SetBehaviorInstanceEnabled(ctx, automationID, enabled)
+		if err != nil {
+			return toolError("Failed to update automation", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Automation %s %s", automationID, state)), nil
+	}
+}
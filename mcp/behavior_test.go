@@ -0,0 +1,53 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestHandleListNativeAutomations(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("behavior_instance", client.BehaviorInstance{
+		ID:       "b1",
+		ScriptID: "script-wake",
+		Enabled:  true,
+		Metadata: client.BehaviorMetadata{Name: "Morning"},
+	})
+	fb.Add("behavior_instance", client.BehaviorInstance{
+		ID:       "b2",
+		ScriptID: "script-motion",
+		Enabled:  false,
+		Metadata: client.BehaviorMetadata{Name: "Hallway sensor"},
+	})
+	fb.Add("behavior_script", client.BehaviorScript{ID: "script-wake", Metadata: client.BehaviorMetadata{Name: "Wake up"}})
+	
+	result := callTool(t, HandleListNativeAutomations(fb.client()), nil)
+	text := resultText(result)
+	
+	for _, want := range []string{
+		"Found 2 native automations",
+		"- Morning [Wake up] (enabled) (ID: b1)",
+		"- Hallway sensor [script-motion] (disabled) (ID: b2)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestHandleDisableNativeAutomation(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("behavior_instance", client.BehaviorInstance{ID: "b1", Enabled: true})
+	
+	result := callTool(t, HandleDisableNativeAutomation(fb.client()), map[string]interface{}{"automation_id": "b1"})
+	if result.IsError {
+		t.Fatalf("Unexpected error: %s", resultText(result))
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/behavior_instance/b1")
+	if len(puts) != 1 || puts[0].Body["enabled"] != false {
+		t.Errorf("Expected PUT with enabled=false, got %+v", puts)
+	}
+}
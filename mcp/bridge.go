@@ -0,0 +1,38 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// WithBridge builds a handler for every bridge in the registry and picks one per call from
+// the optional bridge argument. Calls without it go to the primary bridge.
+func WithBridge(bridges *client.ClientRegistry, factory func(*client.Client) server.ToolHandlerFunc) server.ToolHandlerFunc {
+	handlers := make(map[string]server.ToolHandlerFunc)
+	for _, name := range bridges.Names() {
+		hueClient, _ := bridges.Get(name)
+		handlers[strings.ToLower(name)] = factory(hueClient)
+	}
+
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, _ := request.GetArguments()["bridge"].(string)
+		if name == "" {
+			names := bridges.Names()
+			if len(names) == 0 {
+				return mcp.NewToolResultError("No bridges configured"), nil
+			}
+			name = names[0]
+		}
+
+		handler, ok := handlers[strings.ToLower(name)]
+		if !ok {
+			_, err := bridges.Get(name)
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return handler(ctx, request)
+	}
+}
@@ -0,0 +1,33 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestWithBridgeSelectsClient(t *testing.T) {
+	upstairs := newFakeBridge(t)
+	downstairs := newFakeBridge(t)
+	
+	bridges := client.NewClientRegistry()
+	bridges.Add("upstairs", upstairs.client())
+	bridges.Add("downstairs", downstairs.client())
+	handler := WithBridge(bridges, HandleLightOn)
+	
+	// No bridge argument goes to the primary
+	callTool(t, handler, map[string]interface{}{"light_id": "light-1"})
+	callTool(t, handler, map[string]interface{}{"light_id": "light-2", "bridge": "Downstairs"})
+	
+	if puts := upstairs.RequestsFor("PUT", "/clip/v2/resource/light/"); len(puts) != 1 || puts[0].Path != "/clip/v2/resource/light/light-1" {
+		t.Errorf("Expected light-1 on the primary bridge, got %+v", puts)
+	}
+	if puts := downstairs.RequestsFor("PUT", "/clip/v2/resource/light/"); len(puts) != 1 || puts[0].Path != "/clip/v2/resource/light/light-2" {
+		t.Errorf("Expected light-2 on the downstairs bridge, got %+v", puts)
+	}
+	
+	result := callTool(t, handler, map[string]interface{}{"light_id": "light-1", "bridge": "garage"})
+	if !result.IsError {
+		t.Error("Expected an error for an unknown bridge")
+	}
+}
@@ -27,7 +27,7 @@ func HandleCreateSceneFromState(hueClient *client.Client) server.ToolHandlerFunc
 		
 		scene, err := hueClient.CreateSceneFromCurrentState(ctx, name, groupID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to create scene: %v", err)), nil
+			return toolError("Failed to create scene", err), nil
 		}
 		
 		return mcp.NewToolResultText(fmt.Sprintf("Scene '%s' created successfully with ID: %s", name, scene.ID)), nil
@@ -44,22 +44,33 @@ func HandleUpdateScene(hueClient *client.Client) server.ToolHandlerFunc {
 			return mcp.NewToolResultError("scene_id is required"), nil
 		}
 		
+		// Unset fields are left out of the request, so changing only the speed keeps the name
 		update := client.SceneUpdate{}
+		var changes []string
 		
 		if name, ok := args["name"].(string); ok && name != "" {
 			update.Metadata = &client.Metadata{Name: name}
+			changes = append(changes, fmt.Sprintf("name '%s'", name))
 		}
 		
 		if speed, ok := args["speed"].(float64); ok {
+			if speed < 0 || speed > 1 {
+				return mcp.NewToolResultError("speed must be between 0 and 1"), nil
+			}
 			update.Speed = &speed
+			changes = append(changes, fmt.Sprintf("speed %.2f", speed))
+		}
+		
+		if len(changes) == 0 {
+			return mcp.NewToolResultError("nothing to update: give a name or speed"), nil
 		}
 		
 		err := hueClient.UpdateScene(ctx, sceneID, update)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to update scene: %v", err)), nil
+			return toolError("Failed to update scene", err), nil
 		}
 		
-		return mcp.NewToolResultText("Scene updated successfully"), nil
+		return mcp.NewToolResultText(fmt.Sprintf("Scene updated successfully: %s", strings.Join(changes, ", "))), nil
 	}
 }
 
@@ -75,13 +86,103 @@ func HandleDeleteScene(hueClient *client.Client) server.ToolHandlerFunc {
 		
 		err := hueClient.DeleteScene(ctx, sceneID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete scene: %v", err)), nil
+			return toolError("Failed to delete scene", err), nil
 		}
 		
 		return mcp.NewToolResultText(fmt.Sprintf("Scene %s deleted successfully", sceneID)), nil
 	}
 }
 
+// HandleMoveScene moves a scene to a different room or zone
+func HandleMoveScene(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		sceneID, ok := args["scene_id"].(string)
+		if !ok || sceneID == "" {
+			return mcp.NewToolResultError("scene_id is required"), nil
+		}
+		
+		groupID, ok := args["group_id"].(string)
+		if !ok || groupID == "" {
+			return mcp.NewToolResultError("group_id is required"), nil
+		}
+		
+		newID, err := hueClient.MoveScene(ctx, sceneID, groupID)
+		if err != nil {
+			return toolError("Failed to move scene", err), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("Scene moved successfully. New scene ID: %s", newID)), nil
+	}
+}
+
+// HandleDuplicateScene copies a scene under a new name
+func HandleDuplicateScene(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		sceneRef, ok := args["scene_id"].(string)
+		if !ok || sceneRef == "" {
+			return mcp.NewToolResultError("scene_id is required"), nil
+		}
+		
+		name, ok := args["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+		
+		sceneID, err := resolveSceneID(ctx, hueClient, sceneRef)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		
+		scene, err := hueClient.DuplicateScene(ctx, sceneID, name)
+		if err != nil {
+			return toolError("Failed to duplicate scene", err), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("Scene '%s' created as a copy of %s with ID: %s", name, sceneRef, scene.ID)), nil
+	}
+}
+
+// HandleApplySceneToGroup copies a scene's colors and brightness onto another room or zone
+func HandleApplySceneToGroup(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		sceneRef, ok := args["scene_id"].(string)
+		if !ok || sceneRef == "" {
+			return mcp.NewToolResultError("scene_id is required"), nil
+		}
+		
+		groupRef, ok := args["group_id"].(string)
+		if !ok || groupRef == "" {
+			return mcp.NewToolResultError("group_id is required"), nil
+		}
+		
+		sceneID, err := resolveSceneID(ctx, hueClient, sceneRef)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		
+		groupID, err := resolveGroupID(ctx, hueClient, groupRef)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		
+		actions, err := hueClient.ApplySceneTemplate(ctx, sceneID, groupID)
+		if err != nil {
+			if len(actions) > 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("Applied the look of %s to %s only in part: %v", sceneRef, groupRef, err)), nil
+			}
+			return toolError("Failed to apply scene", err), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("Applied the look of %s to %d lights in %s (a copy of its colors and brightness, not a scene recall)", sceneRef, len(actions), groupRef)), nil
+	}
+}
+
 // HandleAddLightToGroup adds a light to a group
 func HandleAddLightToGroup(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -99,7 +200,7 @@ func HandleAddLightToGroup(hueClient *client.Client) server.ToolHandlerFunc {
 		
 		err := hueClient.AddLightToGroup(ctx, groupID, lightID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to add light to group: %v", err)), nil
+			return toolError("Failed to add light to group", err), nil
 		}
 		
 		return mcp.NewToolResultText(fmt.Sprintf("Light %s added to group %s", lightID, groupID)), nil
@@ -123,13 +224,53 @@ func HandleRemoveLightFromGroup(hueClient *client.Client) server.ToolHandlerFunc
 		
 		err := hueClient.RemoveLightFromGroup(ctx, groupID, lightID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to remove light from group: %v", err)), nil
+			return toolError("Failed to remove light from group", err), nil
 		}
 		
 		return mcp.NewToolResultText(fmt.Sprintf("Light %s removed from group %s", lightID, groupID)), nil
 	}
 }
 
+// HandleMoveLightToRoom moves a light from whatever room it is in to another
+func HandleMoveLightToRoom(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		lightRef, ok := args["light_id"].(string)
+		if !ok || lightRef == "" {
+			return mcp.NewToolResultError("light_id is required"), nil
+		}
+		
+		roomRef, ok := args["room_id"].(string)
+		if !ok || roomRef == "" {
+			return mcp.NewToolResultError("room_id is required"), nil
+		}
+		
+		lightID, err := resolveLightID(ctx, hueClient, lightRef)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		
+		roomID, err := resolveGroupID(ctx, hueClient, roomRef)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		
+		from, to, err := hueClient.MoveLightToRoom(ctx, lightID, roomID)
+		if err != nil {
+			return toolError("Failed to move light", err), nil
+		}
+		
+		if from == nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Light %s was in no room and is now in %s", lightRef, to.Metadata.Name)), nil
+		}
+		if from.ID == to.ID {
+			return mcp.NewToolResultText(fmt.Sprintf("Light %s is already in %s", lightRef, to.Metadata.Name)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Light %s moved from %s to %s", lightRef, from.Metadata.Name, to.Metadata.Name)), nil
+	}
+}
+
 // HandleCreateZone creates a new zone
 func HandleCreateZone(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -172,7 +313,7 @@ func HandleCreateZone(hueClient *client.Client) server.ToolHandlerFunc {
 		
 		zone, err := hueClient.CreateZone(ctx, zoneCreate)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to create zone: %v", err)), nil
+			return toolError("Failed to create zone", err), nil
 		}
 		
 		return mcp.NewToolResultText(fmt.Sprintf("Zone '%s' created with ID: %s", name, zone.ID)), nil
@@ -212,7 +353,7 @@ func HandleUpdateZone(hueClient *client.Client) server.ToolHandlerFunc {
 		
 		err := hueClient.UpdateZone(ctx, zoneID, update)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to update zone: %v", err)), nil
+			return toolError("Failed to update zone", err), nil
 		}
 		
 		return mcp.NewToolResultText("Zone updated successfully"), nil
@@ -231,13 +372,95 @@ func HandleDeleteZone(hueClient *client.Client) server.ToolHandlerFunc {
 		
 		err := hueClient.DeleteZone(ctx, zoneID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete zone: %v", err)), nil
+			return toolError("Failed to delete zone", err), nil
 		}
 		
 		return mcp.NewToolResultText(fmt.Sprintf("Zone %s deleted successfully", zoneID)), nil
 	}
 }
 
+// HandleCreateRoom creates a new room
+func HandleCreateRoom(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		name, ok := args["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+		
+		archetype := "other"
+		if a, ok := args["archetype"].(string); ok && a != "" {
+			archetype = a
+		}
+		
+		// Rooms hold devices, so lights are added via the device that owns them
+		var children []client.ResourceIdentifier
+		seen := make(map[string]bool)
+		addDevice := func(id string) {
+			if id != "" && !seen[id] {
+				seen[id] = true
+				children = append(children, client.ResourceIdentifier{RID: id, RType: "device"})
+			}
+		}
+		
+		if deviceIDsStr, ok := args["device_ids"].(string); ok && deviceIDsStr != "" {
+			for _, id := range strings.Split(deviceIDsStr, ",") {
+				addDevice(strings.TrimSpace(id))
+			}
+		}
+		
+		if lightIDsStr, ok := args["light_ids"].(string); ok && lightIDsStr != "" {
+			for _, id := range strings.Split(lightIDsStr, ",") {
+				id = strings.TrimSpace(id)
+				if id == "" {
+					continue
+				}
+				light, err := hueClient.GetLight(ctx, id)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to get light %s: %v", id, err)), nil
+				}
+				addDevice(light.Owner.RID)
+			}
+		}
+		
+		roomCreate := client.RoomCreate{
+			Type: "room",
+			Metadata: client.Metadata{
+				Name:      name,
+				Archetype: archetype,
+			},
+			Children: children,
+		}
+		
+		room, err := hueClient.CreateRoom(ctx, roomCreate)
+		if err != nil {
+			return toolError("Failed to create room", err), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("Room '%s' (%s) created with ID: %s and %d devices", name, archetype, room.ID, len(children))), nil
+	}
+}
+
+// HandleDeleteRoom deletes a room
+func HandleDeleteRoom(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		roomID, ok := args["room_id"].(string)
+		if !ok || roomID == "" {
+			return mcp.NewToolResultError("room_id is required"), nil
+		}
+		
+		err := hueClient.DeleteRoom(ctx, roomID)
+		if err != nil {
+			return toolError("Failed to delete room", err), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("Room %s deleted successfully", roomID)), nil
+	}
+}
+
 // HandleUpdateRoom updates a room's metadata
 func HandleUpdateRoom(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -259,9 +482,65 @@ func HandleUpdateRoom(hueClient *client.Client) server.ToolHandlerFunc {
 		
 		err := hueClient.UpdateRoom(ctx, roomID, update)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to update room: %v", err)), nil
+			return toolError("Failed to update room", err), nil
 		}
 		
 		return mcp.NewToolResultText(fmt.Sprintf("Room renamed to '%s'", name)), nil
 	}
-}
\ No newline at end of file
+}
+
+// HandleRenameLight renames a light service
+func HandleRenameLight(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		lightID, ok := args["light_id"].(string)
+		if !ok || lightID == "" {
+			return mcp.NewToolResultError("light_id is required"), nil
+		}
+		
+		name, ok := args["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+		
+		resolvedID, err := resolveLightID(ctx, hueClient, lightID)
+		if err != nil {
+			return toolError("Failed to resolve light", err), nil
+		}
+		
+		if err := hueClient.RenameLight(ctx, resolvedID, name); err != nil {
+			return toolError("Failed to rename light", err), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("Light renamed to '%s'", name)), nil
+	}
+}
+
+// HandleRenameDevice renames a device
+func HandleRenameDevice(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		deviceID, ok := args["device_id"].(string)
+		if !ok || deviceID == "" {
+			return mcp.NewToolResultError("device_id is required"), nil
+		}
+		
+		name, ok := args["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+		
+		// Accept a light ID too, renaming the device that owns it
+		if light, err := hueClient.GetLight(ctx, deviceID); err == nil && light.Owner.RType == "device" {
+			deviceID = light.Owner.RID
+		}
+		
+		if err := hueClient.RenameDevice(ctx, deviceID, name); err != nil {
+			return toolError("Failed to rename device", err), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("Device %s renamed to '%s'", deviceID, name)), nil
+	}
+}
@@ -0,0 +1,84 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestRenameDeviceAcceptsLightID(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{
+		ID:       "light-1",
+		Owner:    client.ResourceIdentifier{RID: "device-1", RType: "device"},
+		Metadata: client.Metadata{Name: "Hue bulb 1"},
+	})
+	fb.Add("device", client.Device{ID: "device-1", Metadata: client.Metadata{Name: "Hue bulb 1"}})
+	
+	result := callTool(t, HandleRenameDevice(fb.client()), map[string]interface{}{
+		"device_id": "light-1",
+		"name":      "Reading Lamp",
+	})
+	if result.IsError {
+		t.Fatalf("rename_device failed: %s", resultText(result))
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/device/device-1")
+	if len(puts) != 1 {
+		t.Fatalf("Expected one device rename, got %d", len(puts))
+	}
+	metadata, _ := puts[0].Body["metadata"].(map[string]interface{})
+	if metadata["name"] != "Reading Lamp" {
+		t.Errorf("Expected name Reading Lamp, got %v", puts[0].Body)
+	}
+	if len(fb.RequestsFor("PUT", "/clip/v2/resource/light/")) != 0 {
+		t.Error("Expected the light service to be left alone")
+	}
+}
+
+func TestRenameLightResolvesName(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Hue bulb 1"}})
+	
+	result := callTool(t, HandleRenameLight(fb.client()), map[string]interface{}{
+		"light_id": "Hue bulb 1",
+		"name":     "Reading Lamp",
+	})
+	if result.IsError {
+		t.Fatalf("rename_light failed: %s", resultText(result))
+	}
+	
+	if len(fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1")) != 1 {
+		t.Error("Expected the light to be renamed")
+	}
+}
+
+func TestUpdateSceneSpeedOnlyKeepsName(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("scene", client.Scene{ID: "scene-1", Metadata: client.Metadata{Name: "Relax"}, Speed: 0.5})
+	hueClient := fb.client()
+	
+	result := callTool(t, HandleUpdateScene(hueClient), map[string]interface{}{"scene_id": "scene-1", "speed": 0.9})
+	if result.IsError {
+		t.Fatalf("update_scene failed: %s", resultText(result))
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/scene/scene-1")
+	if len(puts) != 1 {
+		t.Fatalf("Expected one scene update, got %d", len(puts))
+	}
+	if _, ok := puts[0].Body["metadata"]; ok {
+		t.Errorf("Expected no metadata in a speed-only update, got %v", puts[0].Body)
+	}
+	
+	result = callTool(t, HandleGetScene(hueClient), map[string]interface{}{"scene_id": "scene-1"})
+	text := resultText(result)
+	if !strings.Contains(text, "Scene: Relax") || !strings.Contains(text, "Speed: 0.90") {
+		t.Errorf("Expected the name kept and the new speed shown, got:\n%s", text)
+	}
+	
+	if result := callTool(t, HandleUpdateScene(hueClient), map[string]interface{}{"scene_id": "scene-1", "speed": 1.5}); !result.IsError {
+		t.Error("Expected a speed above 1 to be rejected")
+	}
+}
@@ -0,0 +1,177 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/kungfusheep/hue/scheduler"
+)
+
+// dryRunKey marks a context whose batch commands should be described rather than sent
+type dryRunKey struct{}
+
+// withDryRun returns a context that makes executeBatchCommand describe commands instead of
+// running them. names maps resource IDs to display names for the descriptions.
+func withDryRun(ctx context.Context, names map[string]string) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, names)
+}
+
+// dryRunNames returns the dry-run name map and whether ctx is a dry run
+func dryRunNames(ctx context.Context) (map[string]string, bool) {
+	names, ok := ctx.Value(dryRunKey{}).(map[string]string)
+	return names, ok
+}
+
+// describeDryRunCommand formats what a batch command would do
+func describeDryRunCommand(names map[string]string, action, targetID, value string, duration int) string {
+	line := fmt.Sprintf("%s → %s", action, describeTarget(names, targetID))
+	if value != "" {
+		line += fmt.Sprintf(" = %s", value)
+	}
+	if duration > 0 {
+		line += fmt.Sprintf(" for %ds", duration)
+	}
+	return line
+}
+
+// describeTarget names a resource ID, keeping the ID visible so it can be checked
+func describeTarget(names map[string]string, id string) string {
+	if name, ok := names[id]; ok && name != "" {
+		return fmt.Sprintf("%s (%s)", name, id)
+	}
+	return fmt.Sprintf("%s (unknown target)", id)
+}
+
+// resourceNames maps light, grouped_light and scene IDs to their names for dry-run output
+func resourceNames(ctx context.Context, hueClient *client.Client) (map[string]string, error) {
+	names := make(map[string]string)
+
+	lights, err := hueClient.GetLights(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lights: %w", err)
+	}
+	for _, light := range lights {
+		names[light.ID] = light.Metadata.Name
+	}
+
+	rooms, err := hueClient.GetRooms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rooms: %w", err)
+	}
+	zones, err := hueClient.GetZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zones: %w", err)
+	}
+	addGroup := func(id, name string, services []client.ResourceIdentifier) {
+		names[id] = name
+		for _, service := range services {
+			if service.RType == "grouped_light" {
+				names[service.RID] = name
+			}
+		}
+	}
+	for _, room := range rooms {
+		addGroup(room.ID, room.Metadata.Name, room.Services)
+	}
+	for _, zone := range zones {
+		addGroup(zone.ID, zone.Metadata.Name, zone.Services)
+	}
+
+	scenes, err := hueClient.GetScenes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scenes: %w", err)
+	}
+	for _, scene := range scenes {
+		names[scene.ID] = scene.Metadata.Name
+	}
+
+	return names, nil
+}
+
+// dryRunBatch describes a batch with the time each command would fire, without touching the bridge
+func dryRunBatch(ctx context.Context, hueClient *client.Client, commands []map[string]interface{}, delayMs int, parallel bool) (string, error) {
+	names, err := resourceNames(ctx, hueClient)
+	if err != nil {
+		return "", err
+	}
+	dryCtx := withDryRun(ctx, names)
+
+	offsets := make([]time.Duration, len(commands))
+	total := time.Duration(0)
+	if parallel {
+		for _, step := range batchSteps(commands) {
+			for _, i := range step.indexes {
+				offsets[i] = step.at
+			}
+			total = step.at
+		}
+	} else {
+		for i := range commands {
+			offsets[i] = time.Duration(i*delayMs) * time.Millisecond
+		}
+		if len(commands) > 0 {
+			total = offsets[len(commands)-1]
+		}
+	}
+
+	mode := "sequential"
+	if parallel {
+		mode = "parallel"
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Dry run: %d commands (%s), nothing was sent to the bridge\n", len(commands), mode))
+	for i, cmd := range commands {
+		action, _ := cmd["action"].(string)
+		targetID, _ := cmd["target_id"].(string)
+		value, _ := cmd["value"].(string)
+		duration := 0
+		if d, ok := cmd["duration"].(float64); ok {
+			duration = int(d)
+		}
+
+		line, _ := executeBatchCommand(dryCtx, hueClient, action, targetID, value, duration)
+		result.WriteString(fmt.Sprintf("%3d. +%-7s %s\n", i, offsets[i].String(), line))
+	}
+	result.WriteString(fmt.Sprintf("Total time: %s", total))
+
+	return result.String(), nil
+}
+
+// dryRunSequence describes a resolved sequence with the cumulative time of each command
+func dryRunSequence(ctx context.Context, hueClient *client.Client, seq *scheduler.Sequence) (string, error) {
+	names, err := resourceNames(ctx, hueClient)
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Dry run of %s: %d commands, nothing was sent to the bridge\n", seq.Name, len(seq.Commands)))
+
+	elapsed := time.Duration(0)
+	for i, cmd := range seq.Commands {
+		elapsed += cmd.Delay
+		line := fmt.Sprintf("%s %s → %s", cmd.Type, cmd.Action, describeTarget(names, cmd.Target))
+		keys := make([]string, 0, len(cmd.Params))
+		for key := range cmd.Params {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			line += fmt.Sprintf(" %s=%v", key, cmd.Params[key])
+		}
+		result.WriteString(fmt.Sprintf("%3d. +%-7s %s\n", i, elapsed.String(), line))
+	}
+
+	if seq.Loop {
+		result.WriteString(fmt.Sprintf("Each pass takes %s and repeats until stopped", elapsed))
+	} else {
+		result.WriteString(fmt.Sprintf("Total time: %s", elapsed))
+	}
+
+	return result.String(), nil
+}
@@ -0,0 +1,65 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestHandleBatchCommandsDryRun(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk Lamp"}})
+	
+	result := callTool(t, HandleBatchCommands(fb.client()), map[string]interface{}{
+		"commands": `[{"action":"light_on","target_id":"light-1"},` +
+			`{"action":"light_color","target_id":"light-1","value":"red"},` +
+			`{"action":"light_off","target_id":"Desk Lamp"}]`,
+		"delay_ms": float64(250),
+		"dry_run":  true,
+	})
+	if result.IsError {
+		t.Fatalf("dry run failed: %s", resultText(result))
+	}
+	
+	text := resultText(result)
+	for _, want := range []string{
+		"nothing was sent to the bridge",
+		"+0s      light_on → Desk Lamp (light-1)",
+		"+250ms   light_color → Desk Lamp (light-1) = red",
+		"+500ms   light_off → Desk Lamp (light-1)",
+		"Total time: 500ms",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in preview, got:\n%s", want, text)
+		}
+	}
+	
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/"); len(puts) != 0 {
+		t.Errorf("Expected a dry run not to touch the bridge, got %d PUTs", len(puts))
+	}
+}
+
+func TestHandleCustomSequenceDryRun(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk Lamp"}})
+	
+	// globalScheduler is nil in tests, so starting the sequence would panic
+	result := callTool(t, HandleCustomSequence(fb.client()), map[string]interface{}{
+		"sequence": `{"name":"Preview","commands":[` +
+			`{"type":"light","action":"on","target":"Desk Lamp"},` +
+			`{"type":"light","action":"brightness","target":"Desk Lamp","params":{"brightness":40},"delay":1500}]}`,
+		"dry_run": true,
+	})
+	if result.IsError {
+		t.Fatalf("dry run failed: %s", resultText(result))
+	}
+	
+	text := resultText(result)
+	if !strings.Contains(text, "+1.5s    light brightness → Desk Lamp (light-1) brightness=40") {
+		t.Errorf("Expected resolved names and cumulative timing, got:\n%s", text)
+	}
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/"); len(puts) != 0 {
+		t.Errorf("Expected a dry run not to touch the bridge, got %d PUTs", len(puts))
+	}
+}
@@ -18,7 +18,7 @@ func HandleListEntertainment(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		configs, err := hueClient.GetEntertainmentConfigurations(ctx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to list entertainment configurations: %v", err)), nil
+			return toolError("Failed to list entertainment configurations", err), nil
 		}
 
 		var result strings.Builder
@@ -31,7 +31,7 @@ func HandleListEntertainment(hueClient *client.Client) server.ToolHandlerFunc {
 			result.WriteString(fmt.Sprintf("  Lights: %d\n", len(config.LightServices)))
 			
 			if config.ActiveStreamer != nil {
-				result.WriteString(fmt.Sprintf("  Active Streamer: %s\n", config.ActiveStreamer.RID))
+				result.WriteString(fmt.Sprintf("  Active Streamer: %s\n", hueClient.DescribeActiveStreamer(ctx, *config.ActiveStreamer)))
 			}
 		}
 
@@ -50,7 +50,7 @@ func HandleStartEntertainment(hueClient *client.Client) server.ToolHandlerFunc {
 
 		err := hueClient.StartEntertainment(ctx, configID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to start entertainment: %v", err)), nil
+			return toolError("Failed to start entertainment", err), nil
 		}
 
 		return mcp.NewToolResultText(fmt.Sprintf("Entertainment mode started for configuration %s", configID)), nil
@@ -68,7 +68,7 @@ func HandleStopEntertainment(hueClient *client.Client) server.ToolHandlerFunc {
 
 		err := hueClient.StopEntertainment(ctx, configID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to stop entertainment: %v", err)), nil
+			return toolError("Failed to stop entertainment", err), nil
 		}
 
 		return mcp.NewToolResultText(fmt.Sprintf("Entertainment mode stopped for configuration %s", configID)), nil
@@ -100,10 +100,19 @@ func HandleStartStreaming(hueClient *client.Client) server.ToolHandlerFunc {
 			return mcp.NewToolResultText(fmt.Sprintf("Streaming already active for configuration %s", configID)), nil
 		}
 
+		// Check the configuration has something to stream to
+		config, err := hueClient.GetEntertainmentConfiguration(ctx, configID)
+		if err != nil {
+			return toolError("Failed to get entertainment configuration", err), nil
+		}
+		if err := config.Validate(); err != nil {
+			return toolError("Cannot start streaming", err), nil
+		}
+
 		// Create new streamer
 		streamer, err := client.NewEntertainmentStreamer(hueClient, configID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to create streamer: %v", err)), nil
+			return toolError("Failed to create streamer", err), nil
 		}
 
 		// Set update rate if provided
@@ -113,10 +122,17 @@ func HandleStartStreaming(hueClient *client.Client) server.ToolHandlerFunc {
 			}
 		}
 
+		// Ease between sparse color updates if requested
+		if easeStr, ok := args["ease_ms"].(string); ok {
+			if ease, err := strconv.Atoi(easeStr); err == nil && ease > 0 {
+				streamer.SetEasing(time.Duration(ease) * time.Millisecond)
+			}
+		}
+
 		// Start streaming
 		err = streamer.Start(ctx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to start streaming: %v", err)), nil
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to start streaming: %v%s", err, activeStreamerNote(ctx, hueClient, configID))), nil
 		}
 
 		// Store streamer
@@ -151,7 +167,7 @@ func HandleStopStreaming(hueClient *client.Client) server.ToolHandlerFunc {
 
 		err := streamer.Stop(ctx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to stop streaming: %v", err)), nil
+			return toolError("Failed to stop streaming", err), nil
 		}
 
 		return mcp.NewToolResultText(fmt.Sprintf("UDP streaming stopped for configuration %s", configID)), nil
@@ -178,19 +194,19 @@ func HandleSendColors(hueClient *client.Client) server.ToolHandlerFunc {
 		streamersMutex.RUnlock()
 
 		if !exists {
-			return mcp.NewToolResultError(fmt.Sprintf("No active streaming for configuration %s", configID)), nil
+			return mcp.NewToolResultError(fmt.Sprintf("No active streaming for configuration %s%s", configID, activeStreamerNote(ctx, hueClient, configID))), nil
 		}
 
 		// Parse colors
 		updates, err := parseColorUpdates(colorsStr)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to parse colors: %v", err)), nil
+			return toolError("Failed to parse colors", err), nil
 		}
 
 		// Send colors
 		err = streamer.SendColors(updates)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to send colors: %v", err)), nil
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to send colors: %v%s", err, activeStreamerNote(ctx, hueClient, configID))), nil
 		}
 
 		return mcp.NewToolResultText(fmt.Sprintf("Sent color updates to %d lights", len(updates))), nil
@@ -217,6 +233,7 @@ func HandleStreamingStatus(hueClient *client.Client) server.ToolHandlerFunc {
 					result += fmt.Sprintf("    - %s (%s)\n", light.RID, light.RType)
 				}
 			}
+			result += describeStreamStats(streamer.GetStats())
 			result += "\n"
 		}
 
@@ -255,7 +272,7 @@ func HandleRainbowEffect(hueClient *client.Client) server.ToolHandlerFunc {
 		// Get lights
 		lights := streamer.GetLights()
 		if len(lights) == 0 {
-			return mcp.NewToolResultError("No lights found in configuration"), nil
+			return mcp.NewToolResultError(fmt.Sprintf("No lights found in configuration %s; add lights to it in the Hue app", configID)), nil
 		}
 
 		// Start rainbow effect
@@ -265,6 +282,123 @@ func HandleRainbowEffect(hueClient *client.Client) server.ToolHandlerFunc {
 	}
 }
 
+// HandleChaseEffect sends a bright dot with a fading tail around the streaming lights
+func HandleChaseEffect(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		streamer, lights, color, duration, errResult := parseStreamEffectArgs(args)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		tail := 2
+		if t, ok := args["tail_length"].(float64); ok {
+			if t < 0 {
+				return mcp.NewToolResultError("tail_length cannot be negative"), nil
+			}
+			tail = int(t)
+		}
+
+		speed := 4.0
+		if s, ok := args["speed"].(float64); ok {
+			if s <= 0 {
+				return mcp.NewToolResultError("speed must be positive (lights per second)"), nil
+			}
+			speed = s
+		}
+
+		go runChaseEffect(streamer, lights, color, tail, speed, duration)
+
+		return mcp.NewToolResultText(fmt.Sprintf("Chase effect started across %d lights for %s (tail %d, %.1f lights/s)", len(lights), duration, tail, speed)), nil
+	}
+}
+
+// HandleColorWipe fills the streaming lights one by one with a color
+func HandleColorWipe(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		streamer, lights, color, duration, errResult := parseStreamEffectArgs(args)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		go runColorWipe(streamer, lights, color, duration)
+
+		return mcp.NewToolResultText(fmt.Sprintf("Color wipe started across %d lights over %s", len(lights), duration)), nil
+	}
+}
+
+// parseStreamEffectArgs reads the config, color and duration shared by the streaming effects
+func parseStreamEffectArgs(args map[string]interface{}) (*client.EntertainmentStreamer, []client.ResourceIdentifier, client.RGB, time.Duration, *mcp.CallToolResult) {
+	configID, ok := args["config_id"].(string)
+	if !ok || configID == "" {
+		return nil, nil, client.RGB{}, 0, mcp.NewToolResultError("config_id is required")
+	}
+
+	colorStr, ok := args["color"].(string)
+	if !ok || colorStr == "" {
+		return nil, nil, client.RGB{}, 0, mcp.NewToolResultError("color is required")
+	}
+	hex, err := parseColor(colorStr)
+	if err != nil {
+		return nil, nil, client.RGB{}, 0, mcp.NewToolResultError(err.Error())
+	}
+
+	seconds := 10.0
+	if d, ok := args["duration"].(float64); ok {
+		if d <= 0 {
+			return nil, nil, client.RGB{}, 0, mcp.NewToolResultError("duration must be a positive number of seconds")
+		}
+		seconds = d
+	}
+
+	streamersMutex.RLock()
+	streamer, exists := activeStreamers[configID]
+	streamersMutex.RUnlock()
+
+	if !exists {
+		return nil, nil, client.RGB{}, 0, mcp.NewToolResultError(fmt.Sprintf("No active streaming for configuration %s", configID))
+	}
+
+	lights := streamer.GetLights()
+	if len(lights) == 0 {
+		return nil, nil, client.RGB{}, 0, mcp.NewToolResultError(fmt.Sprintf("No lights found in configuration %s; add lights to it in the Hue app", configID))
+	}
+
+	return streamer, lights, hexToStreamRGB(hex), time.Duration(seconds * float64(time.Second)), nil
+}
+
+// describeStreamStats summarises packet flow so stalled streams are easy to spot
+func describeStreamStats(stats client.StreamStats) string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("  Packets sent: %d\n", stats.PacketsSent))
+	if stats.PacketsSent > 0 {
+		result.WriteString(fmt.Sprintf("  Last send: %s ago\n", time.Since(stats.LastSend).Round(time.Millisecond)))
+		result.WriteString(fmt.Sprintf("  Effective FPS: %.1f\n", stats.EffectiveFPS))
+	}
+	if stats.SendErrors > 0 {
+		result.WriteString(fmt.Sprintf("  Send errors: %d (last: %s)\n", stats.SendErrors, stats.LastError))
+	}
+	if !stats.Running {
+		result.WriteString("  ⚠️ Streamer is not running\n")
+	} else if stats.PacketsSent == 0 {
+		result.WriteString("  ⚠️ No packets are flowing; the bridge may not have accepted the stream\n")
+	}
+	return result.String()
+}
+
+// activeStreamerNote explains which app currently holds an entertainment area's stream, if any
+func activeStreamerNote(ctx context.Context, hueClient *client.Client, configID string) string {
+	config, err := hueClient.GetEntertainmentConfiguration(ctx, configID)
+	if err != nil || config.ActiveStreamer == nil {
+		return ""
+	}
+	
+	return fmt.Sprintf("\nThe entertainment area is currently being streamed by %s", hueClient.DescribeActiveStreamer(ctx, *config.ActiveStreamer))
+}
+
 // parseColorUpdates parses color updates from string format
 func parseColorUpdates(colorsStr string) ([]client.EntertainmentUpdate, error) {
 	var updates []client.EntertainmentUpdate
@@ -320,44 +454,118 @@ func parseColorUpdates(colorsStr string) ([]client.EntertainmentUpdate, error) {
 
 // runRainbowEffect runs a rainbow effect on the given lights
 func runRainbowEffect(streamer *client.EntertainmentStreamer, lights []client.ResourceIdentifier, duration time.Duration) {
+	runStreamEffect(streamer, duration, func(elapsed time.Duration) []client.EntertainmentUpdate {
+		// Calculate rainbow colors
+		progress := float64(elapsed) / float64(duration)
+		var updates []client.EntertainmentUpdate
+		
+		for i, light := range lights {
+			// Create rainbow effect with phase offset for each light
+			hueValue := (progress + float64(i)*0.1) * 360
+			for hueValue >= 360 {
+				hueValue -= 360
+			}
+			
+			r, g, b := hsvToRGB(hueValue, 1.0, 1.0)
+			red, green, blue := client.FloatRGBToUint16(r, g, b)
+			
+			updates = append(updates, client.EntertainmentUpdate{
+				LightID: light.RID,
+				Red:     red,
+				Green:   green,
+				Blue:    blue,
+			})
+		}
+		
+		return updates
+	})
+}
+
+// runStreamEffect sends a frame every 50ms until the duration passes or the streamer stops
+func runStreamEffect(streamer *client.EntertainmentStreamer, duration time.Duration, frame func(elapsed time.Duration) []client.EntertainmentUpdate) {
 	start := time.Now()
 	ticker := time.NewTicker(50 * time.Millisecond) // 20fps
 	defer ticker.Stop()
 	
 	for {
 		select {
+		case <-streamer.Done():
+			return
 		case <-ticker.C:
-			if time.Since(start) >= duration {
+			elapsed := time.Since(start)
+			if elapsed >= duration {
 				return
 			}
 			
-			// Calculate rainbow colors
-			progress := float64(time.Since(start)) / float64(duration)
-			var updates []client.EntertainmentUpdate
-			
-			for i, light := range lights {
-				// Create rainbow effect with phase offset for each light
-				hueValue := (progress + float64(i)*0.1) * 360
-				for hueValue >= 360 {
-					hueValue -= 360
-				}
-				
-				r, g, b := hsvToRGB(hueValue, 1.0, 1.0)
-				red, green, blue := client.FloatRGBToUint16(r, g, b)
-				
-				updates = append(updates, client.EntertainmentUpdate{
-					LightID: light.RID,
-					Red:     red,
-					Green:   green,
-					Blue:    blue,
-				})
+			if err := streamer.SendColors(frame(elapsed)); err != nil {
+				return
 			}
-			
-			streamer.SendColors(updates)
 		}
 	}
 }
 
+// runChaseEffect moves a bright dot around the lights, leaving a fading tail behind it
+func runChaseEffect(streamer *client.EntertainmentStreamer, lights []client.ResourceIdentifier, color client.RGB, tail int, speed float64, duration time.Duration) {
+	runStreamEffect(streamer, duration, func(elapsed time.Duration) []client.EntertainmentUpdate {
+		head := int(elapsed.Seconds()*speed) % len(lights)
+		return chaseFrame(lights, color, head, tail)
+	})
+}
+
+// chaseFrame lights the head at full color and the tail lights behind it progressively dimmer
+func chaseFrame(lights []client.ResourceIdentifier, color client.RGB, head, tail int) []client.EntertainmentUpdate {
+	updates := make([]client.EntertainmentUpdate, len(lights))
+	for i, light := range lights {
+		behind := (head - i + len(lights)) % len(lights)
+		
+		level := 0.0
+		if behind <= tail {
+			level = 1 - float64(behind)/float64(tail+1)
+		}
+		updates[i] = scaledUpdate(light.RID, color, level)
+	}
+	return updates
+}
+
+// runColorWipe fills the lights with a color one at a time over the duration
+func runColorWipe(streamer *client.EntertainmentStreamer, lights []client.ResourceIdentifier, color client.RGB, duration time.Duration) {
+	runStreamEffect(streamer, duration, func(elapsed time.Duration) []client.EntertainmentUpdate {
+		filled := int(float64(elapsed)/float64(duration)*float64(len(lights))) + 1
+		return wipeFrame(lights, color, filled)
+	})
+}
+
+// wipeFrame lights the first filled lights with the color and leaves the rest off
+func wipeFrame(lights []client.ResourceIdentifier, color client.RGB, filled int) []client.EntertainmentUpdate {
+	updates := make([]client.EntertainmentUpdate, len(lights))
+	for i, light := range lights {
+		level := 0.0
+		if i < filled {
+			level = 1
+		}
+		updates[i] = scaledUpdate(light.RID, color, level)
+	}
+	return updates
+}
+
+// scaledUpdate returns an update for the light with the color dimmed to the given level
+func scaledUpdate(lightID string, color client.RGB, level float64) client.EntertainmentUpdate {
+	return client.EntertainmentUpdate{
+		LightID: lightID,
+		Red:     uint16(float64(color.Red) * level),
+		Green:   uint16(float64(color.Green) * level),
+		Blue:    uint16(float64(color.Blue) * level),
+	}
+}
+
+// hexToStreamRGB converts a #RRGGBB color to 16-bit streaming channels
+func hexToStreamRGB(hex string) client.RGB {
+	var r, g, b uint8
+	fmt.Sscanf(strings.TrimPrefix(hex, "#"), "%02x%02x%02x", &r, &g, &b)
+	red, green, blue := client.RGBToUint16(r, g, b)
+	return client.RGB{Red: red, Green: green, Blue: blue}
+}
+
 // hsvToRGB converts HSV color to RGB
 func hsvToRGB(h, s, v float64) (float64, float64, float64) {
 	c := v * s
@@ -393,4 +601,97 @@ func abs(x float64) float64 {
 
 func mod(x, y float64) float64 {
 	return x - y*float64(int(x/y))
-}
\ No newline at end of file
+}
+
+// HandleStartAudioSync switches an active stream into audio-reactive mode
+func HandleStartAudioSync(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		configID, ok := args["config_id"].(string)
+		if !ok || configID == "" {
+			return mcp.NewToolResultError("config_id is required"), nil
+		}
+
+		streamersMutex.RLock()
+		streamer, exists := activeStreamers[configID]
+		streamersMutex.RUnlock()
+
+		if !exists {
+			return mcp.NewToolResultError(fmt.Sprintf("No active streaming for configuration %s; call start_streaming first", configID)), nil
+		}
+
+		streamer.SetAudioReactive(true)
+
+		return mcp.NewToolResultText(fmt.Sprintf("Audio sync started for configuration %s. Push levels with push_audio_level; bass, mid and treble are spread across %d lights", configID, len(streamer.GetLights()))), nil
+	}
+}
+
+// HandleStopAudioSync returns a stream to normal color updates
+func HandleStopAudioSync(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		configID, ok := args["config_id"].(string)
+		if !ok || configID == "" {
+			return mcp.NewToolResultError("config_id is required"), nil
+		}
+
+		streamersMutex.RLock()
+		streamer, exists := activeStreamers[configID]
+		streamersMutex.RUnlock()
+
+		if !exists || !streamer.AudioReactive() {
+			return mcp.NewToolResultText(fmt.Sprintf("Audio sync is not running for configuration %s", configID)), nil
+		}
+
+		streamer.SetAudioReactive(false)
+
+		return mcp.NewToolResultText(fmt.Sprintf("Audio sync stopped for configuration %s", configID)), nil
+	}
+}
+
+// HandlePushAudioLevel feeds an amplitude sample, or explicit band levels, to an audio sync
+func HandlePushAudioLevel(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		configID, ok := args["config_id"].(string)
+		if !ok || configID == "" {
+			return mcp.NewToolResultError("config_id is required"), nil
+		}
+
+		streamersMutex.RLock()
+		streamer, exists := activeStreamers[configID]
+		streamersMutex.RUnlock()
+
+		if !exists {
+			return mcp.NewToolResultError(fmt.Sprintf("No active streaming for configuration %s", configID)), nil
+		}
+
+		bass, hasBass := args["bass"].(float64)
+		mid, hasMid := args["mid"].(float64)
+		treble, hasTreble := args["treble"].(float64)
+
+		if hasBass || hasMid || hasTreble {
+			if err := streamer.PushAudioBands(client.AudioBands{Bass: bass, Mid: mid, Treble: treble}); err != nil {
+				return toolError("Failed to push audio bands", err), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Audio bands set: bass %.2f, mid %.2f, treble %.2f", bass, mid, treble)), nil
+		}
+
+		level, ok := args["level"].(float64)
+		if !ok {
+			return mcp.NewToolResultError("level, or one of bass/mid/treble, is required"), nil
+		}
+		if level < 0 || level > 1 {
+			return mcp.NewToolResultError("level must be between 0 and 1"), nil
+		}
+
+		if err := streamer.PushAudioLevel(level); err != nil {
+			return toolError("Failed to push audio level", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Audio level %.2f pushed", level)), nil
+	}
+}
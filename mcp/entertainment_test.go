@@ -0,0 +1,138 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestHandleListEntertainmentNamesActiveStreamer(t *testing.T) {
+	fb := newStreamingBridge(t)
+	
+	result := callTool(t, HandleListEntertainment(fb.client()), nil)
+	if result.IsError {
+		t.Fatalf("list_entertainment failed: %s", resultText(result))
+	}
+	
+	if text := resultText(result); !strings.Contains(text, "Active Streamer: Hue Sync Box (auth_v1 app-1)") {
+		t.Errorf("Expected the active streamer's app name, got:\n%s", text)
+	}
+}
+
+func TestHandleSendColorsReportsActiveStreamer(t *testing.T) {
+	fb := newStreamingBridge(t)
+	
+	result := callTool(t, HandleSendColors(fb.client()), map[string]interface{}{
+		"config_id": "ent-1",
+		"colors":    "light-1:255,0,0",
+	})
+	if !result.IsError {
+		t.Fatal("Expected send_colors to fail without a local stream")
+	}
+	
+	if text := resultText(result); !strings.Contains(text, "being streamed by Hue Sync Box") {
+		t.Errorf("Expected error to name the app holding the stream, got:\n%s", text)
+	}
+}
+
+func TestHandleStartStreamingZeroChannels(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("entertainment_configuration", client.Entertainment{ID: "ent-empty", Metadata: client.Metadata{Name: "Empty Area"}})
+	
+	result := callTool(t, HandleStartStreaming(fb.client()), map[string]interface{}{"config_id": "ent-empty"})
+	if !result.IsError {
+		t.Fatal("Expected start_streaming to fail for a configuration with no channels")
+	}
+	
+	if text := resultText(result); !strings.Contains(text, "'Empty Area' (ent-empty) has no channels") {
+		t.Errorf("Expected a clear no-channels error, got: %s", text)
+	}
+	
+	streamersMutex.RLock()
+	_, registered := activeStreamers["ent-empty"]
+	streamersMutex.RUnlock()
+	if registered {
+		t.Error("Expected no streamer to be registered")
+	}
+}
+
+func streamLights(n int) []client.ResourceIdentifier {
+	lights := make([]client.ResourceIdentifier, n)
+	for i := range lights {
+		lights[i] = client.ResourceIdentifier{RID: fmt.Sprintf("light-%d", i), RType: "light"}
+	}
+	return lights
+}
+
+func TestChaseFrameFadesTail(t *testing.T) {
+	red := client.RGB{Red: 60000}
+	
+	// Head on light 1 wraps its two-light tail around to the end of the strip
+	updates := chaseFrame(streamLights(5), red, 1, 2)
+	
+	want := []uint16{40000, 60000, 0, 0, 20000}
+	for i, update := range updates {
+		if update.Red != want[i] {
+			t.Errorf("Light %d: expected red %d, got %d", i, want[i], update.Red)
+		}
+	}
+	
+	// Without a tail only the head is lit
+	updates = chaseFrame(streamLights(3), red, 2, 0)
+	if updates[2].Red != 60000 || updates[0].Red != 0 || updates[1].Red != 0 {
+		t.Errorf("Expected only the head lit, got %+v", updates)
+	}
+}
+
+func TestWipeFrameFillsInOrder(t *testing.T) {
+	blue := client.RGB{Blue: 50000}
+	
+	updates := wipeFrame(streamLights(4), blue, 2)
+	for i, update := range updates {
+		lit := update.Blue == 50000
+		if lit != (i < 2) {
+			t.Errorf("Light %d: expected lit=%v, got %+v", i, i < 2, update)
+		}
+	}
+}
+
+func TestStreamEffectStopsWithStreamer(t *testing.T) {
+	fb := newFakeBridge(t)
+	streamer, _ := client.NewEntertainmentStreamer(fb.client(), "ent-1")
+	
+	// The streamer is not running, so the first frame fails and the effect ends
+	done := make(chan struct{})
+	go func() {
+		runChaseEffect(streamer, streamLights(3), client.RGB{Red: 65535}, 1, 4, time.Hour)
+		close(done)
+	}()
+	
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the chase effect to stop once the stream is gone")
+	}
+}
+
+func TestHandleChaseEffectRequiresStream(t *testing.T) {
+	fb := newFakeBridge(t)
+	
+	result := callTool(t, HandleChaseEffect(fb.client()), map[string]interface{}{
+		"config_id": "ent-missing",
+		"color":     "red",
+	})
+	if !result.IsError || !strings.Contains(resultText(result), "No active streaming") {
+		t.Errorf("Expected a no-stream error, got: %s", resultText(result))
+	}
+	
+	result = callTool(t, HandleColorWipe(fb.client()), map[string]interface{}{
+		"config_id": "ent-missing",
+		"color":     "not-a-color",
+	})
+	if !result.IsError {
+		t.Error("Expected an invalid color to be rejected")
+	}
+}
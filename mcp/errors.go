@@ -0,0 +1,27 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// toolError formats a failed bridge call as a tool error, adding a hint for the failures
+// a user can act on: a missing resource, a rejected application key, a throttled bridge or
+// a request that timed out
+func toolError(action string, err error) *mcp.CallToolResult {
+	message := fmt.Sprintf("%s: %v", action, err)
+	switch {
+	case errors.Is(err, client.ErrUnauthorized):
+		message += "\nThe bridge rejected the application key. Check HUE_USERNAME, or run 'hue pair --bridge <ip>' to create a new one."
+	case errors.Is(err, client.ErrRateLimited):
+		message += "\nThe bridge is rate limiting requests. Wait a moment and try again, or send fewer commands at once."
+	case errors.Is(err, client.ErrTimeout):
+		message += "\nThe bridge did not answer in time. Check it is powered on and reachable, or raise HUE_HTTP_TIMEOUT_MS."
+	case errors.Is(err, client.ErrNotFound):
+		message += "\nIt may have been deleted or its ID changed. List the resources to find the current ID."
+	}
+	return mcp.NewToolResultError(message)
+}
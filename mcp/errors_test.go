@@ -0,0 +1,18 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMissingLightErrorHasHint(t *testing.T) {
+	fb := newFakeBridge(t)
+
+	result := callTool(t, HandleGetLightState(fb.client()), map[string]interface{}{"light_id": "light-9"})
+	if !result.IsError {
+		t.Fatal("Expected an error for a missing light")
+	}
+	if text := resultText(result); !strings.Contains(text, "HTTP 404: Not Found") || !strings.Contains(text, "may have been deleted") {
+		t.Errorf("Expected the not-found hint, got: %s", text)
+	}
+}
@@ -2,9 +2,12 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/kungfusheep/hue/client"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -20,8 +23,21 @@ type EventManager struct {
 	maxEvents     int
 	streaming     bool
 	streamingLock sync.Mutex
+	decodeErrors  int              // Events skipped because they could not be parsed
+	automations   *AutomationStore // Rules evaluated against incoming events
+	cancel        context.CancelFunc
+	stopped       chan struct{} // Closed when the supervising goroutine exits
+	reconnects    int // Times the stream was re-established after dropping
+	webhookURL    string // Every stored event is POSTed here when set
+	webhookMutex  sync.RWMutex
 }
 
+// Backoff bounds for re-establishing a dropped event stream
+var (
+	reconnectBackoffMin = time.Second
+	reconnectBackoffMax = time.Minute
+)
+
 // Global event manager instance
 var eventManager *EventManager
 
@@ -31,6 +47,7 @@ func InitEventManager(hueClient *client.Client) {
 		client:       hueClient,
 		recentEvents: make([]client.Event, 0),
 		maxEvents:    1000,
+		automations:  GetAutomationStore(),
 	}
 }
 
@@ -55,17 +72,22 @@ func HandleStartEventStream(hueClient *client.Client) server.ToolHandlerFunc {
 			filterTypes = strings.Split(filter, ",")
 		}
 
-		// Start the stream
-		stream, err := hueClient.StreamEvents(ctx)
+		// Start the stream; it outlives this request so it gets its own context
+		streamCtx, cancel := context.WithCancel(context.Background())
+		stream, err := hueClient.StreamEvents(streamCtx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to start event stream: %v", err)), nil
+			cancel()
+			return toolError("Failed to start event stream", err), nil
 		}
 
 		eventManager.stream = stream
 		eventManager.streaming = true
+		eventManager.cancel = cancel
+		eventManager.reconnects = 0
+		eventManager.stopped = make(chan struct{})
 
-		// Start processing events in background
-		go eventManager.processEvents(filterTypes)
+		// Process events in background, reconnecting if the stream drops
+		go eventManager.superviseStream(streamCtx, stream, filterTypes, eventManager.stopped)
 
 		result := "Event stream started successfully"
 		if len(filterTypes) > 0 {
@@ -79,21 +101,41 @@ func HandleStartEventStream(hueClient *client.Client) server.ToolHandlerFunc {
 // HandleStopEventStream stops the event stream
 func HandleStopEventStream(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if eventManager == nil || !eventManager.streaming {
+		if !stopEventStream(ctx) {
 			return mcp.NewToolResultText("Event stream is not running"), nil
 		}
+		return mcp.NewToolResultText("Event stream stopped"), nil
+	}
+}
 
-		eventManager.streamingLock.Lock()
-		defer eventManager.streamingLock.Unlock()
+// stopEventStream stops the event stream and waits for its reconnect loop to exit so no new
+// connections are made, giving up waiting when ctx ends. It reports whether a stream was running.
+func stopEventStream(ctx context.Context) bool {
+	if eventManager == nil {
+		return false
+	}
 
-		if eventManager.stream != nil {
-			eventManager.stream.Close()
-			eventManager.stream = nil
-		}
-		eventManager.streaming = false
+	eventManager.streamingLock.Lock()
+	if !eventManager.streaming {
+		eventManager.streamingLock.Unlock()
+		return false
+	}
+	if eventManager.cancel != nil {
+		eventManager.cancel()
+		eventManager.cancel = nil
+	}
+	stopped := eventManager.stopped
+	eventManager.stream = nil
+	eventManager.streaming = false
+	eventManager.streamingLock.Unlock()
 
-		return mcp.NewToolResultText("Event stream stopped"), nil
+	if stopped != nil {
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+		}
 	}
+	return true
 }
 
 // HandleGetRecentEvents returns recent events
@@ -114,6 +156,8 @@ func HandleGetRecentEvents(hueClient *client.Client) server.ToolHandlerFunc {
 			eventType = t
 		}
 
+		resourceID, _ := args["resource_id"].(string)
+
 		eventManager.eventsMutex.RLock()
 		defer eventManager.eventsMutex.RUnlock()
 
@@ -130,15 +174,29 @@ func HandleGetRecentEvents(hueClient *client.Client) server.ToolHandlerFunc {
 				continue
 			}
 
+			// With a resource ID, only the parts of the event about that resource are shown
+			items := event.Data
+			if resourceID != "" {
+				items = nil
+				for _, data := range event.Data {
+					if data.ID == resourceID {
+						items = append(items, data)
+					}
+				}
+				if len(items) == 0 {
+					continue
+				}
+			}
+
 			result.WriteString(fmt.Sprintf("🔔 Event %s at %s\n", event.ID, event.CreationTime))
 			result.WriteString(fmt.Sprintf("   Type: %s\n", event.Type))
 			
-			for _, data := range event.Data {
+			for _, data := range items {
 				result.WriteString(fmt.Sprintf("   • %s (%s)\n", data.Type, data.ID))
 				
 				// Show relevant details based on type
 				switch data.Type {
-				case "light":
+				case "light", "grouped_light":
 					if data.On != nil {
 						result.WriteString(fmt.Sprintf("     On: %v\n", data.On.On))
 					}
@@ -148,6 +206,10 @@ func HandleGetRecentEvents(hueClient *client.Client) server.ToolHandlerFunc {
 					if data.Color != nil {
 						result.WriteString(fmt.Sprintf("     Color: XY(%.3f, %.3f)\n", data.Color.XY.X, data.Color.XY.Y))
 					}
+					// The bridge sends a null mirek when a light leaves color temperature mode
+					if data.ColorTemperature != nil && data.ColorTemperature.Mirek > 0 {
+						result.WriteString(fmt.Sprintf("     Color Temperature: %s\n", describeMirek(data.ColorTemperature.Mirek)))
+					}
 				case "motion":
 					if data.Motion != nil {
 						result.WriteString(fmt.Sprintf("     Motion: %v\n", data.Motion.Motion))
@@ -175,6 +237,9 @@ func HandleGetRecentEvents(hueClient *client.Client) server.ToolHandlerFunc {
 			if eventType != "" {
 				result.WriteString(fmt.Sprintf(" of type '%s'", eventType))
 			}
+			if resourceID != "" {
+				result.WriteString(fmt.Sprintf(" for resource %s", resourceID))
+			}
 		}
 
 		return mcp.NewToolResultText(result.String()), nil
@@ -206,43 +271,161 @@ func HandleGetEventStreamStatus(hueClient *client.Client) server.ToolHandlerFunc
 			eventManager.eventsMutex.RUnlock()
 			
 			result.WriteString(fmt.Sprintf("• Events buffered: %d\n", eventCount))
+			result.WriteString(fmt.Sprintf("• Decode errors: %d\n", eventManager.DecodeErrors()))
+			result.WriteString(fmt.Sprintf("• Reconnects: %d\n", eventManager.ReconnectCount()))
 			result.WriteString(fmt.Sprintf("• Max buffer size: %d\n", eventManager.maxEvents))
+			if webhook := eventManager.Webhook(); webhook != "" {
+				result.WriteString(fmt.Sprintf("• Webhook: %s\n", webhook))
+			}
 		}
 		
 		return mcp.NewToolResultText(result.String()), nil
 	}
 }
 
-// processEvents processes incoming events
-func (em *EventManager) processEvents(filterTypes []string) {
-	var events <-chan client.Event
+// superviseStream processes events until stopped, re-establishing the stream with
+// exponential backoff whenever it drops
+func (em *EventManager) superviseStream(ctx context.Context, stream *client.EventStream, filterTypes []string, stopped chan struct{}) {
+	defer close(stopped)
+	backoff := reconnectBackoffMin
 	
-	if len(filterTypes) > 0 {
-		events = em.stream.FilterEvents(filterTypes...)
-	} else {
-		events = em.stream.Events()
+	for {
+		if stream != nil {
+			received := em.processEvents(ctx, stream, filterTypes)
+			stream.Close()
+			if received {
+				backoff = reconnectBackoffMin
+			}
+		}
+		
+		if ctx.Err() != nil {
+			return
+		}
+		
+		log.Printf("Event stream disconnected, reconnecting in %v", backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		
+		backoff *= 2
+		if backoff > reconnectBackoffMax {
+			backoff = reconnectBackoffMax
+		}
+		
+		next, err := em.client.StreamEvents(ctx)
+		if err != nil {
+			log.Printf("Failed to reconnect event stream: %v", err)
+			stream = nil
+			continue
+		}
+		
+		em.streamingLock.Lock()
+		if ctx.Err() != nil {
+			em.streamingLock.Unlock()
+			next.Close()
+			return
+		}
+		em.stream = next
+		em.reconnects++
+		em.streamingLock.Unlock()
+		
+		stream = next
+	}
+}
+
+// processEvents processes incoming events until the stream fails or is stopped.
+// It reports whether any events were received.
+func (em *EventManager) processEvents(ctx context.Context, stream *client.EventStream, filterTypes []string) bool {
+	events := stream.Events()
+	errs := stream.Errors()
+	
+	wanted := make(map[string]bool)
+	for _, t := range filterTypes {
+		wanted[t] = true
 	}
 	
+	received := false
 	for {
 		select {
+		case <-ctx.Done():
+			return received
+			
 		case event, ok := <-events:
 			if !ok {
-				return
+				return received
 			}
-			em.storeEvent(event)
+			received = true
+			// The filter only limits what is stored; automations see every event
+			if len(wanted) == 0 || wanted[event.Type] {
+				em.storeEvent(event)
+			}
+			em.runAutomations(event)
 			
-		case err, ok := <-em.stream.Errors():
+		case err, ok := <-errs:
 			if !ok {
-				return
+				return received
+			}
+			
+			var decodeErr *client.DecodeError
+			if errors.As(err, &decodeErr) {
+				em.eventsMutex.Lock()
+				em.decodeErrors++
+				em.eventsMutex.Unlock()
+				log.Printf("Event stream error: %v", err)
+				continue
 			}
-			// Log error but continue
-			fmt.Printf("Event stream error: %v\n", err)
+			
+			// Connection failures are handled by reconnecting
+			log.Printf("Event stream error: %v", err)
+			return received
 		}
 	}
 }
 
-// storeEvent stores an event in the recent events buffer
+// ReconnectCount returns how many times the stream has been re-established
+func (em *EventManager) ReconnectCount() int {
+	em.streamingLock.Lock()
+	defer em.streamingLock.Unlock()
+	return em.reconnects
+}
+
+// runAutomations fires any automations matched by the event's data
+func (em *EventManager) runAutomations(event client.Event) {
+	if em.automations == nil {
+		return
+	}
+
+	for _, data := range event.Data {
+		for _, automation := range em.automations.Match(data, time.Now()) {
+			go func(automation *Automation) {
+				if err := runAutomationAction(context.Background(), em.client, automation); err != nil {
+					log.Printf("Automation '%s' failed: %v", automation.Name, err)
+				}
+			}(automation)
+		}
+	}
+}
+
+// isStreaming reports whether the event stream is running
+func (em *EventManager) isStreaming() bool {
+	em.streamingLock.Lock()
+	defer em.streamingLock.Unlock()
+	return em.streaming
+}
+
+// DecodeErrors returns how many events were skipped because they could not be parsed
+func (em *EventManager) DecodeErrors() int {
+	em.eventsMutex.RLock()
+	defer em.eventsMutex.RUnlock()
+	return em.decodeErrors
+}
+
+// storeEvent stores an event in the recent events buffer and passes it to the webhook
 func (em *EventManager) storeEvent(event client.Event) {
+	em.sendWebhook(event)
+	
 	em.eventsMutex.Lock()
 	defer em.eventsMutex.Unlock()
 	
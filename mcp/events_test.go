@@ -0,0 +1,183 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestEventStreamSkipsMalformedEvent(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eventstream/clip/v2" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": hi\n\n")
+		fmt.Fprint(w, `data: [{"id":"evt-1","type":"update","data":[{"id":"light-1","type":"light","on":{"on":true}}]}]`+"\n\n")
+		fmt.Fprint(w, `data: [{"id":"evt-2","type":"update","data":[{"id":"light-1","type":"light","on":"broken"}]}]`+"\n\n")
+		fmt.Fprint(w, `data: [{"id":"evt-3","type":"update","data":[{"id":"light-1","type":"light","on":{"on":false}}]}]`+"\n\n")
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	
+	hueClient := client.NewClient(strings.TrimPrefix(server.URL, "https://"), "test-key", server.Client())
+	t.Setenv("HUE_MCP_DATA_DIR", t.TempDir())
+	InitEventManager(hueClient)
+	
+	result := callTool(t, HandleStartEventStream(hueClient), map[string]interface{}{})
+	if result.IsError {
+		t.Fatalf("start_event_stream failed: %s", resultText(result))
+	}
+	defer callTool(t, HandleStopEventStream(hueClient), map[string]interface{}{})
+	
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		eventManager.eventsMutex.RLock()
+		stored := len(eventManager.recentEvents)
+		eventManager.eventsMutex.RUnlock()
+		if stored == 2 && eventManager.DecodeErrors() == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected 2 stored events and 1 decode error, got %d and %d", stored, eventManager.DecodeErrors())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	
+	eventManager.eventsMutex.RLock()
+	ids := []string{eventManager.recentEvents[0].ID, eventManager.recentEvents[1].ID}
+	eventManager.eventsMutex.RUnlock()
+	if ids[0] != "evt-1" || ids[1] != "evt-3" {
+		t.Errorf("Expected evt-1 and evt-3 to be stored, got %v", ids)
+	}
+	
+	status := resultText(callTool(t, HandleGetEventStreamStatus(hueClient), map[string]interface{}{}))
+	if !strings.Contains(status, "Decode errors: 1") {
+		t.Errorf("Expected decode errors in status, got:\n%s", status)
+	}
+}
+
+func TestEventStreamReconnectsAfterDrop(t *testing.T) {
+	minBackoff := reconnectBackoffMin
+	reconnectBackoffMin = 10 * time.Millisecond
+	defer func() { reconnectBackoffMin = minBackoff }()
+	
+	var mu sync.Mutex
+	connections := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eventstream/clip/v2" {
+			http.NotFound(w, r)
+			return
+		}
+		mu.Lock()
+		connections++
+		n := connections
+		mu.Unlock()
+		
+		// Each connection delivers one event and then drops, like a bridge reboot
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, `data: [{"id":"evt-%d","type":"update","data":[{"id":"light-1","type":"light","on":{"on":true}}]}]`+"\n\n", n)
+	}))
+	defer server.Close()
+	
+	hueClient := client.NewClient(strings.TrimPrefix(server.URL, "https://"), "test-key", server.Client())
+	t.Setenv("HUE_MCP_DATA_DIR", t.TempDir())
+	InitEventManager(hueClient)
+	
+	result := callTool(t, HandleStartEventStream(hueClient), map[string]interface{}{})
+	if result.IsError {
+		t.Fatalf("start_event_stream failed: %s", resultText(result))
+	}
+	
+	deadline := time.Now().Add(2 * time.Second)
+	for eventManager.ReconnectCount() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the stream to reconnect, got %d reconnects", eventManager.ReconnectCount())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	
+	status := resultText(callTool(t, HandleGetEventStreamStatus(hueClient), map[string]interface{}{}))
+	if !strings.Contains(status, "Reconnects:") || !strings.Contains(status, "Running") {
+		t.Errorf("Expected a running stream with reconnects in status, got:\n%s", status)
+	}
+	
+	callTool(t, HandleStopEventStream(hueClient), map[string]interface{}{})
+	
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	stopped := connections
+	mu.Unlock()
+	time.Sleep(100 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if connections != stopped {
+		t.Errorf("Expected no connections after stopping, got %d more", connections-stopped)
+	}
+}
+
+func TestGetRecentEventsShowsColorTemperature(t *testing.T) {
+	t.Setenv("HUE_MCP_DATA_DIR", t.TempDir())
+	fb := newFakeBridge(t)
+	InitEventManager(fb.client())
+	
+	// Captured from a bridge while a circadian automation warmed a light, then switched it to a color
+	captured := `[
+		{"creationtime":"2026-10-14T21:00:00Z","id":"evt-1","type":"update","data":[
+			{"id":"light-1","type":"light","color_temperature":{"mirek":366,"mirek_valid":true},"dimming":{"brightness":40}}]},
+		{"creationtime":"2026-10-14T21:05:00Z","id":"evt-2","type":"update","data":[
+			{"id":"light-1","type":"light","color_temperature":{"mirek":null,"mirek_valid":false},"color":{"xy":{"x":0.6,"y":0.3}}}]}
+	]`
+	var events []client.Event
+	if err := json.Unmarshal([]byte(captured), &events); err != nil {
+		t.Fatalf("Failed to decode captured events: %v", err)
+	}
+	eventManager.recentEvents = events
+	
+	text := resultText(callTool(t, HandleGetRecentEvents(fb.client()), map[string]interface{}{}))
+	if !strings.Contains(text, "Color Temperature: 366 mirek (2732K)") {
+		t.Errorf("Expected the color temperature change, got:\n%s", text)
+	}
+	if strings.Count(text, "Color Temperature:") != 1 {
+		t.Errorf("Expected no color temperature for the switch to color, got:\n%s", text)
+	}
+}
+
+func TestGetRecentEventsFiltersByResource(t *testing.T) {
+	t.Setenv("HUE_MCP_DATA_DIR", t.TempDir())
+	fb := newFakeBridge(t)
+	InitEventManager(fb.client())
+	
+	eventManager.recentEvents = []client.Event{
+		{ID: "evt-1", Type: "update", Data: []client.EventData{{ID: "motion-1", Type: "motion"}, {ID: "light-1", Type: "light"}}},
+		{ID: "evt-2", Type: "update", Data: []client.EventData{{ID: "light-1", Type: "light"}}},
+		{ID: "evt-3", Type: "add", Data: []client.EventData{{ID: "motion-1", Type: "motion"}}},
+	}
+	
+	text := resultText(callTool(t, HandleGetRecentEvents(fb.client()), map[string]interface{}{"resource_id": "motion-1"}))
+	if !strings.Contains(text, "evt-1") || !strings.Contains(text, "evt-3") || strings.Contains(text, "evt-2") {
+		t.Errorf("Expected only the motion sensor's events, got:\n%s", text)
+	}
+	if strings.Contains(text, "light-1") {
+		t.Errorf("Expected other resources in a matching event to be left out, got:\n%s", text)
+	}
+	
+	text = resultText(callTool(t, HandleGetRecentEvents(fb.client()), map[string]interface{}{"resource_id": "motion-1", "type": "update"}))
+	if !strings.Contains(text, "evt-1") || strings.Contains(text, "evt-3") {
+		t.Errorf("Expected the type filter to apply too, got:\n%s", text)
+	}
+}
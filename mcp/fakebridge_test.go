@@ -0,0 +1,117 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/kungfusheep/hue/internal/fakebridge"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// fakeBridge is the shared in-memory bridge with a client for handler tests
+type fakeBridge struct {
+	*fakebridge.Bridge
+}
+
+func newFakeBridge(t *testing.T) *fakeBridge {
+	return &fakeBridge{fakebridge.New(t)}
+}
+
+// client returns a Client pointed at the fake bridge, without rate limits
+func (fb *fakeBridge) client() *client.Client {
+	c := client.NewClient(fb.Host(), "test-key", fb.HTTPClient())
+	c.SetRateLimits(0, 0)
+	return c
+}
+
+// newMixedRoom sets up a room whose lights 1 and 3 are on and light 2 is off
+func newMixedRoom(t *testing.T) *fakeBridge {
+	fb := newFakeBridge(t)
+	fb.Add("room", client.Room{
+		ID:       "room-1",
+		Metadata: client.Metadata{Name: "Living Room"},
+		Services: []client.ResourceIdentifier{{RID: "group-1", RType: "grouped_light"}},
+		Children: []client.ResourceIdentifier{{RID: "device-1", RType: "device"}, {RID: "device-2", RType: "device"}, {RID: "device-3", RType: "device"}},
+	})
+	fb.Add("grouped_light", client.Group{ID: "group-1"})
+	for i := 1; i <= 3; i++ {
+		fb.Add("device", client.Device{
+			ID:       fmt.Sprintf("device-%d", i),
+			Services: []client.ResourceIdentifier{{RID: fmt.Sprintf("light-%d", i), RType: "light"}},
+		})
+		fb.Add("light", client.Light{ID: fmt.Sprintf("light-%d", i), On: client.OnState{On: i != 2}})
+	}
+	return fb
+}
+
+// newStreamingBridge returns a bridge whose entertainment area is held by another app
+func newStreamingBridge(t *testing.T) *fakeBridge {
+	fb := newFakeBridge(t)
+	fb.Add("entertainment_configuration", client.Entertainment{
+		ID:             "ent-1",
+		Metadata:       client.Metadata{Name: "TV Area"},
+		Status:         "active",
+		ActiveStreamer: &client.ResourceIdentifier{RID: "app-1", RType: "auth_v1"},
+	})
+	fb.Add("auth_v1", client.StreamingApplication{ID: "app-1", Type: "auth_v1", AppName: "Hue Sync Box"})
+	return fb
+}
+
+// newPreviewBridge returns a bridge with a light that is on and a scene that turns it on
+func newPreviewBridge(t *testing.T) *fakeBridge {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk"}, On: client.OnState{On: true}, Dimming: client.Dimming{Brightness: 30}})
+	fb.Add("scene", client.Scene{
+		ID:       "scene-1",
+		Metadata: client.Metadata{Name: "Sunset"},
+		Actions: []client.SceneAction{{
+			Target: client.ResourceIdentifier{RID: "light-1", RType: "light"},
+			Action: client.LightUpdate{On: &client.OnState{On: true}},
+		}},
+	})
+	return fb
+}
+
+// newSharedNameBridge returns a bridge with a room and a zone both named "Downstairs"
+func newSharedNameBridge(t *testing.T) *fakeBridge {
+	fb := newFakeBridge(t)
+	fb.Add("room", client.Room{
+		ID:       "room-1",
+		Metadata: client.Metadata{Name: "Downstairs"},
+		Services: []client.ResourceIdentifier{{RID: "room-group", RType: "grouped_light"}},
+	})
+	fb.Add("zone", client.Zone{
+		ID:       "zone-1",
+		Metadata: client.Metadata{Name: "Downstairs"},
+		Services: []client.ResourceIdentifier{{RID: "zone-group", RType: "grouped_light"}},
+	})
+	return fb
+}
+
+// callTool invokes a handler with the given arguments
+func callTool(t *testing.T, handler func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = args
+
+	result, err := handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	return result
+}
+
+// resultText returns the text content of a tool result
+func resultText(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
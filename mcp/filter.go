@@ -0,0 +1,80 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+// filterOnLights returns the IDs from ids whose lights are currently on, in the same order
+func filterOnLights(ctx context.Context, hueClient *client.Client, ids []string) ([]string, error) {
+	lights, err := hueClient.GetLights(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lights: %w", err)
+	}
+
+	on := make(map[string]bool, len(lights))
+	for _, light := range lights {
+		on[light.ID] = light.On.On
+	}
+
+	var result []string
+	for _, id := range ids {
+		if on[id] {
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
+
+// targetOnLights returns the lights of a light or group target that are on.
+// It errors when none are, so callers never end up doing nothing silently.
+func targetOnLights(ctx context.Context, hueClient *client.Client, targetID string, isGroup bool) ([]string, error) {
+	ids := []string{targetID}
+	if isGroup {
+		groupLights, err := hueClient.GetGroupLights(ctx, targetID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get lights in group: %w", err)
+		}
+		ids = groupLights
+	}
+
+	lightIDs, err := filterOnLights(ctx, hueClient, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(lightIDs) == 0 {
+		return nil, fmt.Errorf("no lights are on in %s", targetID)
+	}
+	return lightIDs, nil
+}
+
+// splitEffectSupport divides ids into lights that list effect among their effect_values and
+// lights that don't, the latter described by name for error messages
+func splitEffectSupport(ctx context.Context, hueClient *client.Client, ids []string, effect string) ([]string, []string, error) {
+	lights, err := hueClient.GetLights(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get lights: %w", err)
+	}
+
+	byID := make(map[string]client.Light, len(lights))
+	for _, light := range lights {
+		byID[light.ID] = light
+	}
+
+	var supporting, lacking []string
+	for _, id := range ids {
+		light, ok := byID[id]
+		if ok && light.Effects != nil && containsString(light.Effects.EffectValues, effect) {
+			supporting = append(supporting, id)
+			continue
+		}
+		name := light.Metadata.Name
+		if name == "" {
+			name = id
+		}
+		lacking = append(lacking, fmt.Sprintf("%s (%s)", name, id))
+	}
+	return supporting, lacking, nil
+}
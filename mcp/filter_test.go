@@ -0,0 +1,170 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/kungfusheep/hue/scheduler"
+)
+
+func TestFilterOnLights(t *testing.T) {
+	fb := newMixedRoom(t)
+	
+	got, err := filterOnLights(context.Background(), fb.client(), []string{"light-3", "light-2", "light-1", "missing"})
+	if err != nil {
+		t.Fatalf("filterOnLights failed: %v", err)
+	}
+	if strings.Join(got, ",") != "light-3,light-1" {
+		t.Errorf("Expected light-3,light-1, got %v", got)
+	}
+}
+
+func TestGroupColorOnlyOn(t *testing.T) {
+	fb := newMixedRoom(t)
+	
+	result := callTool(t, HandleGroupColor(fb.client()), map[string]interface{}{
+		"group_id": "group-1",
+		"color":    "#FF0000",
+		"only_on":  true,
+	})
+	if result.IsError {
+		t.Fatalf("group_color failed: %s", resultText(result))
+	}
+	
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/grouped_light/"); len(puts) != 0 {
+		t.Errorf("Expected no group-wide update, got %d", len(puts))
+	}
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-2"); len(puts) != 0 {
+		t.Errorf("Expected the light that is off to be left alone, got %d updates", len(puts))
+	}
+	for _, id := range []string{"light-1", "light-3"} {
+		if puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/"+id); len(puts) != 1 {
+			t.Errorf("Expected %s to be updated once, got %d", id, len(puts))
+		}
+	}
+}
+
+func TestGroupColorOnlyOnAllOff(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("room", client.Room{
+		ID:       "room-1",
+		Services: []client.ResourceIdentifier{{RID: "group-1", RType: "grouped_light"}},
+		Children: []client.ResourceIdentifier{{RID: "light-1", RType: "light"}},
+	})
+	fb.Add("light", client.Light{ID: "light-1"})
+	
+	result := callTool(t, HandleGroupColor(fb.client()), map[string]interface{}{
+		"group_id": "group-1",
+		"color":    "red",
+		"only_on":  true,
+	})
+	if !result.IsError || !strings.Contains(resultText(result), "no lights are on") {
+		t.Errorf("Expected an error when no lights are on, got: %s", resultText(result))
+	}
+}
+
+func TestFlashEffectOnlyOnTargetsOnLights(t *testing.T) {
+	fb := newMixedRoom(t)
+	hueClient := fb.client()
+	
+	seq, err := sequenceForTarget(context.Background(), hueClient, scheduler.CreateFlashEffect("group-1", "#FFFFFF", 1, 100*time.Millisecond), "group-1", true)
+	if err != nil {
+		t.Fatalf("sequenceForTarget failed: %v", err)
+	}
+	
+	targets := map[string]int{}
+	for _, cmd := range seq.Commands {
+		if cmd.Type != "light" {
+			t.Errorf("Expected only light commands, got %s", cmd.Type)
+		}
+		targets[cmd.Target]++
+	}
+	if len(targets) != 2 || targets["light-1"] == 0 || targets["light-3"] == 0 {
+		t.Errorf("Expected commands for light-1 and light-3 only, got %v", targets)
+	}
+	if targets["light-1"] != targets["light-3"] {
+		t.Errorf("Expected both lights to get the same commands, got %v", targets)
+	}
+}
+
+func TestGroupEffectChecksSupport(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("room", client.Room{
+		ID:       "room-1",
+		Services: []client.ResourceIdentifier{{RID: "group-1", RType: "grouped_light"}},
+		Children: []client.ResourceIdentifier{{RID: "light-1", RType: "light"}, {RID: "light-2", RType: "light"}},
+	})
+	fb.Add("light", client.Light{ID: "light-1", Effects: &client.Effects{EffectValues: []string{"no_effect", "candle"}}})
+	fb.Add("light", client.Light{ID: "light-2", Metadata: client.Metadata{Name: "Old Bulb"}})
+	
+	result := callTool(t, HandleGroupEffect(fb.client()), map[string]interface{}{"group_id": "group-1", "effect": "candle"})
+	if !result.IsError || !strings.Contains(resultText(result), "Old Bulb (light-2)") {
+		t.Fatalf("Expected an error naming the unsupported light, got: %s", resultText(result))
+	}
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/"); len(puts) != 0 {
+		t.Fatalf("Expected nothing to be sent, got %d PUTs", len(puts))
+	}
+	
+	result = callTool(t, HandleGroupEffect(fb.client()), map[string]interface{}{"group_id": "group-1", "effect": "candle", "only_supporting": true})
+	if result.IsError {
+		t.Fatalf("group_effect failed: %s", resultText(result))
+	}
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1"); len(puts) != 1 {
+		t.Errorf("Expected the supporting light to get the effect, got %d PUTs", len(puts))
+	}
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-2"); len(puts) != 0 {
+		t.Errorf("Expected the unsupported light to be skipped, got %d PUTs", len(puts))
+	}
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/grouped_light/"); len(puts) != 0 {
+		t.Errorf("Expected no group-wide update, got %d", len(puts))
+	}
+}
+
+func TestGroupColorPerLightUsesEachGamut(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("room", client.Room{
+		ID:       "room-1",
+		Services: []client.ResourceIdentifier{{RID: "group-1", RType: "grouped_light"}},
+		Children: []client.ResourceIdentifier{{RID: "light-1", RType: "light"}, {RID: "light-2", RType: "light"}},
+	})
+	fb.Add("light", client.Light{ID: "light-1", Color: &client.Color{GamutType: "A"}})
+	fb.Add("light", client.Light{ID: "light-2", Color: &client.Color{GamutType: "B"}})
+
+	result := callTool(t, HandleGroupColor(fb.client()), map[string]interface{}{
+		"group_id":  "group-1",
+		"color":     "#00FF00",
+		"per_light": true,
+	})
+	if result.IsError {
+		t.Fatalf("group_color failed: %s", resultText(result))
+	}
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/grouped_light/"); len(puts) != 0 {
+		t.Errorf("Expected no group-wide update, got %d", len(puts))
+	}
+
+	xys := map[string]interface{}{}
+	for _, id := range []string{"light-1", "light-2"} {
+		puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/"+id)
+		if len(puts) != 1 {
+			t.Fatalf("Expected %s to be updated once, got %d", id, len(puts))
+		}
+		color, _ := puts[0].Body["color"].(map[string]interface{})
+		xys[id] = color["xy"]
+	}
+	if fmt.Sprint(xys["light-1"]) == fmt.Sprint(xys["light-2"]) {
+		t.Errorf("Expected green to be converted differently for gamuts A and B, both got %v", xys["light-1"])
+	}
+
+	result = callTool(t, HandleGroupColor(fb.client()), map[string]interface{}{
+		"group_id":  "group-1",
+		"xy":        "0.3,0.3",
+		"per_light": true,
+	})
+	if !result.IsError {
+		t.Error("Expected per_light with xy to be rejected")
+	}
+}
@@ -0,0 +1,143 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// listItem is the compact JSON form of a resource returned by list tools with format "json"
+type listItem struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type,omitempty"`
+	Index      int      `json:"index,omitempty"`
+	On         *bool    `json:"on,omitempty"`
+	Brightness *float64 `json:"brightness,omitempty"`
+	Lights     []string `json:"lights,omitempty"`
+}
+
+// listFormat reads the format argument of a list tool, returning true for JSON output
+func listFormat(args map[string]interface{}) (bool, error) {
+	format, _ := args["format"].(string)
+	switch format {
+	case "", "text":
+		return false, nil
+	case "json":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown format '%s' (expected text or json)", format)
+	}
+}
+
+// jsonListResult marshals list items as a compact JSON array
+func jsonListResult(items []listItem) (*mcp.CallToolResult, error) {
+	if items == nil {
+		items = []listItem{}
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return toolError("Failed to encode JSON", err), nil
+	}
+	return mcp.NewToolResultText(string(data)), nil
+}
+
+// listPage holds the name_filter, offset and limit arguments of a paged list tool
+type listPage struct {
+	nameFilter string
+	offset     int
+	limit      int // 0 means no limit
+}
+
+// parseListPage reads the paging arguments of a list tool
+func parseListPage(args map[string]interface{}) (listPage, error) {
+	var page listPage
+	page.nameFilter, _ = args["name_filter"].(string)
+	page.nameFilter = strings.TrimSpace(page.nameFilter)
+
+	if offset, ok := args["offset"].(float64); ok {
+		if offset < 0 {
+			return page, fmt.Errorf("offset cannot be negative")
+		}
+		page.offset = int(offset)
+	}
+	if limit, ok := args["limit"].(float64); ok {
+		if limit < 1 {
+			return page, fmt.Errorf("limit must be at least 1")
+		}
+		page.limit = int(limit)
+	}
+	return page, nil
+}
+
+// paged reports whether the caller asked for a filtered or partial list
+func (p listPage) paged() bool {
+	return p.nameFilter != "" || p.offset > 0 || p.limit > 0
+}
+
+// pageItems filters items by name and cuts out the requested page, returning the page
+// and how many items matched the filter in total
+func pageItems[T any](p listPage, items []T, name func(T) string) ([]T, int) {
+	var matched []T
+	filter := strings.ToLower(p.nameFilter)
+	for _, item := range items {
+		if filter == "" || strings.Contains(strings.ToLower(name(item)), filter) {
+			matched = append(matched, item)
+		}
+	}
+
+	total := len(matched)
+	start := p.offset
+	if start > total {
+		start = total
+	}
+	end := total
+	if p.limit > 0 && start+p.limit < total {
+		end = start + p.limit
+	}
+	return matched[start:end], total
+}
+
+// header describes which slice of a list is shown, e.g. "Showing 21-40 of 57 lights matching 'lamp'"
+func (p listPage) header(shown, total int, noun string) string {
+	if !p.paged() {
+		return fmt.Sprintf("Found %d %s", total, noun)
+	}
+
+	header := fmt.Sprintf("Showing %d of %d %s", shown, total, noun)
+	if shown > 0 {
+		header = fmt.Sprintf("Showing %d-%d of %d %s", p.offset+1, p.offset+shown, total, noun)
+	}
+	if p.nameFilter != "" {
+		header += fmt.Sprintf(" matching '%s'", p.nameFilter)
+	}
+	return header
+}
+
+// footer points at the next page when the list was cut short
+func (p listPage) footer(shown, total int) string {
+	next := p.offset + shown
+	if shown == 0 || next >= total {
+		return ""
+	}
+	return fmt.Sprintf("%d more; use offset=%d to see the next page\n", total-next, next)
+}
+
+// jsonPageResult marshals a page of list items along with the total and offset,
+// so callers can tell whether there are more to fetch
+func jsonPageResult(p listPage, items []listItem, total int) (*mcp.CallToolResult, error) {
+	if items == nil {
+		items = []listItem{}
+	}
+	data, err := json.Marshal(struct {
+		Total  int        `json:"total"`
+		Offset int        `json:"offset"`
+		Items  []listItem `json:"items"`
+	}{total, p.offset, items})
+	if err != nil {
+		return toolError("Failed to encode JSON", err), nil
+	}
+	return mcp.NewToolResultText(string(data)), nil
+}
@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestListToolsJSONFormat(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk", Archetype: "desk_lamp"}, On: client.OnState{On: true}, Dimming: client.Dimming{Brightness: 40}})
+	fb.Add("light", client.Light{ID: "light-2", Metadata: client.Metadata{Name: "Floor"}})
+	fb.Add("room", client.Room{
+		ID:       "room-1",
+		Metadata: client.Metadata{Name: "Study"},
+		Services: []client.ResourceIdentifier{{RID: "group-1", RType: "grouped_light"}},
+		Children: []client.ResourceIdentifier{{RID: "device-1", RType: "device"}},
+	})
+	fb.Add("device", client.Device{ID: "device-1", Services: []client.ResourceIdentifier{{RID: "light-1", RType: "light"}}})
+	fb.Add("grouped_light", client.Group{ID: "group-1", Owner: &client.ResourceIdentifier{RID: "room-1", RType: "room"}})
+	
+	var lights []listItem
+	text := resultText(callTool(t, HandleListLights(fb.client()), map[string]interface{}{"format": "json"}))
+	if err := json.Unmarshal([]byte(text), &lights); err != nil {
+		t.Fatalf("list_lights did not return JSON: %v\n%s", err, text)
+	}
+	if len(lights) != 2 || lights[0].Name != "Desk" || !*lights[0].On || *lights[0].Brightness != 40 || *lights[1].On {
+		t.Errorf("Unexpected lights: %s", text)
+	}
+	
+	var groups []listItem
+	text = resultText(callTool(t, HandleListGroups(fb.client()), map[string]interface{}{"format": "json"}))
+	if err := json.Unmarshal([]byte(text), &groups); err != nil {
+		t.Fatalf("list_groups did not return JSON: %v\n%s", err, text)
+	}
+	if len(groups) != 1 || groups[0].Name != "Study" || groups[0].Type != "room" {
+		t.Errorf("Expected the group to be named after its room, got %s", text)
+	}
+	
+	var rooms []listItem
+	text = resultText(callTool(t, HandleListRooms(fb.client()), map[string]interface{}{"format": "json"}))
+	if err := json.Unmarshal([]byte(text), &rooms); err != nil {
+		t.Fatalf("list_rooms did not return JSON: %v\n%s", err, text)
+	}
+	if len(rooms) != 1 || strings.Join(rooms[0].Lights, ",") != "light-1" {
+		t.Errorf("Expected the room's device to be expanded to its light, got %s", text)
+	}
+	
+	text = resultText(callTool(t, HandleListScenes(fb.client()), map[string]interface{}{"format": "json"}))
+	if text != "[]" {
+		t.Errorf("Expected an empty JSON array for no scenes, got %s", text)
+	}
+	
+	if result := callTool(t, HandleListZones(fb.client()), map[string]interface{}{"format": "yaml"}); !result.IsError {
+		t.Error("Expected an unknown format to be rejected")
+	}
+}
+
+func TestListLightsPaging(t *testing.T) {
+	fb := newFakeBridge(t)
+	// Added out of order, with two lights sharing a name, so pages must come from a sorted list
+	for _, light := range [][2]string{{"light-5", "Porch Lamp"}, {"light-3", "Desk Lamp"}, {"light-4", "Hall"}, {"light-2", "ceiling"}, {"light-1", "Desk Lamp"}} {
+		fb.Add("light", client.Light{ID: light[0], Metadata: client.Metadata{Name: light[1]}})
+	}
+	handler := HandleListLights(fb.client())
+	
+	text := resultText(callTool(t, handler, map[string]interface{}{"limit": 2.0}))
+	for _, expected := range []string{"Showing 1-2 of 5 lights:", "ceiling", "light-1", "3 more; use offset=2"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "light-3") {
+		t.Errorf("Expected the second Desk Lamp to be on the next page:\n%s", text)
+	}
+	
+	text = resultText(callTool(t, handler, map[string]interface{}{"offset": 2.0, "limit": 2.0}))
+	if !strings.Contains(text, "light-3") || !strings.Contains(text, "Hall") || strings.Contains(text, "light-1") {
+		t.Errorf("Expected the next page to carry on from the first:\n%s", text)
+	}
+	
+	text = resultText(callTool(t, handler, map[string]interface{}{"name_filter": "lamp", "offset": 2.0}))
+	if !strings.Contains(text, "Showing 3-3 of 3 lights matching 'lamp'") || !strings.Contains(text, "Porch Lamp") || strings.Contains(text, "more;") {
+		t.Errorf("Unexpected last page:\n%s", text)
+	}
+	
+	var page struct {
+		Total  int        `json:"total"`
+		Offset int        `json:"offset"`
+		Items  []listItem `json:"items"`
+	}
+	text = resultText(callTool(t, handler, map[string]interface{}{"format": "json", "offset": 4.0, "limit": 10.0}))
+	if err := json.Unmarshal([]byte(text), &page); err != nil {
+		t.Fatalf("Expected a JSON page: %v\n%s", err, text)
+	}
+	if page.Total != 5 || page.Offset != 4 || len(page.Items) != 1 || page.Items[0].Name != "Porch Lamp" {
+		t.Errorf("Unexpected JSON page: %s", text)
+	}
+	
+	if result := callTool(t, handler, map[string]interface{}{"limit": 0.0}); !result.IsError {
+		t.Error("Expected a zero limit to be rejected")
+	}
+}
@@ -0,0 +1,210 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GlobalSnapshotStore keeps named whole-home snapshots, persisted to disk so they survive restarts
+type GlobalSnapshotStore struct {
+	snapshots map[string]client.Snapshot
+	path      string
+	mu        sync.RWMutex
+}
+
+var (
+	globalSnapshots     *GlobalSnapshotStore
+	globalSnapshotsOnce sync.Once
+)
+
+// GetGlobalSnapshotStore returns the global snapshot store, loading it from disk on first use
+func GetGlobalSnapshotStore() *GlobalSnapshotStore {
+	globalSnapshotsOnce.Do(func() {
+		path, err := dataFile("global_snapshots.json")
+		if err != nil {
+			log.Printf("Global snapshots will not be persisted: %v", err)
+		}
+		globalSnapshots = newGlobalSnapshotStore(path)
+	})
+	return globalSnapshots
+}
+
+// newGlobalSnapshotStore creates a store backed by the given file
+func newGlobalSnapshotStore(path string) *GlobalSnapshotStore {
+	store := &GlobalSnapshotStore{
+		snapshots: make(map[string]client.Snapshot),
+		path:      path,
+	}
+
+	if path != "" {
+		var snapshots []client.Snapshot
+		if err := loadJSONFile(path, &snapshots); err != nil {
+			log.Printf("Failed to load global snapshots from %s: %v", path, err)
+		}
+		for _, snapshot := range snapshots {
+			store.snapshots[snapshot.Name] = snapshot
+		}
+	}
+
+	return store
+}
+
+// Save stores a snapshot under its name, replacing any snapshot with the same name
+func (gs *GlobalSnapshotStore) Save(snapshot client.Snapshot) error {
+	if snapshot.Name == "" {
+		return fmt.Errorf("snapshot name cannot be empty")
+	}
+
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	gs.snapshots[snapshot.Name] = snapshot
+	return gs.persist()
+}
+
+// Get returns a snapshot by name
+func (gs *GlobalSnapshotStore) Get(name string) (client.Snapshot, error) {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	snapshot, exists := gs.snapshots[name]
+	if !exists {
+		return client.Snapshot{}, fmt.Errorf("global snapshot '%s' not found", name)
+	}
+	return snapshot, nil
+}
+
+// List returns all snapshots sorted by name
+func (gs *GlobalSnapshotStore) List() []client.Snapshot {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	return gs.sorted()
+}
+
+// sorted returns the snapshots ordered by name; callers must hold the lock
+func (gs *GlobalSnapshotStore) sorted() []client.Snapshot {
+	snapshots := make([]client.Snapshot, 0, len(gs.snapshots))
+	for _, snapshot := range gs.snapshots {
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Name < snapshots[j].Name
+	})
+	return snapshots
+}
+
+// persist writes the snapshots to disk; callers must hold the lock
+func (gs *GlobalSnapshotStore) persist() error {
+	if gs.path == "" {
+		return nil
+	}
+	if err := saveJSONFile(gs.path, gs.sorted()); err != nil {
+		return fmt.Errorf("failed to save global snapshots: %w", err)
+	}
+	return nil
+}
+
+// HandleGlobalSnapshot captures the state of every light into a named, persisted snapshot
+func HandleGlobalSnapshot(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, _ := request.GetArguments()["name"].(string)
+		if name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+
+		lights, err := hueClient.GetLights(ctx)
+		if err != nil {
+			return toolError("Failed to list lights", err), nil
+		}
+		if len(lights) == 0 {
+			return mcp.NewToolResultError("No lights to snapshot"), nil
+		}
+		lightIDs := make([]string, len(lights))
+		for i, light := range lights {
+			lightIDs[i] = light.ID
+		}
+
+		snapshot, err := hueClient.CaptureStates(ctx, lightIDs)
+		if err != nil {
+			return toolError("Failed to capture light states", err), nil
+		}
+		snapshot.Name = name
+		if err := GetGlobalSnapshotStore().Save(snapshot); err != nil {
+			return toolError("Failed to save global snapshot", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Global snapshot '%s' saved with %d lights\nUse global_restore to return to it", name, len(snapshot.Lights))), nil
+	}
+}
+
+// HandleGlobalRestore reapplies a named global snapshot, reporting each light that failed
+func HandleGlobalRestore(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, _ := request.GetArguments()["name"].(string)
+		if name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+
+		snapshot, err := GetGlobalSnapshotStore().Get(name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		errs := hueClient.ApplyStatesEach(ctx, snapshot)
+		restored := fmt.Sprintf("Restored global snapshot '%s' (%d of %d lights, captured %s)",
+			name, len(snapshot.Lights)-len(errs), len(snapshot.Lights), snapshot.CapturedAt.Format("2006-01-02 15:04"))
+		if len(errs) == 0 {
+			return mcp.NewToolResultText(restored), nil
+		}
+
+		var result strings.Builder
+		result.WriteString(restored)
+		result.WriteString(fmt.Sprintf("\nFailed on %d lights:\n", len(errs)))
+		for _, state := range snapshot.Lights {
+			if err, failed := errs[state.LightID]; failed {
+				label := state.Name
+				if label == "" {
+					label = state.LightID
+				}
+				result.WriteString(fmt.Sprintf("- %s (%s): %v\n", label, state.LightID, err))
+			}
+		}
+		if len(errs) == len(snapshot.Lights) {
+			return mcp.NewToolResultError(result.String()), nil
+		}
+		return mcp.NewToolResultText(result.String()), nil
+	}
+}
+
+// HandleListGlobalSnapshots lists the saved global snapshots
+func HandleListGlobalSnapshots(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		snapshots := GetGlobalSnapshotStore().List()
+		if len(snapshots) == 0 {
+			return mcp.NewToolResultText("No global snapshots saved. Use global_snapshot to save one."), nil
+		}
+
+		var result strings.Builder
+		result.WriteString(fmt.Sprintf("%d global snapshots:\n", len(snapshots)))
+		for _, snapshot := range snapshots {
+			on := 0
+			for _, state := range snapshot.Lights {
+				if state.On {
+					on++
+				}
+			}
+			result.WriteString(fmt.Sprintf("- %s: %d lights (%d on), captured %s\n",
+				snapshot.Name, len(snapshot.Lights), on, snapshot.CapturedAt.Format("2006-01-02 15:04")))
+		}
+		return mcp.NewToolResultText(result.String()), nil
+	}
+}
@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestGlobalSnapshotStorePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "global_snapshots.json")
+
+	store := newGlobalSnapshotStore(path)
+	if err := store.Save(client.Snapshot{Name: "party", Lights: []client.LightState{{LightID: "light-1", On: true}}}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := store.Save(client.Snapshot{}); err == nil {
+		t.Error("Expected a snapshot without a name to be rejected")
+	}
+
+	reloaded := newGlobalSnapshotStore(path)
+	snapshot, err := reloaded.Get("party")
+	if err != nil {
+		t.Fatalf("Expected the snapshot to survive a reload: %v", err)
+	}
+	if len(snapshot.Lights) != 1 || snapshot.Lights[0].LightID != "light-1" {
+		t.Errorf("Expected the saved lights back, got %+v", snapshot.Lights)
+	}
+}
+
+func TestGlobalRestoreReportsFailedLights(t *testing.T) {
+	t.Setenv("HUE_MCP_DATA_DIR", t.TempDir())
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk"}, On: client.OnState{On: true}, Dimming: client.Dimming{Brightness: 30}})
+	fb.Add("light", client.Light{ID: "light-2", Metadata: client.Metadata{Name: "Porch"}})
+
+	result := callTool(t, HandleGlobalSnapshot(fb.client()), map[string]interface{}{"name": "before-party"})
+	if result.IsError {
+		t.Fatalf("global_snapshot failed: %s", resultText(result))
+	}
+
+	result = callTool(t, HandleListGlobalSnapshots(fb.client()), nil)
+	if !strings.Contains(resultText(result), "before-party: 2 lights (1 on)") {
+		t.Errorf("Expected the snapshot to be listed, got:\n%s", resultText(result))
+	}
+
+	fb.Fail("PUT", "/clip/v2/resource/light/light-2")
+	result = callTool(t, HandleGlobalRestore(fb.client()), map[string]interface{}{"name": "before-party"})
+	if result.IsError {
+		t.Fatalf("Expected a partial restore to succeed, got: %s", resultText(result))
+	}
+	text := resultText(result)
+	if !strings.Contains(text, "1 of 2 lights") || !strings.Contains(text, "- Porch (light-2):") {
+		t.Errorf("Expected the failed light to be reported, got:\n%s", text)
+	}
+}
@@ -0,0 +1,389 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Macro step types
+const (
+	MacroStepScene       = "scene"        // Activate a native scene
+	MacroStepCachedScene = "cached_scene" // Recall a cached scene
+	MacroStepEffect      = "effect"       // Apply a native effect to a light or group
+	MacroStepCommand     = "command"      // Run a single batch command
+	MacroStepDelay       = "delay"        // Wait before the next step
+	MacroStepAllOff      = "all_off"      // Turn every light off
+)
+
+// MacroStep is a single step in a macro
+type MacroStep struct {
+	Type     string `json:"type"`
+	Target   string `json:"target,omitempty"`
+	Action   string `json:"action,omitempty"`   // Batch action for command steps
+	Value    string `json:"value,omitempty"`    // Effect name or command value
+	Duration int    `json:"duration,omitempty"` // Effect duration in seconds
+	DelayMs  int    `json:"delay_ms,omitempty"` // Wait time for delay steps
+}
+
+// Macro is a named, ordered list of steps
+type Macro struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Steps       []MacroStep `json:"steps"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// MacroStore manages macros persisted to disk
+type MacroStore struct {
+	macros map[string]*Macro
+	path   string
+	mu     sync.RWMutex
+}
+
+var (
+	globalMacroStore *MacroStore
+	macroStoreOnce   sync.Once
+)
+
+// GetMacroStore returns the global macro store, loading it from disk on first use
+func GetMacroStore() *MacroStore {
+	macroStoreOnce.Do(func() {
+		path, err := dataFile("macros.json")
+		if err != nil {
+			log.Printf("Macros will not be persisted: %v", err)
+		}
+		globalMacroStore = newMacroStore(path)
+	})
+	return globalMacroStore
+}
+
+// newMacroStore creates a store backed by the given file
+func newMacroStore(path string) *MacroStore {
+	store := &MacroStore{
+		macros: make(map[string]*Macro),
+		path:   path,
+	}
+
+	if path != "" {
+		var macros []*Macro
+		if err := loadJSONFile(path, &macros); err != nil {
+			log.Printf("Failed to load macros from %s: %v", path, err)
+		}
+		for _, macro := range macros {
+			store.macros[macro.Name] = macro
+		}
+	}
+
+	return store
+}
+
+// SaveMacro validates and stores a macro
+func (ms *MacroStore) SaveMacro(macro *Macro) error {
+	if macro.Name == "" {
+		return fmt.Errorf("macro name cannot be empty")
+	}
+	if err := validateMacroSteps(macro.Steps); err != nil {
+		return err
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if macro.CreatedAt.IsZero() {
+		macro.CreatedAt = time.Now()
+	}
+	ms.macros[macro.Name] = macro
+
+	return ms.persist()
+}
+
+// GetMacro returns a macro by name
+func (ms *MacroStore) GetMacro(name string) (*Macro, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	macro, exists := ms.macros[name]
+	if !exists {
+		return nil, fmt.Errorf("macro '%s' not found", name)
+	}
+	return macro, nil
+}
+
+// ListMacros returns all macros sorted by name
+func (ms *MacroStore) ListMacros() []*Macro {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	macros := make([]*Macro, 0, len(ms.macros))
+	for _, macro := range ms.macros {
+		macros = append(macros, macro)
+	}
+	sort.Slice(macros, func(i, j int) bool {
+		return macros[i].Name < macros[j].Name
+	})
+	return macros
+}
+
+// DeleteMacro removes a macro
+func (ms *MacroStore) DeleteMacro(name string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, exists := ms.macros[name]; !exists {
+		return fmt.Errorf("macro '%s' not found", name)
+	}
+	delete(ms.macros, name)
+
+	return ms.persist()
+}
+
+// persist writes the macros to disk; callers must hold the lock
+func (ms *MacroStore) persist() error {
+	if ms.path == "" {
+		return nil
+	}
+
+	macros := make([]*Macro, 0, len(ms.macros))
+	for _, macro := range ms.macros {
+		macros = append(macros, macro)
+	}
+	sort.Slice(macros, func(i, j int) bool {
+		return macros[i].Name < macros[j].Name
+	})
+
+	if err := saveJSONFile(ms.path, macros); err != nil {
+		return fmt.Errorf("failed to save macros: %w", err)
+	}
+	return nil
+}
+
+// validateMacroSteps checks each step has what it needs to run
+func validateMacroSteps(steps []MacroStep) error {
+	if len(steps) == 0 {
+		return fmt.Errorf("macro must have at least one step")
+	}
+
+	for i, step := range steps {
+		switch step.Type {
+		case MacroStepScene, MacroStepCachedScene:
+			if step.Target == "" {
+				return fmt.Errorf("step %d (%s): target is required", i, step.Type)
+			}
+		case MacroStepEffect:
+			if step.Target == "" || step.Value == "" {
+				return fmt.Errorf("step %d (effect): target and value are required", i)
+			}
+		case MacroStepCommand:
+			if step.Action == "" {
+				return fmt.Errorf("step %d (command): action is required", i)
+			}
+		case MacroStepDelay:
+			if step.DelayMs <= 0 {
+				return fmt.Errorf("step %d (delay): delay_ms must be positive", i)
+			}
+		case MacroStepAllOff:
+		default:
+			return fmt.Errorf("step %d: unknown step type '%s'", i, step.Type)
+		}
+	}
+
+	return nil
+}
+
+// runMacro executes a macro's steps in order, stopping at the first failure
+func runMacro(ctx context.Context, hueClient *client.Client, macro *Macro) ([]string, error) {
+	var results []string
+
+	for i, step := range macro.Steps {
+		select {
+		case <-ctx.Done():
+			return results, ctx.Err()
+		default:
+		}
+
+		result, err := runMacroStep(ctx, hueClient, step)
+		if err != nil {
+			return results, fmt.Errorf("step %d (%s) failed: %w", i, step.Type, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func runMacroStep(ctx context.Context, hueClient *client.Client, step MacroStep) (string, error) {
+	switch step.Type {
+	case MacroStepScene:
+		sceneID, err := resolveSceneID(ctx, hueClient, step.Target)
+		if err != nil {
+			return "", err
+		}
+		if err := hueClient.ActivateScene(ctx, sceneID); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Scene %s activated", step.Target), nil
+
+	case MacroStepCachedScene:
+		scene, err := globalSceneCache.GetScene(step.Target)
+		if err != nil {
+			return "", err
+		}
+		for _, result := range ExecuteBatch(ctx, hueClient, scene.Commands, scene.DelayMs) {
+			if !result.Success {
+				return "", result.Error
+			}
+		}
+		return fmt.Sprintf("Cached scene %s recalled", step.Target), nil
+
+	case MacroStepEffect:
+		isGroup, err := isGroupTarget(ctx, hueClient, step.Target)
+		if err != nil {
+			return "", err
+		}
+		action := "light_effect"
+		if isGroup {
+			action = "group_effect"
+		}
+		return executeBatchCommand(ctx, hueClient, action, step.Target, step.Value, step.Duration)
+
+	case MacroStepCommand:
+		return executeBatchCommand(ctx, hueClient, step.Action, step.Target, step.Value, step.Duration)
+
+	case MacroStepDelay:
+		select {
+		case <-time.After(time.Duration(step.DelayMs) * time.Millisecond):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		return fmt.Sprintf("Waited %dms", step.DelayMs), nil
+
+	case MacroStepAllOff:
+		if _, err := hueClient.TurnOffAllLights(ctx); err != nil {
+			return "", err
+		}
+		return "All lights turned off", nil
+
+	default:
+		return "", fmt.Errorf("unknown step type '%s'", step.Type)
+	}
+}
+
+// HandleDefineMacro returns a handler for creating or replacing a macro
+func HandleDefineMacro(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		name, ok := args["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+
+		stepsJSON, ok := args["steps"].(string)
+		if !ok || stepsJSON == "" {
+			return mcp.NewToolResultError("steps JSON is required"), nil
+		}
+
+		var steps []MacroStep
+		if err := json.Unmarshal([]byte(stepsJSON), &steps); err != nil {
+			return toolError("Failed to parse steps JSON", err), nil
+		}
+
+		description, _ := args["description"].(string)
+
+		macro := &Macro{Name: name, Description: description, Steps: steps}
+		if err := GetMacroStore().SaveMacro(macro); err != nil {
+			return toolError("Failed to save macro", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Macro '%s' saved with %d steps", name, len(steps))), nil
+	}
+}
+
+// HandleRunMacro returns a handler for running a macro
+func HandleRunMacro(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		name, ok := args["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+
+		macro, err := GetMacroStore().GetMacro(name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		wait, _ := args["wait"].(bool)
+		if !wait {
+			go func() {
+				if _, err := runMacro(context.Background(), hueClient, macro); err != nil {
+					log.Printf("Macro %s failed: %v", macro.Name, err)
+				} else {
+					log.Printf("Macro %s completed", macro.Name)
+				}
+			}()
+			return mcp.NewToolResultText(fmt.Sprintf("Running macro '%s' (%d steps) in the background", macro.Name, len(macro.Steps))), nil
+		}
+
+		results, err := runMacro(ctx, hueClient, macro)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Macro '%s' failed: %v\nCompleted steps:\n%s", macro.Name, err, strings.Join(results, "\n"))), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Macro '%s' completed:\n%s", macro.Name, strings.Join(results, "\n"))), nil
+	}
+}
+
+// HandleListMacros returns a handler for listing macros
+func HandleListMacros(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		macros := GetMacroStore().ListMacros()
+		if len(macros) == 0 {
+			return mcp.NewToolResultText("No macros defined"), nil
+		}
+
+		var result strings.Builder
+		result.WriteString(fmt.Sprintf("Found %d macros:\n", len(macros)))
+		for _, macro := range macros {
+			var stepTypes []string
+			for _, step := range macro.Steps {
+				stepTypes = append(stepTypes, step.Type)
+			}
+			result.WriteString(fmt.Sprintf("- %s: %s\n", macro.Name, strings.Join(stepTypes, " → ")))
+			if macro.Description != "" {
+				result.WriteString(fmt.Sprintf("  %s\n", macro.Description))
+			}
+		}
+
+		return mcp.NewToolResultText(result.String()), nil
+	}
+}
+
+// HandleDeleteMacro returns a handler for deleting a macro
+func HandleDeleteMacro(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		name, ok := args["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+
+		if err := GetMacroStore().DeleteMacro(name); err != nil {
+			return toolError("Failed to delete macro", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Macro '%s' deleted", name)), nil
+	}
+}
@@ -0,0 +1,121 @@
+package mcp
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestRunMacroStepsInOrderWithDelays(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Bedside"}})
+	fb.Add("grouped_light", client.Group{ID: "group-home", Owner: &client.ResourceIdentifier{RID: "home", RType: "bridge_home"}})
+	fb.Add("scene", client.Scene{ID: "scene-1", Metadata: client.Metadata{Name: "Nightlight"}})
+	
+	macro := &Macro{
+		Name: "goodnight",
+		Steps: []MacroStep{
+			{Type: MacroStepScene, Target: "Nightlight"},
+			{Type: MacroStepDelay, DelayMs: 100},
+			{Type: MacroStepCommand, Action: "light_brightness", Target: "light-1", Value: "10"},
+			{Type: MacroStepEffect, Target: "light-1", Value: "candle"},
+			{Type: MacroStepAllOff},
+		},
+	}
+	
+	results, err := runMacro(context.Background(), fb.client(), macro)
+	if err != nil {
+		t.Fatalf("runMacro failed: %v", err)
+	}
+	
+	if len(results) != len(macro.Steps) {
+		t.Errorf("Expected %d step results, got %d", len(macro.Steps), len(results))
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/")
+	
+	expected := []string{
+		"/clip/v2/resource/scene/scene-1",
+		"/clip/v2/resource/light/light-1",
+		"/clip/v2/resource/light/light-1",
+		"/clip/v2/resource/grouped_light/group-home",
+	}
+	if len(puts) != len(expected) {
+		t.Fatalf("Expected %d updates, got %d", len(expected), len(puts))
+	}
+	for i, path := range expected {
+		if puts[i].Path != path {
+			t.Errorf("Update %d: expected %s, got %s", i, path, puts[i].Path)
+		}
+	}
+	
+	if puts[2].Body["effects"] == nil {
+		t.Errorf("Expected effect update on light-1, got %v", puts[2].Body)
+	}
+	
+	if gap := puts[1].Time.Sub(puts[0].Time); gap < 100*time.Millisecond {
+		t.Errorf("Expected at least 100ms between scene and brightness, got %v", gap)
+	}
+}
+
+func TestRunMacroStopsOnFailure(t *testing.T) {
+	fb := newFakeBridge(t)
+	
+	macro := &Macro{
+		Name: "broken",
+		Steps: []MacroStep{
+			{Type: MacroStepScene, Target: "Missing Scene"},
+			{Type: MacroStepAllOff},
+		},
+	}
+	
+	_, err := runMacro(context.Background(), fb.client(), macro)
+	if err == nil {
+		t.Fatal("Expected failure for missing scene")
+	}
+	
+	if len(fb.RequestsFor("PUT", "/clip/v2/resource/")) != 0 {
+		t.Error("Expected no updates after a failed step")
+	}
+}
+
+func TestMacroStorePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "macros.json")
+	
+	store := newMacroStore(path)
+	err := store.SaveMacro(&Macro{
+		Name:  "morning",
+		Steps: []MacroStep{{Type: MacroStepDelay, DelayMs: 10}, {Type: MacroStepAllOff}},
+	})
+	if err != nil {
+		t.Fatalf("SaveMacro failed: %v", err)
+	}
+	
+	reloaded := newMacroStore(path)
+	macro, err := reloaded.GetMacro("morning")
+	if err != nil {
+		t.Fatalf("Expected macro to survive reload: %v", err)
+	}
+	if len(macro.Steps) != 2 || macro.Steps[0].DelayMs != 10 {
+		t.Errorf("Unexpected reloaded steps: %+v", macro.Steps)
+	}
+	
+	if err := reloaded.DeleteMacro("morning"); err != nil {
+		t.Fatalf("DeleteMacro failed: %v", err)
+	}
+	if len(newMacroStore(path).ListMacros()) != 0 {
+		t.Error("Expected deletion to be persisted")
+	}
+}
+
+func TestMacroStoreRejectsInvalidSteps(t *testing.T) {
+	store := newMacroStore("")
+	
+	err := store.SaveMacro(&Macro{Name: "bad", Steps: []MacroStep{{Type: "teleport"}}})
+	if err == nil {
+		t.Error("Expected unknown step type to be rejected")
+	}
+}
@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/kungfusheep/hue/effects"
 	"github.com/kungfusheep/hue/client"
+	"github.com/kungfusheep/hue/scheduler"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -28,7 +32,7 @@ func HandleLightOn(hueClient *client.Client) server.ToolHandlerFunc {
 
 		err := hueClient.TurnOnLight(ctx, lightID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to turn on light: %v", err)), nil
+			return toolError("Failed to turn on light", err), nil
 		}
 
 		return mcp.NewToolResultText(fmt.Sprintf("Light %s turned on", lightID)), nil
@@ -46,7 +50,7 @@ func HandleLightOff(hueClient *client.Client) server.ToolHandlerFunc {
 
 		err := hueClient.TurnOffLight(ctx, lightID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to turn off light: %v", err)), nil
+			return toolError("Failed to turn off light", err), nil
 		}
 
 		return mcp.NewToolResultText(fmt.Sprintf("Light %s turned off", lightID)), nil
@@ -71,11 +75,28 @@ func HandleLightBrightness(hueClient *client.Client) server.ToolHandlerFunc {
 			return mcp.NewToolResultError("brightness must be between 0 and 100"), nil
 		}
 
-		err := hueClient.SetLightBrightness(ctx, lightID, brightness)
+		if err := checkLightCapabilities(ctx, hueClient, lightID, true, false, false); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		offBelowMin, _ := args["off_below_min"].(bool)
+		adjusted, turnOff, note := fitMinDimLevel(ctx, hueClient, lightID, brightness, offBelowMin)
+
+		if turnOff {
+			if err := hueClient.TurnOffLight(ctx, lightID); err != nil {
+				return toolError("Failed to turn off light", err), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Light %s turned off (%s)", lightID, note)), nil
+		}
+
+		err := hueClient.SetLightBrightness(ctx, lightID, adjusted)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to set brightness: %v", err)), nil
+			return toolError("Failed to set brightness", err), nil
 		}
 
+		if note != "" {
+			return mcp.NewToolResultText(fmt.Sprintf("Light %s brightness set to %.1f%% (%s)", lightID, adjusted, note)), nil
+		}
 		return mcp.NewToolResultText(fmt.Sprintf("Light %s brightness set to %.0f%%", lightID, brightness)), nil
 	}
 }
@@ -89,25 +110,21 @@ func HandleLightColor(hueClient *client.Client) server.ToolHandlerFunc {
 			return mcp.NewToolResultError("light_id is required"), nil
 		}
 
-		color, ok := args["color"].(string)
-		if !ok {
-			return mcp.NewToolResultError("color is required"), nil
+		xy, hexColor, color, err := colorArgXY(args)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Handle named colors
-		hexColor := namedColorToHex(color)
-		if hexColor == "" {
-			hexColor = color
+		if err := checkLightCapabilities(ctx, hueClient, lightID, false, true, false); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Validate hex color
-		if !isValidHexColor(hexColor) {
-			return mcp.NewToolResultError("Invalid color format. Use hex code (#RRGGBB) or color name"), nil
+		if hexColor != "" {
+			xy = hueClient.LightColorXY(ctx, lightID, hexColor)
 		}
-
-		err := hueClient.SetLightColor(ctx, lightID, hexColor)
+		err = hueClient.SetLightColorXY(ctx, lightID, xy)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to set color: %v", err)), nil
+			return toolError("Failed to set color", err), nil
 		}
 
 		return mcp.NewToolResultText(fmt.Sprintf("Light %s color set to %s", lightID, color)), nil
@@ -136,21 +153,132 @@ func HandleLightEffect(hueClient *client.Client) server.ToolHandlerFunc {
 			duration = int(d)
 		}
 
-		err := hueClient.SetLightEffect(ctx, lightID, effect, duration)
+		params, err := effectParameters(args)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to set effect: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// duration is how long the effect runs, enforced by the scheduled stop below. It is
+		// not passed on, as the bridge would treat it as a transition time.
+		err = hueClient.SetLightEffectWithParameters(ctx, lightID, effect, 0, params)
+		if err != nil {
+			return toolError("Failed to set effect", err), nil
 		}
 
 		desc := effects.GetDescription(effect)
 		result := fmt.Sprintf("Light %s effect set to %s - %s", lightID, effect, desc)
 		if duration > 0 {
-			result += fmt.Sprintf(" (duration: %d seconds)", duration)
+			stop, err := scheduleEffectStop("light", duration, lightID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s, but failed to schedule it to stop: %v", result, err)), nil
+			}
+			result += effectStopNote(duration, stop)
 		}
 
 		return mcp.NewToolResultText(result), nil
 	}
 }
 
+// effectParameters reads the optional speed and color that tune an effect, returning nil
+// when neither is given
+func effectParameters(args map[string]interface{}) (*client.EffectParameters, error) {
+	var params client.EffectParameters
+	given := false
+	
+	if speed, ok := args["speed"].(float64); ok {
+		if speed < 0 || speed > 1 {
+			return nil, fmt.Errorf("speed must be between 0 and 1")
+		}
+		params.Speed = &speed
+		given = true
+	}
+	
+	if color, ok := args["color"].(string); ok && color != "" {
+		hex, err := parseColor(color)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color: %v", err)
+		}
+		x, y := client.HexToXY(hex)
+		params.Color = &client.Color{XY: client.XY{X: x, Y: y}}
+		given = true
+	}
+	
+	if !given {
+		return nil, nil
+	}
+	return &params, nil
+}
+
+// scheduleEffectStop clears the effect on the targets once duration seconds have passed.
+// The bridge only treats an effect's duration as a transition, so effects never end on their own.
+func scheduleEffectStop(targetType string, duration int, targetIDs ...string) (string, error) {
+	if globalScheduler == nil {
+		return "", fmt.Errorf("scheduler not initialized")
+	}
+	stopAt := time.Now().Add(time.Duration(duration) * time.Second)
+	return globalScheduler.ScheduleAt(scheduler.CreateEffectStop(targetType, targetIDs...), stopAt)
+}
+
+// effectStopNote tells the user when an effect ends and how to keep it running
+func effectStopNote(duration int, scheduleID string) string {
+	return fmt.Sprintf(" (stops after %d seconds)\nStop schedule: %s - cancel it with cancel_schedule to keep the effect running", duration, scheduleID)
+}
+
+// HandleListEffects returns a handler listing the effects one light, or any light, supports
+func HandleListEffects(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		lightID, _ := args["light_id"].(string)
+		if lightID == "" {
+			supported, err := hueClient.GetAllSupportedEffects(ctx)
+			if err != nil {
+				return toolError("Failed to get effects", err), nil
+			}
+			sort.Strings(supported)
+
+			var result strings.Builder
+			result.WriteString(fmt.Sprintf("Effects supported by at least one light (%d):\n", len(supported)))
+			for _, effect := range supported {
+				result.WriteString(fmt.Sprintf("- %s: %s\n", effect, effects.GetDescription(effect)))
+			}
+			result.WriteString("\nPass light_id to see what a specific light supports")
+			return mcp.NewToolResultText(result.String()), nil
+		}
+
+		resolvedID, err := resolveLightID(ctx, hueClient, lightID)
+		if err != nil {
+			return toolError("Failed to resolve light", err), nil
+		}
+
+		light, err := hueClient.GetLight(ctx, resolvedID)
+		if err != nil {
+			return toolError("Failed to get light", err), nil
+		}
+
+		if light.Effects == nil || len(light.Effects.EffectValues) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("%s (ID: %s) does not support effects", light.Metadata.Name, light.ID)), nil
+		}
+
+		active := light.Effects.Status
+		if active == "" {
+			active = light.Effects.Effect
+		}
+		if active == "" {
+			active = effects.None
+		}
+
+		var result strings.Builder
+		result.WriteString(fmt.Sprintf("Effects for %s (ID: %s):\n", light.Metadata.Name, light.ID))
+		result.WriteString(fmt.Sprintf("Active effect: %s\n", active))
+		for _, effect := range light.Effects.EffectValues {
+			result.WriteString(fmt.Sprintf("- %s: %s\n", effect, effects.GetDescription(effect)))
+		}
+
+		return mcp.NewToolResultText(result.String()), nil
+	}
+}
+
 // Group control handlers
 
 // HandleGroupOn returns a handler for turning a group on
@@ -164,7 +292,7 @@ func HandleGroupOn(hueClient *client.Client) server.ToolHandlerFunc {
 
 		err := hueClient.TurnOnGroup(ctx, groupID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to turn on group: %v", err)), nil
+			return toolError("Failed to turn on group", err), nil
 		}
 
 		return mcp.NewToolResultText(fmt.Sprintf("Group %s turned on", groupID)), nil
@@ -182,13 +310,45 @@ func HandleGroupOff(hueClient *client.Client) server.ToolHandlerFunc {
 
 		err := hueClient.TurnOffGroup(ctx, groupID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to turn off group: %v", err)), nil
+			return toolError("Failed to turn off group", err), nil
 		}
 
 		return mcp.NewToolResultText(fmt.Sprintf("Group %s turned off", groupID)), nil
 	}
 }
 
+// HandleAllLightsOff returns a handler for turning off every light
+func HandleAllLightsOff(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		count, err := hueClient.TurnOffAllLights(ctx)
+		if err != nil {
+			return toolError("Failed to turn off all lights", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("All lights turned off (%s)", describeGroupCount(count))), nil
+	}
+}
+
+// HandleAllLightsOn returns a handler for turning on every light
+func HandleAllLightsOn(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		count, err := hueClient.TurnOnAllLights(ctx)
+		if err != nil {
+			return toolError("Failed to turn on all lights", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("All lights turned on (%s)", describeGroupCount(count))), nil
+	}
+}
+
+// describeGroupCount phrases how many groups an all-lights command touched
+func describeGroupCount(count int) string {
+	if count == 1 {
+		return "1 group"
+	}
+	return fmt.Sprintf("%d groups", count)
+}
+
 // HandleGroupBrightness returns a handler for setting group brightness
 func HandleGroupBrightness(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -209,7 +369,7 @@ func HandleGroupBrightness(hueClient *client.Client) server.ToolHandlerFunc {
 
 		err := hueClient.SetGroupBrightness(ctx, groupID, brightness)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to set brightness: %v", err)), nil
+			return toolError("Failed to set brightness", err), nil
 		}
 
 		return mcp.NewToolResultText(fmt.Sprintf("Group %s brightness set to %.0f%%", groupID, brightness)), nil
@@ -225,31 +385,85 @@ func HandleGroupColor(hueClient *client.Client) server.ToolHandlerFunc {
 			return mcp.NewToolResultError("group_id is required"), nil
 		}
 
-		color, ok := args["color"].(string)
-		if !ok {
-			return mcp.NewToolResultError("color is required"), nil
+		xy, hexColor, color, err := colorArgXY(args)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Handle named colors
-		hexColor := namedColorToHex(color)
-		if hexColor == "" {
-			hexColor = color
+		onlyOn, _ := args["only_on"].(bool)
+		if perLight, _ := args["per_light"].(bool); perLight {
+			return groupColorPerLight(ctx, hueClient, groupID, args, onlyOn), nil
 		}
 
-		// Validate hex color
-		if !isValidHexColor(hexColor) {
-			return mcp.NewToolResultError("Invalid color format. Use hex code (#RRGGBB) or color name"), nil
+		// Leave lights that are off alone if asked
+		if onlyOn {
+			lightIDs, err := targetOnLights(ctx, hueClient, groupID, true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, lightID := range lightIDs {
+				lightXY := xy
+				if hexColor != "" {
+					lightXY = hueClient.LightColorXY(ctx, lightID, hexColor)
+				}
+				if err := hueClient.SetLightColorXY(ctx, lightID, lightXY); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to set color on light %s: %v", lightID, err)), nil
+				}
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Group %s color set to %s on %d lights that were on", groupID, color, len(lightIDs))), nil
 		}
 
-		err := hueClient.SetGroupColor(ctx, groupID, hexColor)
+		err = hueClient.SetGroupColorXY(ctx, groupID, xy)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to set color: %v", err)), nil
+			return toolError("Failed to set color", err), nil
 		}
 
 		return mcp.NewToolResultText(fmt.Sprintf("Group %s color set to %s", groupID, color)), nil
 	}
 }
 
+// groupColorPerLight sets a group's color one light at a time, converting it for each
+// light's gamut so mixed-gamut rooms show the same color. Failures are reported per light.
+func groupColorPerLight(ctx context.Context, hueClient *client.Client, groupID string, args map[string]interface{}, onlyOn bool) *mcp.CallToolResult {
+	color, _ := args["color"].(string)
+	if color == "" {
+		return mcp.NewToolResultError("per_light needs a color; xy coordinates are already exact")
+	}
+	hexColor, err := parseColor(color)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid color format: %v", err))
+	}
+
+	var lightIDs []string
+	if onlyOn {
+		lightIDs, err = targetOnLights(ctx, hueClient, groupID, true)
+	} else {
+		lightIDs, err = hueClient.GetGroupLights(ctx, groupID)
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+	if len(lightIDs) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Group %s has no lights", groupID))
+	}
+
+	var failures []string
+	for _, lightID := range lightIDs {
+		if err := hueClient.SetLightColor(ctx, lightID, hexColor); err != nil {
+			failures = append(failures, fmt.Sprintf("- %s: %v", lightID, err))
+		}
+	}
+
+	if len(failures) == len(lightIDs) {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to set color on every light in group %s:\n%s", groupID, strings.Join(failures, "\n")))
+	}
+	result := fmt.Sprintf("Group %s color set to %s light by light on %d lights", groupID, color, len(lightIDs)-len(failures))
+	if len(failures) > 0 {
+		result += fmt.Sprintf("\nFailed on %d lights:\n%s", len(failures), strings.Join(failures, "\n"))
+	}
+	return mcp.NewToolResultText(result)
+}
+
 // HandleGroupEffect returns a handler for setting group effects
 func HandleGroupEffect(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -272,15 +486,75 @@ func HandleGroupEffect(hueClient *client.Client) server.ToolHandlerFunc {
 			duration = int(d)
 		}
 
-		err := hueClient.SetGroupEffect(ctx, groupID, effect, duration)
+		params, err := effectParameters(args)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		groupID, err = resolveGroupID(ctx, hueClient, groupID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to set effect: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// With only_on or only_supporting the effect is set light by light
+		var lightIDs []string
+		perLight := false
+		target := "Group " + groupID
+		if onlyOn, _ := args["only_on"].(bool); onlyOn {
+			// Leave lights that are off alone
+			lightIDs, err = targetOnLights(ctx, hueClient, groupID, true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			perLight = true
+			target = fmt.Sprintf("Group %s (%d lights that were on)", groupID, len(lightIDs))
+		} else if lightIDs, err = hueClient.GetGroupLights(ctx, groupID); err != nil {
+			// Groups without a room or zone, like the bridge-wide one, can't be checked
+			log.Printf("Skipping effect support check for group %s: %v", groupID, err)
+		}
+
+		// Every light can clear its effect, so only check real effects
+		if effect != "no_effect" && len(lightIDs) > 0 {
+			supporting, lacking, err := splitEffectSupport(ctx, hueClient, lightIDs, effect)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(lacking) > 0 {
+				if onlySupporting, _ := args["only_supporting"].(bool); !onlySupporting {
+					return mcp.NewToolResultError(fmt.Sprintf("%d lights in group %s don't support %s: %s\nPass only_supporting to apply it to the other %d lights", len(lacking), groupID, effect, strings.Join(lacking, ", "), len(supporting))), nil
+				}
+				if len(supporting) == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("No lights in group %s support %s", groupID, effect)), nil
+				}
+				lightIDs, perLight = supporting, true
+				target = fmt.Sprintf("Group %s (%d lights that support it, skipped %s)", groupID, len(supporting), strings.Join(lacking, ", "))
+			}
+		}
+
+		// As for light_effect, the scheduled stop enforces duration rather than the bridge
+		stopType, stopIDs := "group", []string{groupID}
+		if perLight {
+			for _, lightID := range lightIDs {
+				if err := hueClient.SetLightEffectWithParameters(ctx, lightID, effect, 0, params); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to set effect on light %s: %v", lightID, err)), nil
+				}
+			}
+			stopType, stopIDs = "light", lightIDs
+		} else {
+			err := hueClient.SetGroupEffectWithParameters(ctx, groupID, effect, 0, params)
+			if err != nil {
+				return toolError("Failed to set effect", err), nil
+			}
 		}
 
 		desc := effects.GetDescription(effect)
-		result := fmt.Sprintf("Group %s effect set to %s - %s", groupID, effect, desc)
+		result := fmt.Sprintf("%s effect set to %s - %s", target, effect, desc)
 		if duration > 0 {
-			result += fmt.Sprintf(" (duration: %d seconds)", duration)
+			stop, err := scheduleEffectStop(stopType, duration, stopIDs...)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s, but failed to schedule it to stop: %v", result, err)), nil
+			}
+			result += effectStopNote(duration, stop)
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -292,16 +566,50 @@ func HandleGroupEffect(hueClient *client.Client) server.ToolHandlerFunc {
 // HandleListScenes returns a handler for listing scenes
 func HandleListScenes(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		asJSON, err := listFormat(request.GetArguments())
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		page, err := parseListPage(request.GetArguments())
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		scenes, err := hueClient.GetScenes(ctx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to list scenes: %v", err)), nil
+			return toolError("Failed to list scenes", err), nil
+		}
+
+		// Number scenes consistently so they can be recalled by index
+		sceneIndex := sceneIndexFor(hueClient)
+		sceneIndex.Update(scenes)
+		sort.Slice(scenes, func(i, j int) bool {
+			a, _ := sceneIndex.Index(scenes[i].ID)
+			b, _ := sceneIndex.Index(scenes[j].ID)
+			return a < b
+		})
+		// Filtering and paging keep each scene's index so it can still be recalled by number
+		shown, total := pageItems(page, scenes, func(scene client.Scene) string { return scene.Metadata.Name })
+
+		if asJSON {
+			items := make([]listItem, 0, len(shown))
+			for _, scene := range shown {
+				index, _ := sceneIndex.Index(scene.ID)
+				items = append(items, listItem{ID: scene.ID, Name: scene.Metadata.Name, Index: index})
+			}
+			if page.paged() {
+				return jsonPageResult(page, items, total)
+			}
+			return jsonListResult(items)
 		}
 
 		var result strings.Builder
-		result.WriteString(fmt.Sprintf("Found %d scenes:\n", len(scenes)))
-		for _, scene := range scenes {
-			result.WriteString(fmt.Sprintf("- %s: %s (ID: %s)\n", scene.Metadata.Name, scene.ID, scene.IDV1))
+		result.WriteString(fmt.Sprintf("%s:\n", page.header(len(shown), total, "scenes")))
+		for _, scene := range shown {
+			index, _ := sceneIndex.Index(scene.ID)
+			result.WriteString(fmt.Sprintf("%d. %s: %s (ID: %s)\n", index, scene.Metadata.Name, scene.ID, scene.IDV1))
 		}
+		result.WriteString(page.footer(len(shown), total))
 
 		return mcp.NewToolResultText(result.String()), nil
 	}
@@ -316,12 +624,54 @@ func HandleActivateScene(hueClient *client.Client) server.ToolHandlerFunc {
 			return mcp.NewToolResultError("scene_id is required"), nil
 		}
 
-		err := hueClient.ActivateScene(ctx, sceneID)
+		var brightness *float64
+		if b, ok := args["brightness"].(float64); ok {
+			if b < 0 || b > 100 {
+				return mcp.NewToolResultError("brightness must be between 0 and 100"), nil
+			}
+			brightness = &b
+		}
+
+		var durationMs *int
+		if d, ok := args["transition_ms"].(float64); ok {
+			if d < 0 {
+				return mcp.NewToolResultError("transition_ms cannot be negative"), nil
+			}
+			ms := int(d)
+			durationMs = &ms
+		}
+
+		recall := client.SceneRecall{Brightness: brightness, DurationMs: durationMs}
+		mode, _ := args["mode"].(string)
+		switch mode {
+		case "", "static":
+		case "dynamic":
+			recall.Action = client.SceneRecallDynamic
+		default:
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown mode '%s' (expected static or dynamic)", mode)), nil
+		}
+
+		err := hueClient.RecallScene(ctx, sceneID, recall)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to activate scene: %v", err)), nil
+			return toolError("Failed to activate scene", err), nil
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Scene %s activated", sceneID)), nil
+		result := fmt.Sprintf("Scene %s activated", sceneID)
+		if recall.Action == client.SceneRecallDynamic {
+			// The bridge accepts dynamic recall for any scene but only animates those with a palette
+			if scene, err := hueClient.GetScene(ctx, sceneID); err == nil && !scene.HasDynamicPalette() {
+				result += " (dynamic mode not applied: scene has no dynamic palette)"
+			} else {
+				result += " in dynamic mode"
+			}
+		}
+		if brightness != nil {
+			result += fmt.Sprintf(" at %.0f%% brightness", *brightness)
+		}
+		if durationMs != nil {
+			result += fmt.Sprintf(" over %dms", *durationMs)
+		}
+		return mcp.NewToolResultText(result), nil
 	}
 }
 
@@ -339,26 +689,272 @@ func HandleCreateScene(hueClient *client.Client) server.ToolHandlerFunc {
 			return mcp.NewToolResultError("group_id is required"), nil
 		}
 
-		// Create scene
-		sceneCreate := client.SceneCreate{
-			Type: "scene",
-			Metadata: client.Metadata{
-				Name: name,
-			},
-			Group: client.ResourceIdentifier{
-				RID:   groupID,
-				RType: "grouped_light",
-			},
-			Actions: []client.SceneAction{}, // Would need to capture current states
+		// Capture the current state of every light in the group
+		scene, err := hueClient.CreateSceneFromCurrentState(ctx, name, groupID)
+		if err != nil {
+			return toolError("Failed to create scene", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Scene '%s' created with ID: %s", name, scene.ID)), nil
+	}
+}
+
+// HandleCreatePaletteScene returns a handler for creating a multi-color palette scene
+func HandleCreatePaletteScene(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		name, ok := args["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+
+		groupID, ok := args["group_id"].(string)
+		if !ok || groupID == "" {
+			return mcp.NewToolResultError("group_id is required"), nil
+		}
+
+		colorsJSON, ok := args["colors"].(string)
+		if !ok || colorsJSON == "" {
+			return mcp.NewToolResultError("colors is required"), nil
+		}
+
+		var colorNames []string
+		if err := json.Unmarshal([]byte(colorsJSON), &colorNames); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to parse colors JSON (expected an array like [\"red\", \"#FF8000\"]): %v", err)), nil
+		}
+
+		colors := make([]string, len(colorNames))
+		for i, color := range colorNames {
+			hex, err := parseColor(color)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid color format: %v", err)), nil
+			}
+			colors[i] = hex
+		}
+
+		brightness := 100.0
+		if b, ok := args["brightness"].(float64); ok {
+			brightness = b
+		}
+
+		resolvedID, err := resolveGroupID(ctx, hueClient, groupID)
+		if err != nil {
+			return toolError("Failed to resolve group", err), nil
+		}
+
+		autoDynamic, _ := args["auto_dynamic"].(bool)
+
+		scene, err := hueClient.CreateSceneWithPalette(ctx, name, resolvedID, colors, brightness, autoDynamic)
+		if err != nil {
+			return toolError("Failed to create scene", err), nil
+		}
+
+		result := fmt.Sprintf("Palette scene '%s' created with %d colors, ID: %s", name, len(colors), scene.ID)
+		if autoDynamic {
+			result += "\nIt shifts between the colors whenever it is activated."
+		} else if len(colors) > 1 {
+			result += "\nActivate it with mode 'dynamic' to shift between the colors."
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+// HandleActiveScene returns a handler reporting which scene a room or zone is in
+func HandleActiveScene(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		groupRef, ok := args["group_id"].(string)
+		if !ok || groupRef == "" {
+			return mcp.NewToolResultError("group_id is required"), nil
+		}
+
+		groupID, err := resolveGroupID(ctx, hueClient, groupRef)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		scene, err := hueClient.CreateScene(ctx, sceneCreate)
+		scene, err := hueClient.GetActiveScene(ctx, groupID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to create scene: %v", err)), nil
+			return toolError("Failed to get active scene", err), nil
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Scene '%s' created with ID: %s", name, scene.ID)), nil
+		if scene == nil {
+			return mcp.NewToolResultText(fmt.Sprintf("No active scene for %s (lights are manually set)", groupRef)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Active scene for %s: %s (ID: %s, %s)", groupRef, scene.Metadata.Name, scene.ID, scene.Status.Active)), nil
+	}
+}
+
+// HandleGetScene returns a handler showing what a scene does to each of its lights
+func HandleGetScene(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		sceneRef, ok := args["scene_id"].(string)
+		if !ok || sceneRef == "" {
+			return mcp.NewToolResultError("scene_id is required"), nil
+		}
+
+		sceneID, err := resolveSceneID(ctx, hueClient, sceneRef)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		scene, err := hueClient.GetScene(ctx, sceneID)
+		if err != nil {
+			return toolError("Failed to get scene", err), nil
+		}
+
+		names, err := resourceNames(ctx, hueClient)
+		if err != nil {
+			return toolError("Failed to look up names", err), nil
+		}
+		nameOf := func(id string) string {
+			if name := names[id]; name != "" {
+				return fmt.Sprintf("%s (%s)", name, id)
+			}
+			return id
+		}
+
+		var result strings.Builder
+		result.WriteString(fmt.Sprintf("Scene: %s (ID: %s)\n", scene.Metadata.Name, scene.ID))
+		result.WriteString(fmt.Sprintf("Group: %s [%s]\n", nameOf(scene.Group.RID), scene.Group.RType))
+		result.WriteString(fmt.Sprintf("Speed: %.2f\n", scene.Speed))
+		result.WriteString(fmt.Sprintf("Actions (%d):\n", len(scene.Actions)))
+		for _, action := range scene.Actions {
+			result.WriteString(fmt.Sprintf("- %s: %s\n", nameOf(action.Target.RID), describeSceneAction(action.Action)))
+		}
+
+		return mcp.NewToolResultText(result.String()), nil
+	}
+}
+
+// HandleScenesUsingLight returns a handler listing the scenes that set a light, either directly
+// or through an action on a room, zone or grouped_light the light belongs to
+func HandleScenesUsingLight(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		lightRef, ok := args["light_id"].(string)
+		if !ok || lightRef == "" {
+			return mcp.NewToolResultError("light_id is required"), nil
+		}
+
+		lightID, err := resolveLightID(ctx, hueClient, lightRef)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		scenes, err := hueClient.GetScenes(ctx)
+		if err != nil {
+			return toolError("Failed to list scenes", err), nil
+		}
+		names, err := resourceNames(ctx, hueClient)
+		if err != nil {
+			return toolError("Failed to look up names", err), nil
+		}
+
+		// Group membership is looked up once per group however many scenes target it. A failed
+		// lookup is reported rather than read as "not in the group", since this answer is used
+		// to decide whether a light is safe to remove.
+		groupHasLight := make(map[string]bool)
+		inGroup := func(groupID string) (bool, error) {
+			has, seen := groupHasLight[groupID]
+			if !seen {
+				lightIDs, err := hueClient.GetGroupLights(ctx, groupID)
+				if err != nil {
+					return false, err
+				}
+				has = containsString(lightIDs, lightID)
+				groupHasLight[groupID] = has
+			}
+			return has, nil
+		}
+
+		sort.Slice(scenes, func(i, j int) bool {
+			return strings.ToLower(scenes[i].Metadata.Name) < strings.ToLower(scenes[j].Metadata.Name)
+		})
+
+		var matches []string
+		for _, scene := range scenes {
+			direct, via := sceneSetsLight(scene, lightID), ""
+			if !direct {
+				for _, action := range scene.Actions {
+					switch action.Target.RType {
+					case "grouped_light", "room", "zone":
+						has, err := inGroup(action.Target.RID)
+						if err != nil {
+							return toolError(fmt.Sprintf("Failed to look up the lights in group %s", action.Target.RID), err), nil
+						}
+						if has {
+							via = action.Target.RID
+						}
+					}
+					if via != "" {
+						break
+					}
+				}
+			}
+			if !direct && via == "" {
+				continue
+			}
+
+			line := fmt.Sprintf("- %s (ID: %s) in %s", scene.Metadata.Name, scene.ID, names[scene.Group.RID])
+			if via != "" {
+				line += fmt.Sprintf(", through group %s", names[via])
+			}
+			matches = append(matches, line)
+		}
+
+		label := lightID
+		if name := names[lightID]; name != "" {
+			label = fmt.Sprintf("%s (%s)", name, lightID)
+		}
+		if len(matches) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No scenes use light %s", label)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("%d scenes use light %s:\n%s\n", len(matches), label, strings.Join(matches, "\n"))), nil
+	}
+}
+
+// sceneSetsLight reports whether a scene has an action targeting the light directly
+func sceneSetsLight(scene client.Scene, lightID string) bool {
+	for _, action := range scene.Actions {
+		if action.Target.RType == "light" && action.Target.RID == lightID {
+			return true
+		}
 	}
+	return false
+}
+
+// describeSceneAction summarises the state a scene sets on one light
+func describeSceneAction(action client.LightUpdate) string {
+	var parts []string
+	if action.On != nil {
+		if !action.On.On {
+			return "off"
+		}
+		parts = append(parts, "on")
+	}
+	if action.Dimming != nil {
+		parts = append(parts, fmt.Sprintf("%.0f%%", action.Dimming.Brightness))
+	}
+	if action.Color != nil {
+		brightness := 100.0
+		if action.Dimming != nil {
+			brightness = action.Dimming.Brightness
+		}
+		parts = append(parts, client.XYToHex(action.Color.XY.X, action.Color.XY.Y, brightness))
+	}
+	if action.ColorTemperature != nil && action.ColorTemperature.Mirek > 0 {
+		parts = append(parts, describeMirek(action.ColorTemperature.Mirek))
+	}
+	if action.Effects != nil && action.Effects.Effect != "" && action.Effects.Effect != "no_effect" {
+		parts = append(parts, "effect "+action.Effects.Effect)
+	}
+	if len(parts) == 0 {
+		return "no change"
+	}
+	return strings.Join(parts, ", ")
 }
 
 // System handlers
@@ -366,21 +962,62 @@ func HandleCreateScene(hueClient *client.Client) server.ToolHandlerFunc {
 // HandleListLights returns a handler for listing lights
 func HandleListLights(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		asJSON, err := listFormat(request.GetArguments())
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		page, err := parseListPage(request.GetArguments())
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		lights, err := hueClient.GetLights(ctx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to list lights: %v", err)), nil
+			return toolError("Failed to list lights", err), nil
+		}
+		// The bridge's order isn't stable, so sort before paging to keep pages from overlapping
+		sort.Slice(lights, func(i, j int) bool {
+			a, b := strings.ToLower(lights[i].Metadata.Name), strings.ToLower(lights[j].Metadata.Name)
+			if a != b {
+				return a < b
+			}
+			return lights[i].ID < lights[j].ID
+		})
+		lights, total := pageItems(page, lights, func(light client.Light) string { return light.Metadata.Name })
+
+		if asJSON {
+			items := make([]listItem, 0, len(lights))
+			for _, light := range lights {
+				on, brightness := light.On.On, light.Dimming.Brightness
+				item := listItem{ID: light.ID, Name: light.Metadata.Name, Type: light.Metadata.Archetype, On: &on, Brightness: &brightness}
+				if light.IsOnOffOnly() {
+					item.Brightness = nil
+				}
+				items = append(items, item)
+			}
+			if page.paged() {
+				return jsonPageResult(page, items, total)
+			}
+			return jsonListResult(items)
 		}
 
 		var result strings.Builder
-		result.WriteString(fmt.Sprintf("Found %d lights:\n", len(lights)))
+		result.WriteString(fmt.Sprintf("%s:\n", page.header(len(lights), total, "lights")))
 		for _, light := range lights {
 			status := "off"
 			if light.On.On {
 				status = fmt.Sprintf("on, brightness: %.0f%%", light.Dimming.Brightness)
 			}
+			if light.IsOnOffOnly() {
+				status = "off (on/off only)"
+				if light.On.On {
+					status = "on (on/off only)"
+				}
+			}
 			result.WriteString(fmt.Sprintf("- %s (%s): %s (ID: %s, v1: %s)\n", 
 				light.Metadata.Name, light.Metadata.Archetype, status, light.ID, light.IDV1))
 		}
+		result.WriteString(page.footer(len(lights), total))
 
 		return mcp.NewToolResultText(result.String()), nil
 	}
@@ -389,9 +1026,37 @@ func HandleListLights(hueClient *client.Client) server.ToolHandlerFunc {
 // HandleListGroups returns a handler for listing groups
 func HandleListGroups(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		asJSON, err := listFormat(request.GetArguments())
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		groups, err := hueClient.GetGroups(ctx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to list groups: %v", err)), nil
+			return toolError("Failed to list groups", err), nil
+		}
+
+		if asJSON {
+			// grouped_light resources carry no name of their own, so name them after their room or zone
+			names, err := resourceNames(ctx, hueClient)
+			if err != nil {
+				return toolError("Failed to list groups", err), nil
+			}
+
+			items := make([]listItem, 0, len(groups))
+			for _, group := range groups {
+				item := listItem{ID: group.ID, Name: group.Metadata.Name}
+				if group.Owner != nil {
+					item.Type = group.Owner.RType
+					if item.Name == "" {
+						item.Name = names[group.Owner.RID]
+					}
+				}
+				on, brightness := group.On.On, group.Dimming.Brightness
+				item.On, item.Brightness = &on, &brightness
+				items = append(items, item)
+			}
+			return jsonListResult(items)
 		}
 
 		var result strings.Builder
@@ -418,31 +1083,132 @@ func HandleGetLightState(hueClient *client.Client) server.ToolHandlerFunc {
 			return mcp.NewToolResultError("light_id is required"), nil
 		}
 
-		light, err := hueClient.GetLight(ctx, lightID)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to get light: %v", err)), nil
-		}
+		light, err := hueClient.GetLight(ctx, lightID)
+		if err != nil {
+			return toolError("Failed to get light", err), nil
+		}
+
+		var result strings.Builder
+		result.WriteString(fmt.Sprintf("Light: %s\n", light.Metadata.Name))
+		result.WriteString(fmt.Sprintf("Type: %s\n", light.Metadata.Archetype))
+		result.WriteString(fmt.Sprintf("On: %v\n", light.On.On))
+		if light.IsOnOffOnly() {
+			result.WriteString("Capabilities: on/off only (no brightness or color)\n")
+		} else {
+			result.WriteString(fmt.Sprintf("Brightness: %.0f%%\n", light.Dimming.Brightness))
+		}
+		
+		if light.Color != nil {
+			result.WriteString(fmt.Sprintf("Color XY: (%.3f, %.3f)\n", light.Color.XY.X, light.Color.XY.Y))
+			result.WriteString(fmt.Sprintf("Color (approx hex): %s\n", client.XYToHex(light.Color.XY.X, light.Color.XY.Y, light.Dimming.Brightness)))
+		}
+		
+		if light.ColorTemperature != nil && light.ColorTemperature.MirekValid {
+			result.WriteString(fmt.Sprintf("Color Temperature: %s\n", describeMirek(light.ColorTemperature.Mirek)))
+		}
+		
+		if light.Effects != nil {
+			result.WriteString(fmt.Sprintf("Effect: %s\n", light.Effects.Effect))
+		}
+
+		return mcp.NewToolResultText(result.String()), nil
+	}
+}
+
+// HandleGetGroupState returns a handler for getting a group's aggregate state and member lights
+func HandleGetGroupState(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		groupID, ok := args["group_id"].(string)
+		if !ok || groupID == "" {
+			return mcp.NewToolResultError("group_id is required"), nil
+		}
+
+		resolvedID, err := resolveGroupID(ctx, hueClient, groupID)
+		if err != nil {
+			return toolError("Failed to resolve group", err), nil
+		}
+
+		state, err := groupStateText(ctx, hueClient, resolvedID, false)
+		if err != nil {
+			return toolError("Failed to get group state", err), nil
+		}
+
+		return mcp.NewToolResultText(state), nil
+	}
+}
+
+// groupStateText describes a grouped_light's aggregate state, optionally listing each member light
+func groupStateText(ctx context.Context, hueClient *client.Client, resolvedID string, listMembers bool) (string, error) {
+	group, err := hueClient.GetGroup(ctx, resolvedID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get group: %w", err)
+	}
+
+	// grouped_light resources carry no name, so take it from the owning room or zone
+	name := group.Metadata.Name
+	if group.Owner != nil && name == "" {
+		if names, err := resourceNames(ctx, hueClient); err == nil {
+			name = names[group.Owner.RID]
+		}
+	}
+	if name == "" {
+		name = resolvedID
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Group: %s\n", name))
+	if group.Owner != nil {
+		result.WriteString(fmt.Sprintf("Type: %s\n", group.Owner.RType))
+	}
+	result.WriteString(fmt.Sprintf("On: %v\n", group.On.On))
+	result.WriteString(fmt.Sprintf("Brightness: %.0f%%\n", group.Dimming.Brightness))
+
+	if group.Color != nil && (group.Color.XY.X != 0 || group.Color.XY.Y != 0) {
+		result.WriteString(fmt.Sprintf("Color (approx hex): %s\n", client.XYToHex(group.Color.XY.X, group.Color.XY.Y, group.Dimming.Brightness)))
+	}
+
+	if group.ColorTemperature != nil && group.ColorTemperature.MirekValid {
+		result.WriteString(fmt.Sprintf("Color Temperature: %s\n", describeMirek(group.ColorTemperature.Mirek)))
+	}
 
-		var result strings.Builder
-		result.WriteString(fmt.Sprintf("Light: %s\n", light.Metadata.Name))
-		result.WriteString(fmt.Sprintf("Type: %s\n", light.Metadata.Archetype))
-		result.WriteString(fmt.Sprintf("On: %v\n", light.On.On))
-		result.WriteString(fmt.Sprintf("Brightness: %.0f%%\n", light.Dimming.Brightness))
-		
-		if light.Color != nil {
-			result.WriteString(fmt.Sprintf("Color XY: (%.3f, %.3f)\n", light.Color.XY.X, light.Color.XY.Y))
-		}
-		
-		if light.ColorTemperature != nil && light.ColorTemperature.MirekValid {
-			result.WriteString(fmt.Sprintf("Color Temperature: %d mirek\n", light.ColorTemperature.Mirek))
-		}
-		
-		if light.Effects != nil {
-			result.WriteString(fmt.Sprintf("Effect: %s\n", light.Effects.Effect))
+	lightIDs, err := hueClient.GetGroupLights(ctx, resolvedID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get group lights: %w", err)
+	}
+
+	lights, err := hueClient.GetLights(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get lights: %w", err)
+	}
+	byID := make(map[string]client.Light, len(lights))
+	for _, light := range lights {
+		byID[light.ID] = light
+	}
+
+	lightsOn := 0
+	for _, lightID := range lightIDs {
+		if byID[lightID].On.On {
+			lightsOn++
 		}
+	}
+	result.WriteString(fmt.Sprintf("Lights: %d (%d on)\n", len(lightIDs), lightsOn))
 
-		return mcp.NewToolResultText(result.String()), nil
+	if listMembers {
+		for _, lightID := range lightIDs {
+			state := "off"
+			if byID[lightID].On.On {
+				state = fmt.Sprintf("on, %.0f%%", byID[lightID].Dimming.Brightness)
+			}
+			name := byID[lightID].Metadata.Name
+			if name == "" {
+				name = lightID
+			}
+			result.WriteString(fmt.Sprintf("- %s (%s): %s\n", name, lightID, state))
+		}
 	}
+
+	return result.String(), nil
 }
 
 // HandleBridgeInfo returns a handler for getting bridge info
@@ -450,7 +1216,7 @@ func HandleBridgeInfo(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		bridge, err := hueClient.GetBridge(ctx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to get bridge info: %v", err)), nil
+			return toolError("Failed to get bridge info", err), nil
 		}
 
 		var result strings.Builder
@@ -464,6 +1230,39 @@ func HandleBridgeInfo(hueClient *client.Client) server.ToolHandlerFunc {
 	}
 }
 
+// bridgeUpdateStates explains each device_software_update state
+var bridgeUpdateStates = map[string]string{
+	"no_update":        "Up to date",
+	"update_pending":   "An update is available and will be downloaded",
+	"ready_to_install": "An update is downloaded and ready to install",
+	"installing":       "An update is installing now",
+	"unknown":          "The bridge does not report its update state",
+}
+
+// HandleBridgeUpdateStatus returns a handler reporting the bridge's software version and update state
+func HandleBridgeUpdateStatus(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		status, err := hueClient.GetBridgeUpdateStatus(ctx)
+		if err != nil {
+			return toolError("Failed to get bridge update status", err), nil
+		}
+
+		state, ok := bridgeUpdateStates[status.State]
+		if !ok {
+			state = status.State
+		}
+
+		var result strings.Builder
+		result.WriteString(fmt.Sprintf("Bridge software version: %s\n", status.SoftwareVersion))
+		result.WriteString(fmt.Sprintf("Update status: %s\n", state))
+		for _, problem := range status.Problems {
+			result.WriteString(fmt.Sprintf("Problem: %s\n", problem))
+		}
+
+		return mcp.NewToolResultText(result.String()), nil
+	}
+}
+
 // HandleIdentifyLight returns a handler for identifying a light
 func HandleIdentifyLight(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -475,38 +1274,100 @@ func HandleIdentifyLight(hueClient *client.Client) server.ToolHandlerFunc {
 
 		err := hueClient.IdentifyLight(ctx, lightID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to identify light: %v", err)), nil
+			return toolError("Failed to identify light", err), nil
 		}
 
 		return mcp.NewToolResultText(fmt.Sprintf("Light %s is blinking for identification", lightID)), nil
 	}
 }
 
-// Helper functions
+// maxIdentifyStaggerMs bounds the delay between lights in identify_group
+const maxIdentifyStaggerMs = 5000
+
+// HandleIdentifyGroup returns a handler that makes every light in a room, zone or group breathe,
+// either all together or one after another
+func HandleIdentifyGroup(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		groupID, ok := args["group_id"].(string)
+		if !ok || groupID == "" {
+			return mcp.NewToolResultError("group_id is required"), nil
+		}
+
+		staggerMs := 0
+		if stagger, ok := args["stagger_ms"].(float64); ok {
+			if stagger < 0 || stagger > maxIdentifyStaggerMs {
+				return mcp.NewToolResultError(fmt.Sprintf("stagger_ms must be between 0 and %d", maxIdentifyStaggerMs)), nil
+			}
+			staggerMs = int(stagger)
+		}
+
+		resolvedID, err := resolveGroupID(ctx, hueClient, groupID)
+		if err != nil {
+			return toolError("Failed to resolve group", err), nil
+		}
+
+		lightIDs, err := hueClient.GetGroupLights(ctx, resolvedID)
+		if err != nil {
+			return toolError("Failed to get group lights", err), nil
+		}
+		if len(lightIDs) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Group %s has no lights", groupID)), nil
+		}
+
+		names := make(map[string]string)
+		if lights, err := hueClient.GetLights(ctx); err == nil {
+			for _, light := range lights {
+				names[light.ID] = light.Metadata.Name
+			}
+		}
+
+		var result strings.Builder
+		if staggerMs > 0 {
+			// A wave can take several seconds, so it runs in the background instead of holding the call
+			result.WriteString(fmt.Sprintf("Identifying %d lights in %s one at a time, %dms apart:\n", len(lightIDs), groupID, staggerMs))
+			for i, lightID := range lightIDs {
+				result.WriteString(fmt.Sprintf("%d. %s\n", i+1, describeTarget(names, lightID)))
+			}
+			go identifyLightsStaggered(hueClient, lightIDs, time.Duration(staggerMs)*time.Millisecond)
+			return mcp.NewToolResultText(result.String()), nil
+		}
+
+		result.WriteString(fmt.Sprintf("Identifying %d lights in %s together:\n", len(lightIDs), groupID))
+		failed := 0
+		for i, lightID := range lightIDs {
+			label := describeTarget(names, lightID)
+			if err := hueClient.IdentifyLight(ctx, lightID); err != nil {
+				failed++
+				result.WriteString(fmt.Sprintf("%d. %s failed: %v\n", i+1, label, err))
+				continue
+			}
+			result.WriteString(fmt.Sprintf("%d. %s\n", i+1, label))
+		}
 
-func namedColorToHex(color string) string {
-	colors := map[string]string{
-		"red":     "#FF0000",
-		"green":   "#00FF00",
-		"blue":    "#0000FF",
-		"yellow":  "#FFFF00",
-		"cyan":    "#00FFFF",
-		"magenta": "#FF00FF",
-		"white":   "#FFFFFF",
-		"warm":    "#FFA500",
-		"cool":    "#ADD8E6",
-		"orange":  "#FFA500",
-		"purple":  "#800080",
-		"pink":    "#FFC0CB",
+		if failed == len(lightIDs) {
+			return mcp.NewToolResultError(result.String()), nil
+		}
+		return mcp.NewToolResultText(result.String()), nil
 	}
-	
-	hex, ok := colors[strings.ToLower(color)]
-	if ok {
-		return hex
+}
+
+// identifyLightsStaggered blinks each light in turn, waiting stagger between them.
+// It runs after the tool call has returned, so failures only reach the server log.
+func identifyLightsStaggered(hueClient *client.Client, lightIDs []string, stagger time.Duration) {
+	ctx := context.Background()
+	for i, lightID := range lightIDs {
+		if i > 0 {
+			time.Sleep(stagger)
+		}
+		if err := hueClient.IdentifyLight(ctx, lightID); err != nil {
+			log.Printf("Identify of light %s failed: %v", lightID, err)
+		}
 	}
-	return ""
 }
 
+// Helper functions
+
 func isValidHexColor(hex string) bool {
 	if !strings.HasPrefix(hex, "#") {
 		return false
@@ -521,6 +1382,123 @@ func isValidHexColor(hex string) bool {
 	return err == nil
 }
 
+// parseColor accepts a color name, #RGB, #RRGGBB, rgb(r,g,b) or hsv(h,s,v) and returns #RRGGBB
+func parseColor(input string) (string, error) {
+	color := strings.TrimSpace(input)
+	if hex := namedColorToHex(color); hex != "" {
+		return hex, nil
+	}
+
+	lower := strings.ToLower(color)
+	switch {
+	case strings.HasPrefix(color, "#"):
+		digits := color[1:]
+		if len(digits) == 3 {
+			digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+		}
+		hex := "#" + strings.ToUpper(digits)
+		if isValidHexColor(hex) {
+			return hex, nil
+		}
+
+	case strings.HasPrefix(lower, "rgb(") && strings.HasSuffix(lower, ")"):
+		values, err := parseColorComponents(lower[len("rgb(") : len(lower)-1])
+		if err != nil {
+			return "", fmt.Errorf("invalid rgb color '%s': %w", input, err)
+		}
+		for _, v := range values {
+			if v < 0 || v > 255 {
+				return "", fmt.Errorf("invalid rgb color '%s': components must be 0-255", input)
+			}
+		}
+		return fmt.Sprintf("#%02X%02X%02X", int(math.Round(values[0])), int(math.Round(values[1])), int(math.Round(values[2]))), nil
+
+	case strings.HasPrefix(lower, "hsv(") && strings.HasSuffix(lower, ")"):
+		values, err := parseColorComponents(lower[len("hsv(") : len(lower)-1])
+		if err != nil {
+			return "", fmt.Errorf("invalid hsv color '%s': %w", input, err)
+		}
+		h, sat, v := values[0], values[1], values[2]
+		if h < 0 || h > 360 || sat < 0 || sat > 100 || v < 0 || v > 100 {
+			return "", fmt.Errorf("invalid hsv color '%s': hue must be 0-360, saturation and value 0-100", input)
+		}
+		r, g, b := hsvToRGB(h, sat/100, v/100)
+		return fmt.Sprintf("#%02X%02X%02X", int(math.Round(r*255)), int(math.Round(g*255)), int(math.Round(b*255))), nil
+	}
+
+	return "", fmt.Errorf("invalid color '%s' (use a color name, #RGB, #RRGGBB, rgb(r,g,b) or hsv(h,s,v))", input)
+}
+
+// parseColorComponents reads three comma-separated numbers, allowing % suffixes
+func parseColorComponents(list string) ([]float64, error) {
+	parts := strings.Split(list, ",")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("expected 3 components, got %d", len(parts))
+	}
+
+	values := make([]float64, 3)
+	for i, part := range parts {
+		part = strings.TrimSuffix(strings.TrimSpace(part), "%")
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a number", strings.TrimSpace(parts[i]))
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// parseXY reads CIE xy coordinates written as "x,y", each between 0 and 1
+func parseXY(input string) (client.XY, error) {
+	parts := strings.Split(input, ",")
+	if len(parts) != 2 {
+		return client.XY{}, fmt.Errorf("invalid xy '%s' (expected \"x,y\", e.g. \"0.45,0.41\")", input)
+	}
+
+	var values [2]float64
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return client.XY{}, fmt.Errorf("invalid xy '%s': '%s' is not a number", input, strings.TrimSpace(part))
+		}
+		if v < 0 || v > 1 {
+			return client.XY{}, fmt.Errorf("invalid xy '%s': coordinates must be between 0 and 1", input)
+		}
+		values[i] = v
+	}
+	return client.XY{X: values[0], Y: values[1]}, nil
+}
+
+// colorArgXY reads the color or xy argument of a color tool and returns the xy to send
+// along with the text to report. Exact xy input skips the lossy hex conversion. For color
+// input the hex is returned too, so a single light can convert it within its own gamut.
+func colorArgXY(args map[string]interface{}) (client.XY, string, string, error) {
+	color, hasColor := args["color"].(string)
+	hasColor = hasColor && color != ""
+	xyInput, hasXY := args["xy"].(string)
+	hasXY = hasXY && xyInput != ""
+
+	switch {
+	case hasColor && hasXY:
+		return client.XY{}, "", "", fmt.Errorf("color and xy cannot be set together")
+	case hasXY:
+		xy, err := parseXY(xyInput)
+		if err != nil {
+			return client.XY{}, "", "", err
+		}
+		return xy, "", fmt.Sprintf("xy(%.4f, %.4f)", xy.X, xy.Y), nil
+	case hasColor:
+		hexColor, err := parseColor(color)
+		if err != nil {
+			return client.XY{}, "", "", fmt.Errorf("invalid color format: %v", err)
+		}
+		x, y := client.HexToXY(hexColor)
+		return client.XY{X: x, Y: y}, hexColor, color, nil
+	default:
+		return client.XY{}, "", "", fmt.Errorf("color or xy is required")
+	}
+}
+
 // BatchCommand represents a single command in a batch
 type BatchCommand struct {
 	Action   string  `json:"action"`
@@ -543,7 +1521,17 @@ func HandleBatchCommands(hueClient *client.Client) server.ToolHandlerFunc {
 		// Parse commands
 		var commands []map[string]interface{}
 		if err := json.Unmarshal([]byte(commandsJSON), &commands); err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to parse commands JSON: %v", err)), nil
+			return toolError("Failed to parse commands JSON", err), nil
+		}
+		
+		// Catch structural problems now; async failures only reach the server log
+		if err := validateBatchCommands(commands); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid batch, nothing was executed:\n%v", err)), nil
+		}
+		
+		// Targets may be names from list_lights or list_groups
+		if err := resolveBatchTargets(ctx, hueClient, commands); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Could not resolve batch targets, nothing was executed:\n%v", err)), nil
 		}
 		
 		// Get delay between commands (default 100ms)
@@ -558,6 +1546,32 @@ func HandleBatchCommands(hueClient *client.Client) server.ToolHandlerFunc {
 			async = a
 		}
 		
+		// Parallel batches fire commands sharing an at_ms offset together instead of staggering them
+		parallel, _ := args["parallel"].(bool)
+		
+		// Atomic batches stop at the first failure and roll back to the auto snapshot, so they
+		// run synchronously and one command at a time
+		autoSnapshot, _ := args["auto_snapshot"].(bool)
+		atomic, _ := args["atomic"].(bool)
+		if atomic {
+			if parallel {
+				return mcp.NewToolResultError("atomic and parallel cannot be set together"), nil
+			}
+			if !autoSnapshot {
+				return mcp.NewToolResultError("atomic requires auto_snapshot, so there is a state to roll back to"), nil
+			}
+			async = false
+		}
+		
+		// Describe the batch without touching the bridge
+		if dryRun, _ := args["dry_run"].(bool); dryRun {
+			preview, err := dryRunBatch(ctx, hueClient, commands, delayMs, parallel)
+			if err != nil {
+				return toolError("Failed to preview batch", err), nil
+			}
+			return mcp.NewToolResultText(preview), nil
+		}
+		
 		// Check for cache_name to save this scene
 		cacheName, _ := args["cache_name"].(string)
 		cacheDescription, _ := args["cache_description"].(string)
@@ -566,31 +1580,64 @@ func HandleBatchCommands(hueClient *client.Client) server.ToolHandlerFunc {
 		if cacheName != "" {
 			err := globalSceneCache.SaveScene(cacheName, commands, delayMs, cacheDescription)
 			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to cache scene: %v", err)), nil
+				return toolError("Failed to cache scene", err), nil
 			}
 			log.Printf("Cached scene '%s' with %d commands", cacheName, len(commands))
 		}
 		
 		// Generate batch ID for tracking
-		batchID := fmt.Sprintf("batch_%d_%d", time.Now().Unix(), len(commands))
+		batchID := fmt.Sprintf("batch_%d_%d", time.Now().UnixNano(), len(commands))
+		
+		// Capture the lights this batch touches so restore_state can undo it
+		snapshotNote := ""
+		var snapshot client.Snapshot
+		if autoSnapshot {
+			var err error
+			snapshot, err = snapshotBatchTargets(ctx, hueClient, commands)
+			if err != nil {
+				return toolError("Failed to snapshot lights before the batch, nothing was executed", err), nil
+			}
+			snapshot.Name = "before_" + batchID
+			globalSnapshotStore.Save(snapshot)
+			snapshotNote = fmt.Sprintf("\nSnapshot '%s' saved (%d lights); restore_state undoes this batch", snapshot.Name, len(snapshot.Lights))
+		}
 		
 		if async {
-			// Execute asynchronously - return immediately
-			go ExecuteBatchAsync(ctx, hueClient, commands, delayMs, batchID)
+			// Execute asynchronously - return immediately, registering first so it can be polled at once
+			globalBatchRegistry.Start(batchID, len(commands))
 			
-			responseMsg := fmt.Sprintf("Batch started asynchronously with ID: %s\nCommands: %d\nDelay between commands: %dms", 
-				batchID, len(commands), delayMs)
+			var responseMsg string
+			if parallel {
+				go ExecuteBatchParallelAsync(ctx, hueClient, commands, batchID)
+				responseMsg = fmt.Sprintf("Parallel batch started asynchronously with ID: %s\nCommands: %d in %d steps\nUse get_batch_status to check the results",
+					batchID, len(commands), len(batchSteps(commands)))
+			} else {
+				go ExecuteBatchAsync(ctx, hueClient, commands, delayMs, batchID)
+				responseMsg = fmt.Sprintf("Batch started asynchronously with ID: %s\nCommands: %d\nDelay between commands: %dms\nUse get_batch_status to check the results", 
+					batchID, len(commands), delayMs)
+			}
 			
 			if cacheName != "" {
 				responseMsg = fmt.Sprintf("Creating and caching atmosphere: %s...\n%s", cacheName, responseMsg)
 			}
 			
-			return mcp.NewToolResultText(responseMsg), nil
+			return mcp.NewToolResultText(responseMsg + snapshotNote), nil
 		} else {
 			// Execute synchronously
 			log.Printf("Starting synchronous batch %s with %d commands", batchID, len(commands))
 			
-			results := ExecuteBatch(ctx, hueClient, commands, delayMs)
+			var results []BatchResult
+			switch {
+			case atomic:
+				results = executeBatchSequential(ctx, hueClient, commands, delayMs, true)
+				if last := results[len(results)-1]; !last.Success {
+					return mcp.NewToolResultError(rollBackBatch(ctx, hueClient, snapshot, last, len(commands)-len(results))), nil
+				}
+			case parallel:
+				results = ExecuteBatchParallel(ctx, hueClient, commands)
+			default:
+				results = ExecuteBatch(ctx, hueClient, commands, delayMs)
+			}
 			
 			// Summarize results
 			successful := 0
@@ -610,13 +1657,155 @@ func HandleBatchCommands(hueClient *client.Client) server.ToolHandlerFunc {
 				responseMsg = fmt.Sprintf("Created and cached atmosphere: %s\n%s", cacheName, responseMsg)
 			}
 			
-			return mcp.NewToolResultText(responseMsg), nil
+			return mcp.NewToolResultText(responseMsg + snapshotNote), nil
+		}
+	}
+}
+
+// batchValueActions are the batch actions that need a value alongside the target
+var batchValueActions = map[string]string{
+	"light_brightness": "brightness",
+	"light_color":      "color",
+	"light_color_temp": "color_temp",
+	"light_effect":     "effect",
+	"group_brightness": "brightness",
+	"group_color":      "color",
+	"group_color_temp": "color_temp",
+	"group_effect":     "effect",
+}
+
+// batchTargetActions are the remaining batch actions, which only need a target
+var batchTargetActions = map[string]bool{
+	"light_on":       true,
+	"light_off":      true,
+	"group_on":       true,
+	"group_off":      true,
+	"activate_scene": true,
+	"identify_light": true,
+}
+
+// validateBatchCommands checks every command has a known action, a target and a usable value,
+// reporting all problems at once
+func validateBatchCommands(commands []map[string]interface{}) error {
+	if len(commands) == 0 {
+		return fmt.Errorf("commands array is empty")
+	}
+	
+	var problems []string
+	for i, cmd := range commands {
+		action, _ := cmd["action"].(string)
+		targetID, _ := cmd["target_id"].(string)
+		value, _ := cmd["value"].(string)
+		
+		kind, needsValue := batchValueActions[action]
+		switch {
+		case action == "":
+			problems = append(problems, fmt.Sprintf("command %d: action is required", i))
+			continue
+		case !needsValue && !batchTargetActions[action]:
+			problems = append(problems, fmt.Sprintf("command %d: unknown action %q", i, action))
+			continue
+		}
+		
+		if targetID == "" {
+			problems = append(problems, fmt.Sprintf("command %d (%s): target_id is required", i, action))
+		}
+		if at, exists := cmd["at_ms"]; exists {
+			if ms, ok := at.(float64); !ok || ms < 0 {
+				problems = append(problems, fmt.Sprintf("command %d (%s): at_ms must be a non-negative number of milliseconds", i, action))
+			}
+		}
+		if !needsValue {
+			continue
 		}
+		
+		if value == "" {
+			problems = append(problems, fmt.Sprintf("command %d (%s): %s value is required", i, action, kind))
+			continue
+		}
+		switch kind {
+		case "brightness":
+			if brightness, err := strconv.ParseFloat(value, 64); err != nil || brightness < 0 || brightness > 100 {
+				problems = append(problems, fmt.Sprintf("command %d (%s): brightness must be a number between 0 and 100, got %q", i, action, value))
+			}
+		case "color":
+			if _, err := parseColor(value); err != nil {
+				problems = append(problems, fmt.Sprintf("command %d (%s): %v", i, action, err))
+			}
+		case "color_temp":
+			if _, err := parseBatchMirek(value); err != nil {
+				problems = append(problems, fmt.Sprintf("command %d (%s): %v", i, action, err))
+			}
+		}
+	}
+	
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// parseBatchMirek reads a color_temp batch value in mirek
+func parseBatchMirek(value string) (int, error) {
+	mirek, err := strconv.Atoi(value)
+	if err != nil || mirek < 153 || mirek > 500 {
+		return 0, fmt.Errorf("color_temp must be a whole number of mirek between 153 and 500, got %q", value)
+	}
+	return mirek, nil
+}
+
+// resolveBatchTargets replaces light, group and scene names in target_id with their IDs.
+// Each distinct name is looked up once per batch, and all failures are reported together.
+func resolveBatchTargets(ctx context.Context, hueClient *client.Client, commands []map[string]interface{}) error {
+	resolved := make(map[string]string)
+	var problems []string
+	
+	for i, cmd := range commands {
+		action, _ := cmd["action"].(string)
+		targetID, _ := cmd["target_id"].(string)
+		
+		kind := "light"
+		switch {
+		case strings.HasPrefix(action, "group_"):
+			kind = "group"
+		case action == "activate_scene":
+			kind = "scene"
+		}
+		
+		key := kind + ":" + targetID
+		id, ok := resolved[key]
+		if !ok {
+			var err error
+			switch kind {
+			case "light":
+				id, err = resolveLightID(ctx, hueClient, targetID)
+			case "group":
+				id, err = resolveGroupID(ctx, hueClient, targetID)
+			case "scene":
+				id, err = resolveSceneID(ctx, hueClient, targetID)
+			}
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("command %d (%s): %v", i, action, err))
+				continue
+			}
+			resolved[key] = id
+		}
+		
+		cmd["target_id"] = id
 	}
+	
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "\n"))
+	}
+	return nil
 }
 
 // executeBatchCommand executes a single command within a batch
 func executeBatchCommand(ctx context.Context, hueClient *client.Client, action, targetID, value string, duration int) (string, error) {
+	if names, ok := dryRunNames(ctx); ok {
+		return describeDryRunCommand(names, action, targetID, value, duration), nil
+	}
+	
 	switch action {
 	case "light_on":
 		err := hueClient.TurnOnLight(ctx, targetID)
@@ -650,19 +1839,27 @@ func executeBatchCommand(ctx context.Context, hueClient *client.Client, action,
 		if value == "" {
 			return "", fmt.Errorf("color value is required")
 		}
-		hexColor := namedColorToHex(value)
-		if hexColor == "" {
-			hexColor = value
-		}
-		if !isValidHexColor(hexColor) {
-			return "", fmt.Errorf("invalid color format: %s", value)
+		hexColor, err := parseColor(value)
+		if err != nil {
+			return "", err
 		}
-		err := hueClient.SetLightColor(ctx, targetID, hexColor)
+		err = hueClient.SetLightColor(ctx, targetID, hexColor)
 		if err != nil {
 			return "", err
 		}
 		return fmt.Sprintf("Light %s color set to %s", targetID, value), nil
 
+	case "light_color_temp":
+		mirek, err := parseBatchMirek(value)
+		if err != nil {
+			return "", err
+		}
+		err = hueClient.SetLightColorTemperature(ctx, targetID, mirek)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Light %s color temperature set to %s", targetID, describeMirek(mirek)), nil
+
 	case "light_effect":
 		if value == "" {
 			return "", fmt.Errorf("effect value is required")
@@ -710,19 +1907,27 @@ func executeBatchCommand(ctx context.Context, hueClient *client.Client, action,
 		if value == "" {
 			return "", fmt.Errorf("color value is required")
 		}
-		hexColor := namedColorToHex(value)
-		if hexColor == "" {
-			hexColor = value
-		}
-		if !isValidHexColor(hexColor) {
-			return "", fmt.Errorf("invalid color format: %s", value)
+		hexColor, err := parseColor(value)
+		if err != nil {
+			return "", err
 		}
-		err := hueClient.SetGroupColor(ctx, targetID, hexColor)
+		err = hueClient.SetGroupColor(ctx, targetID, hexColor)
 		if err != nil {
 			return "", err
 		}
 		return fmt.Sprintf("Group %s color set to %s", targetID, value), nil
 
+	case "group_color_temp":
+		mirek, err := parseBatchMirek(value)
+		if err != nil {
+			return "", err
+		}
+		err = hueClient.SetGroupColorTemperature(ctx, targetID, mirek)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Group %s color temperature set to %s", targetID, describeMirek(mirek)), nil
+
 	case "group_effect":
 		if value == "" {
 			return "", fmt.Errorf("effect value is required")
@@ -759,6 +1964,7 @@ func executeBatchCommand(ctx context.Context, hueClient *client.Client, action,
 
 // BatchResult represents the result of a batch command
 type BatchResult struct {
+	Index   int // Position of the command in the batch
 	Success bool
 	Message string
 	Error   error
@@ -766,6 +1972,21 @@ type BatchResult struct {
 
 // ExecuteBatch executes batch commands synchronously and returns results
 func ExecuteBatch(ctx context.Context, client *client.Client, commands []map[string]interface{}, delayMs int) []BatchResult {
+	return executeBatchSequential(ctx, client, commands, delayMs, false)
+}
+
+// rollBackBatch restores the pre-batch snapshot after an atomic batch failed and describes
+// which command failed, how many were skipped and whether the rollback worked
+func rollBackBatch(ctx context.Context, hueClient *client.Client, snapshot client.Snapshot, failed BatchResult, skipped int) string {
+	message := fmt.Sprintf("Atomic batch aborted: %s\nSkipped %d remaining commands", failed.Message, skipped)
+	if err := hueClient.ApplyStates(ctx, snapshot); err != nil {
+		return fmt.Sprintf("%s\nRollback to snapshot '%s' failed: %v", message, snapshot.Name, err)
+	}
+	return fmt.Sprintf("%s\nRolled back %d lights to snapshot '%s'", message, len(snapshot.Lights), snapshot.Name)
+}
+
+// executeBatchSequential runs commands one after another, stopping after the first failure if asked
+func executeBatchSequential(ctx context.Context, client *client.Client, commands []map[string]interface{}, delayMs int, stopOnFailure bool) []BatchResult {
 	results := make([]BatchResult, 0, len(commands))
 	
 	for i, cmd := range commands {
@@ -782,12 +2003,17 @@ func ExecuteBatch(ctx context.Context, client *client.Client, commands []map[str
 		result, err := executeBatchCommand(ctx, client, action, targetID, value, duration)
 		if err != nil {
 			results = append(results, BatchResult{
+				Index:   i,
 				Success: false,
 				Message: fmt.Sprintf("Command %d (%s): %v", i, action, err),
 				Error:   err,
 			})
+			if stopOnFailure {
+				break
+			}
 		} else {
 			results = append(results, BatchResult{
+				Index:   i,
 				Success: true,
 				Message: result,
 				Error:   nil,
@@ -810,6 +2036,7 @@ func ExecuteBatchAsync(ctx context.Context, client *client.Client, commands []ma
 	
 	// Log batch start
 	log.Printf("Starting async batch %s with %d commands", batchID, len(commands))
+	globalBatchRegistry.Start(batchID, len(commands))
 	
 	// Process each command
 	for i, cmd := range commands {
@@ -817,6 +2044,7 @@ func ExecuteBatchAsync(ctx context.Context, client *client.Client, commands []ma
 		select {
 		case <-ctx.Done():
 			log.Printf("Batch %s cancelled at command %d", batchID, i)
+			globalBatchRegistry.Finish(batchID, true)
 			return
 		default:
 		}
@@ -834,8 +2062,15 @@ func ExecuteBatchAsync(ctx context.Context, client *client.Client, commands []ma
 		result, err := executeBatchCommand(asyncCtx, client, action, targetID, value, duration)
 		if err != nil {
 			log.Printf("Batch %s - Command %d (%s) failed: %v", batchID, i, action, err)
+			globalBatchRegistry.Record(batchID, BatchResult{
+				Index:   i,
+				Success: false,
+				Message: fmt.Sprintf("Command %d (%s): %v", i, action, err),
+				Error:   err,
+			})
 		} else {
 			log.Printf("Batch %s - Command %d: %s", batchID, i, result)
+			globalBatchRegistry.Record(batchID, BatchResult{Index: i, Success: true, Message: result})
 		}
 		
 		// Add delay between commands (except for the last one)
@@ -845,4 +2080,119 @@ func ExecuteBatchAsync(ctx context.Context, client *client.Client, commands []ma
 	}
 	
 	log.Printf("Batch %s completed", batchID)
-}
\ No newline at end of file
+	globalBatchRegistry.Finish(batchID, false)
+}
+
+// batchStep is a set of commands that share an at_ms offset from the start of a parallel batch
+type batchStep struct {
+	at      time.Duration
+	indexes []int
+}
+
+// batchSteps groups commands by their at_ms offset (default 0), in time order
+func batchSteps(commands []map[string]interface{}) []batchStep {
+	byOffset := make(map[time.Duration][]int)
+	for i, cmd := range commands {
+		at := time.Duration(0)
+		if ms, ok := cmd["at_ms"].(float64); ok && ms > 0 {
+			at = time.Duration(ms) * time.Millisecond
+		}
+		byOffset[at] = append(byOffset[at], i)
+	}
+	
+	steps := make([]batchStep, 0, len(byOffset))
+	for at, indexes := range byOffset {
+		steps = append(steps, batchStep{at: at, indexes: indexes})
+	}
+	sort.Slice(steps, func(i, j int) bool {
+		return steps[i].at < steps[j].at
+	})
+	return steps
+}
+
+// executeBatchParallel fires each step's commands concurrently, waiting for a step to
+// finish before the next. The client's rate limiter bounds how fast they reach the bridge.
+// Cancelling ctx stops the batch between steps; commands already sent are left to finish.
+// It returns false if ctx was cancelled before every step ran.
+func executeBatchParallel(ctx context.Context, hueClient *client.Client, commands []map[string]interface{}, record func(BatchResult)) bool {
+	start := time.Now()
+	runCtx := context.WithoutCancel(ctx)
+	
+	for _, step := range batchSteps(commands) {
+		if ctx.Err() != nil {
+			return false
+		}
+		if wait := step.at - time.Since(start); wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return false
+			}
+		}
+		
+		var wg sync.WaitGroup
+		for _, i := range step.indexes {
+			wg.Add(1)
+			go func(i int, cmd map[string]interface{}) {
+				defer wg.Done()
+				
+				action, _ := cmd["action"].(string)
+				targetID, _ := cmd["target_id"].(string)
+				value, _ := cmd["value"].(string)
+				duration := 0
+				if d, ok := cmd["duration"].(float64); ok {
+					duration = int(d)
+				}
+				
+				result, err := executeBatchCommand(runCtx, hueClient, action, targetID, value, duration)
+				if err != nil {
+					record(BatchResult{Index: i, Message: fmt.Sprintf("Command %d (%s): %v", i, action, err), Error: err})
+				} else {
+					record(BatchResult{Index: i, Success: true, Message: result})
+				}
+			}(i, commands[i])
+		}
+		wg.Wait()
+	}
+	
+	return true
+}
+
+// ExecuteBatchParallel runs a batch in parallel steps and returns results in command order
+func ExecuteBatchParallel(ctx context.Context, client *client.Client, commands []map[string]interface{}) []BatchResult {
+	var mu sync.Mutex
+	var results []BatchResult
+	
+	executeBatchParallel(ctx, client, commands, func(result BatchResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		results = append(results, result)
+	})
+	
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Index < results[j].Index
+	})
+	return results
+}
+
+// ExecuteBatchParallelAsync runs a parallel batch in the background, recording results for get_batch_status
+func ExecuteBatchParallelAsync(ctx context.Context, client *client.Client, commands []map[string]interface{}, batchID string) {
+	log.Printf("Starting parallel async batch %s with %d commands", batchID, len(commands))
+	globalBatchRegistry.Start(batchID, len(commands))
+	
+	completed := executeBatchParallel(ctx, client, commands, func(result BatchResult) {
+		if result.Success {
+			log.Printf("Batch %s - Command %d: %s", batchID, result.Index, result.Message)
+		} else {
+			log.Printf("Batch %s - %s", batchID, result.Message)
+		}
+		globalBatchRegistry.Record(batchID, result)
+	})
+	
+	if completed {
+		log.Printf("Batch %s completed", batchID)
+	} else {
+		log.Printf("Batch %s cancelled", batchID)
+	}
+	globalBatchRegistry.Finish(batchID, !completed)
+}
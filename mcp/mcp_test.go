@@ -1,7 +1,12 @@
 package mcp
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
 )
 
 func TestColorConversion(t *testing.T) {
@@ -14,6 +19,11 @@ func TestColorConversion(t *testing.T) {
 		{"green color name", "green", "#00FF00"},
 		{"blue color name", "blue", "#0000FF"},
 		{"mixed case", "RED", "#FF0000"},
+		{"padded", "  Red ", "#FF0000"},
+		{"css name", "turquoise", "#40E0D0"},
+		{"spaced css name", "Light Blue", "#ADD8E6"},
+		{"hyphenated css name", "lemon-chiffon", "#FFFACD"},
+		{"warm white", "warm", "#FFB46B"},
 		{"hex passthrough", "#FF00FF", ""},
 		{"invalid name", "notacolor", ""},
 	}
@@ -51,4 +61,665 @@ func TestHexColorValidation(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"named", "Red", "#FF0000", false},
+		{"long hex", "#ff8000", "#FF8000", false},
+		{"short hex", "#f80", "#FF8800", false},
+		{"rgb", "rgb(255,128,0)", "#FF8000", false},
+		{"rgb with spaces", "RGB( 0, 0, 255 )", "#0000FF", false},
+		{"hsv", "hsv(30,100,100)", "#FF8000", false},
+		{"hsv percentages", "hsv(240, 100%, 50%)", "#000080", false},
+		{"hsv full circle", "hsv(360,100,100)", "#FF0000", false},
+		{"hex without hash", "FF0000", "", true},
+		{"bad short hex", "#ggg", "", true},
+		{"rgb out of range", "rgb(256,0,0)", "", true},
+		{"rgb too few components", "rgb(255,0)", "", true},
+		{"rgb not numbers", "rgb(a,b,c)", "", true},
+		{"hsv out of range", "hsv(30,120,100)", "", true},
+		{"unknown name", "notacolor", "", true},
+		{"empty", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseColor(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseColor(%q) = %s, want error", tt.input, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseColor(%q) failed: %v", tt.input, err)
+			}
+			if result != tt.expected {
+				t.Errorf("parseColor(%q) = %s, want %s", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHandleCreateSceneCapturesLightStates(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("room", client.Room{
+		ID:       "room-1",
+		Services: []client.ResourceIdentifier{{RID: "group-1", RType: "grouped_light"}},
+		Children: []client.ResourceIdentifier{{RID: "device-1", RType: "device"}, {RID: "device-2", RType: "device"}, {RID: "device-3", RType: "device"}},
+	})
+	for i := 1; i <= 3; i++ {
+		fb.Add("device", client.Device{
+			ID:       fmt.Sprintf("device-%d", i),
+			Services: []client.ResourceIdentifier{{RID: fmt.Sprintf("light-%d", i), RType: "light"}},
+		})
+	}
+	fb.Add("light", client.Light{
+		ID:      "light-1",
+		On:      client.OnState{On: true},
+		Dimming: client.Dimming{Brightness: 75},
+		Color:   &client.Color{XY: client.XY{X: 0.6, Y: 0.3}},
+	})
+	fb.Add("light", client.Light{
+		ID:               "light-2",
+		On:               client.OnState{On: true},
+		Dimming:          client.Dimming{Brightness: 40},
+		ColorTemperature: &client.ColorTemperature{Mirek: 366, MirekValid: true},
+	})
+	fb.Add("light", client.Light{
+		ID:      "light-3",
+		On:      client.OnState{On: false},
+		Dimming: client.Dimming{Brightness: 20},
+	})
+	
+	result := callTool(t, HandleCreateScene(fb.client()), map[string]interface{}{
+		"name":     "Evening",
+		"group_id": "group-1",
+	})
+	if result.IsError {
+		t.Fatalf("create_scene failed: %s", resultText(result))
+	}
+	
+	posts := fb.RequestsFor("POST", "/clip/v2/resource/scene")
+	if len(posts) != 1 {
+		t.Fatalf("Expected 1 scene create, got %d", len(posts))
+	}
+	
+	body := posts[0].Body
+	if group := body["group"].(map[string]interface{}); group["rid"] != "room-1" || group["rtype"] != "room" {
+		t.Errorf("Expected scene group room-1, got %v", group)
+	}
+	
+	actions := map[string]map[string]interface{}{}
+	for _, a := range body["actions"].([]interface{}) {
+		action := a.(map[string]interface{})
+		target := action["target"].(map[string]interface{})["rid"].(string)
+		actions[target] = action["action"].(map[string]interface{})
+	}
+	
+	if len(actions) != 3 {
+		t.Fatalf("Expected actions for 3 lights, got %d", len(actions))
+	}
+	
+	light1 := actions["light-1"]
+	if light1["on"].(map[string]interface{})["on"] != true ||
+		light1["dimming"].(map[string]interface{})["brightness"] != 75.0 ||
+		light1["color"].(map[string]interface{})["xy"].(map[string]interface{})["x"] != 0.6 {
+		t.Errorf("Unexpected action for light-1: %v", light1)
+	}
+	
+	light2 := actions["light-2"]
+	if light2["color_temperature"].(map[string]interface{})["mirek"] != 366.0 ||
+		light2["dimming"].(map[string]interface{})["brightness"] != 40.0 {
+		t.Errorf("Unexpected action for light-2: %v", light2)
+	}
+	
+	light3 := actions["light-3"]
+	if light3["on"].(map[string]interface{})["on"] != false || light3["dimming"] != nil {
+		t.Errorf("Unexpected action for light-3: %v", light3)
+	}
+}
+
+func TestHandleActiveScene(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("room", client.Room{
+		ID:       "room-1",
+		Metadata: client.Metadata{Name: "Living Room"},
+		Services: []client.ResourceIdentifier{{RID: "group-1", RType: "grouped_light"}},
+	})
+	fb.Add("room", client.Room{
+		ID:       "room-2",
+		Metadata: client.Metadata{Name: "Kitchen"},
+		Services: []client.ResourceIdentifier{{RID: "group-2", RType: "grouped_light"}},
+	})
+	fb.Add("scene", client.Scene{
+		ID:       "scene-1",
+		Metadata: client.Metadata{Name: "Relax"},
+		Group:    client.ResourceIdentifier{RID: "room-1", RType: "room"},
+		Status:   &client.SceneStatus{Active: "static"},
+	})
+	handler := HandleActiveScene(fb.client())
+	
+	result := callTool(t, handler, map[string]interface{}{"group_id": "Living Room"})
+	if result.IsError || !strings.Contains(resultText(result), "Relax") {
+		t.Errorf("Expected Relax to be reported active, got: %s", resultText(result))
+	}
+	
+	result = callTool(t, handler, map[string]interface{}{"group_id": "Kitchen"})
+	if result.IsError || !strings.Contains(resultText(result), "No active scene") {
+		t.Errorf("Expected no active scene for the kitchen, got: %s", resultText(result))
+	}
+}
+
+func TestHandleGetScene(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("room", client.Room{ID: "room-1", Metadata: client.Metadata{Name: "Living Room"}})
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk Lamp"}})
+	fb.Add("light", client.Light{ID: "light-2", Metadata: client.Metadata{Name: "Ceiling"}})
+	fb.Add("scene", client.Scene{
+		ID:       "scene-1",
+		Metadata: client.Metadata{Name: "Relax"},
+		Group:    client.ResourceIdentifier{RID: "room-1", RType: "room"},
+		Actions: []client.SceneAction{
+			{
+				Target: client.ResourceIdentifier{RID: "light-1", RType: "light"},
+				Action: client.LightUpdate{
+					On:               &client.OnState{On: true},
+					Dimming:          &client.Dimming{Brightness: 60},
+					ColorTemperature: &client.ColorTemperature{Mirek: 370},
+				},
+			},
+			{
+				Target: client.ResourceIdentifier{RID: "light-2", RType: "light"},
+				Action: client.LightUpdate{On: &client.OnState{On: false}},
+			},
+		},
+	})
+	
+	result := callTool(t, HandleGetScene(fb.client()), map[string]interface{}{"scene_id": "Relax"})
+	if result.IsError {
+		t.Fatalf("get_scene failed: %s", resultText(result))
+	}
+	
+	text := resultText(result)
+	for _, want := range []string{
+		"Group: Living Room (room-1) [room]",
+		"- Desk Lamp (light-1): on, 60%, 370 mirek (2703K)",
+		"- Ceiling (light-2): off",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in:\n%s", want, text)
+		}
+	}
+}
+
+func TestHandleScenesUsingLight(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("room", client.Room{
+		ID:       "room-1",
+		Metadata: client.Metadata{Name: "Living Room"},
+		Services: []client.ResourceIdentifier{{RID: "group-1", RType: "grouped_light"}},
+		Children: []client.ResourceIdentifier{{RID: "light-1", RType: "light"}},
+	})
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk Lamp"}})
+	fb.Add("light", client.Light{ID: "light-2", Metadata: client.Metadata{Name: "Porch"}})
+	room := client.ResourceIdentifier{RID: "room-1", RType: "room"}
+	fb.Add("scene", client.Scene{ID: "scene-1", Metadata: client.Metadata{Name: "Relax"}, Group: room, Actions: []client.SceneAction{
+		{Target: client.ResourceIdentifier{RID: "light-1", RType: "light"}},
+	}})
+	fb.Add("scene", client.Scene{ID: "scene-2", Metadata: client.Metadata{Name: "All Warm"}, Group: room, Actions: []client.SceneAction{
+		{Target: client.ResourceIdentifier{RID: "group-1", RType: "grouped_light"}},
+	}})
+	fb.Add("scene", client.Scene{ID: "scene-3", Metadata: client.Metadata{Name: "Porch Only"}, Group: room, Actions: []client.SceneAction{
+		{Target: client.ResourceIdentifier{RID: "light-2", RType: "light"}},
+	}})
+	
+	result := callTool(t, HandleScenesUsingLight(fb.client()), map[string]interface{}{"light_id": "Desk Lamp"})
+	text := resultText(result)
+	if result.IsError {
+		t.Fatalf("scenes_using_light failed: %s", text)
+	}
+	for _, want := range []string{
+		"2 scenes use light Desk Lamp (light-1)",
+		"- Relax (ID: scene-1) in Living Room\n",
+		"- All Warm (ID: scene-2) in Living Room, through group Living Room",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "Porch Only") {
+		t.Errorf("Expected a scene that doesn't use the light to be left out:\n%s", text)
+	}
+}
+
+func TestHandleScenesUsingLightReportsGroupLookupFailure(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk Lamp"}})
+	fb.Add("scene", client.Scene{ID: "scene-1", Metadata: client.Metadata{Name: "Relax"}, Actions: []client.SceneAction{
+		{Target: client.ResourceIdentifier{RID: "group-gone", RType: "grouped_light"}},
+	}})
+	
+	result := callTool(t, HandleScenesUsingLight(fb.client()), map[string]interface{}{"light_id": "light-1"})
+	text := resultText(result)
+	if !result.IsError || strings.Contains(text, "No scenes use light") {
+		t.Fatalf("Expected a failed group lookup to be reported, got: %s", text)
+	}
+}
+
+func TestHandleActivateSceneDynamicMode(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("scene", client.Scene{
+		ID: "scene-galaxy",
+		Palette: &client.ScenePalette{Color: []client.PaletteColor{
+			{Color: client.Color{XY: client.XY{X: 0.2, Y: 0.1}}},
+			{Color: client.Color{XY: client.XY{X: 0.4, Y: 0.2}}},
+		}},
+	})
+	fb.Add("scene", client.Scene{ID: "scene-plain"})
+	
+	result := callTool(t, HandleActivateScene(fb.client()), map[string]interface{}{
+		"scene_id": "scene-galaxy",
+		"mode":     "dynamic",
+	})
+	if result.IsError || !strings.Contains(resultText(result), "in dynamic mode") {
+		t.Fatalf("Expected dynamic activation, got: %s", resultText(result))
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/scene/scene-galaxy")
+	if len(puts) != 1 || puts[0].Body["recall"].(map[string]interface{})["action"] != "dynamic_palette" {
+		t.Fatalf("Expected a dynamic_palette recall, got %v", puts)
+	}
+	
+	result = callTool(t, HandleActivateScene(fb.client()), map[string]interface{}{
+		"scene_id": "scene-plain",
+		"mode":     "dynamic",
+	})
+	if !strings.Contains(resultText(result), "not applied") {
+		t.Errorf("Expected dynamic mode to be reported as not applied, got: %s", resultText(result))
+	}
+}
+
+func TestHandleListEffects(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{
+		ID:       "light-1",
+		Metadata: client.Metadata{Name: "Lamp"},
+		Effects:  &client.Effects{Status: "candle", EffectValues: []string{"no_effect", "candle", "fire"}},
+	})
+	fb.Add("light", client.Light{
+		ID:       "light-2",
+		Metadata: client.Metadata{Name: "Strip"},
+		Effects:  &client.Effects{Status: "no_effect", EffectValues: []string{"no_effect", "sparkle"}},
+	})
+	fb.Add("light", client.Light{ID: "light-3", Metadata: client.Metadata{Name: "Plain"}})
+	
+	result := callTool(t, HandleListEffects(fb.client()), map[string]interface{}{"light_id": "light-1"})
+	text := resultText(result)
+	if result.IsError {
+		t.Fatalf("list_effects failed: %s", text)
+	}
+	if !strings.Contains(text, "Active effect: candle") || !strings.Contains(text, "- fire: Simulates a cozy fireplace") {
+		t.Errorf("Expected the light's effects and active effect, got:\n%s", text)
+	}
+	if strings.Contains(text, "sparkle") {
+		t.Errorf("Expected only this light's effects, got:\n%s", text)
+	}
+	
+	result = callTool(t, HandleListEffects(fb.client()), nil)
+	text = resultText(result)
+	for _, effect := range []string{"candle", "fire", "sparkle", "no_effect"} {
+		if !strings.Contains(text, "- "+effect+":") {
+			t.Errorf("Expected %s in the union, got:\n%s", effect, text)
+		}
+	}
+	
+	result = callTool(t, HandleListEffects(fb.client()), map[string]interface{}{"light_id": "light-3"})
+	if text := resultText(result); !strings.Contains(text, "does not support effects") {
+		t.Errorf("Expected an unsupported note, got:\n%s", text)
+	}
+}
+
+func TestHandleBatchCommandsRejectsBadCommandsUpFront(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Lamp"}})
+	
+	result := callTool(t, HandleBatchCommands(fb.client()), map[string]interface{}{
+		"commands": `[{"action":"light_on","target_id":"light-1"},` +
+			`{"action":"light_blink","target_id":"light-1"},` +
+			`{"action":"light_brightness","target_id":"light-1","value":"150"},` +
+			`{"action":"light_color","value":"red"}]`,
+		"async": true,
+	})
+	if !result.IsError {
+		t.Fatal("Expected an invalid batch to fail synchronously")
+	}
+	
+	text := resultText(result)
+	for _, want := range []string{
+		`command 1: unknown action "light_blink"`,
+		"command 2 (light_brightness): brightness must be a number between 0 and 100",
+		"command 3 (light_color): target_id is required",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in error, got:\n%s", want, text)
+		}
+	}
+	
+	// Give a stray goroutine time to misbehave before checking nothing ran
+	time.Sleep(50 * time.Millisecond)
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/"); len(puts) != 0 {
+		t.Errorf("Expected no commands to run, got %d PUTs", len(puts))
+	}
+}
+
+func TestHandleBatchCommandsParallel(t *testing.T) {
+	fb := newFakeBridge(t)
+	for i := 1; i <= 3; i++ {
+		fb.Add("light", client.Light{ID: fmt.Sprintf("light-%d", i)})
+	}
+	
+	result := callTool(t, HandleBatchCommands(fb.client()), map[string]interface{}{
+		"commands": `[{"action":"light_on","target_id":"light-1"},` +
+			`{"action":"light_on","target_id":"light-2"},` +
+			`{"action":"light_off","target_id":"light-3","at_ms":250}]`,
+		"delay_ms": float64(300),
+		"parallel": true,
+		"async":    false,
+	})
+	if result.IsError || !strings.Contains(resultText(result), "3 successful, 0 failed") {
+		t.Fatalf("Expected the parallel batch to succeed, got: %s", resultText(result))
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/")
+	if len(puts) != 3 {
+		t.Fatalf("Expected 3 PUTs, got %d", len(puts))
+	}
+	
+	times := map[string]time.Time{}
+	for _, put := range puts {
+		times[strings.TrimPrefix(put.Path, "/clip/v2/resource/light/")] = put.Time
+	}
+	
+	// The first two share a step, so delay_ms does not stagger them
+	if gap := times["light-2"].Sub(times["light-1"]); gap > 100*time.Millisecond || gap < -100*time.Millisecond {
+		t.Errorf("Expected light-1 and light-2 to change together, %s apart", gap)
+	}
+	if gap := times["light-3"].Sub(times["light-1"]); gap < 150*time.Millisecond {
+		t.Errorf("Expected light-3 to wait for its at_ms offset, fired after %s", gap)
+	}
+}
+
+func TestBatchStepsGroupsByOffset(t *testing.T) {
+	steps := batchSteps([]map[string]interface{}{
+		{"action": "light_on", "at_ms": float64(500)},
+		{"action": "light_on"},
+		{"action": "light_on", "at_ms": float64(500)},
+		{"action": "light_on", "at_ms": float64(0)},
+	})
+	
+	if len(steps) != 2 {
+		t.Fatalf("Expected 2 steps, got %d", len(steps))
+	}
+	if steps[0].at != 0 || fmt.Sprint(steps[0].indexes) != "[1 3]" {
+		t.Errorf("Expected first step at 0 with commands 1 and 3, got %+v", steps[0])
+	}
+	if steps[1].at != 500*time.Millisecond || fmt.Sprint(steps[1].indexes) != "[0 2]" {
+		t.Errorf("Expected second step at 500ms with commands 0 and 2, got %+v", steps[1])
+	}
+}
+
+func TestHandleBatchCommandsResolvesNames(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk Lamp"}})
+	fb.Add("light", client.Light{ID: "light-2", Metadata: client.Metadata{Name: "Hall Lamp"}})
+	
+	result := callTool(t, HandleBatchCommands(fb.client()), map[string]interface{}{
+		"commands": `[{"action":"light_on","target_id":"Desk Lamp"},` +
+			`{"action":"light_brightness","target_id":"Desk Lamp","value":"40"},` +
+			`{"action":"light_off","target_id":"hall"}]`,
+		"delay_ms": float64(0),
+		"async":    false,
+	})
+	if result.IsError || !strings.Contains(resultText(result), "3 successful") {
+		t.Fatalf("Expected the named batch to succeed, got: %s", resultText(result))
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/")
+	if len(puts) != 3 || puts[0].Path != "/clip/v2/resource/light/light-1" || puts[2].Path != "/clip/v2/resource/light/light-2" {
+		t.Errorf("Expected PUTs to the resolved light IDs, got %+v", puts)
+	}
+	
+	// Repeated names are looked up once
+	if gets := fb.RequestsFor("GET", "/clip/v2/resource/light"); len(gets) != 2 {
+		t.Errorf("Expected one lookup per distinct name, got %d", len(gets))
+	}
+	
+	result = callTool(t, HandleBatchCommands(fb.client()), map[string]interface{}{
+		"commands": `[{"action":"light_on","target_id":"Lamp"}]`,
+	})
+	text := resultText(result)
+	if !result.IsError || !strings.Contains(text, "multiple lights match 'Lamp'") || !strings.Contains(text, "Hall Lamp (ID: light-2)") {
+		t.Errorf("Expected an ambiguity error listing the matches, got:\n%s", text)
+	}
+}
+
+func TestHandleGetGroupState(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("room", client.Room{
+		ID:       "room-1",
+		Metadata: client.Metadata{Name: "Lounge"},
+		Services: []client.ResourceIdentifier{{RID: "group-1", RType: "grouped_light"}},
+		Children: []client.ResourceIdentifier{{RID: "device-1", RType: "device"}},
+	})
+	fb.Add("device", client.Device{ID: "device-1", Services: []client.ResourceIdentifier{
+		{RID: "light-1", RType: "light"},
+		{RID: "light-2", RType: "light"},
+		{RID: "light-3", RType: "light"},
+	}})
+	fb.Add("grouped_light", client.Group{
+		ID:               "group-1",
+		Owner:            &client.ResourceIdentifier{RID: "room-1", RType: "room"},
+		On:               client.OnState{On: true},
+		Dimming:          client.Dimming{Brightness: 65},
+		ColorTemperature: &client.ColorTemperature{Mirek: 300, MirekValid: true},
+	})
+	fb.Add("light", client.Light{ID: "light-1", On: client.OnState{On: true}})
+	fb.Add("light", client.Light{ID: "light-2", On: client.OnState{On: true}})
+	fb.Add("light", client.Light{ID: "light-3"})
+	
+	result := callTool(t, HandleGetGroupState(fb.client()), map[string]interface{}{"group_id": "lounge"})
+	text := resultText(result)
+	if result.IsError {
+		t.Fatalf("get_group_state failed: %s", text)
+	}
+	for _, expected := range []string{
+		"Group: Lounge",
+		"Type: room",
+		"On: true",
+		"Brightness: 65%",
+		"Color Temperature: 300 mirek (3333K)",
+		"Lights: 3 (2 on)",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, text)
+		}
+	}
+}
+
+func TestHandleGetZoneState(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("room", client.Room{
+		ID:       "room-1",
+		Metadata: client.Metadata{Name: "Downstairs"},
+		Services: []client.ResourceIdentifier{{RID: "room-group", RType: "grouped_light"}},
+	})
+	fb.Add("zone", client.Zone{
+		ID:       "zone-1",
+		Metadata: client.Metadata{Name: "Downstairs"},
+		Services: []client.ResourceIdentifier{{RID: "zone-group", RType: "grouped_light"}},
+		Children: []client.ResourceIdentifier{{RID: "light-1", RType: "light"}, {RID: "light-2", RType: "light"}},
+	})
+	fb.Add("grouped_light", client.Group{
+		ID:      "zone-group",
+		Owner:   &client.ResourceIdentifier{RID: "zone-1", RType: "zone"},
+		On:      client.OnState{On: true},
+		Dimming: client.Dimming{Brightness: 40},
+	})
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Hall"}, On: client.OnState{On: true}, Dimming: client.Dimming{Brightness: 40}})
+	fb.Add("light", client.Light{ID: "light-2", Metadata: client.Metadata{Name: "Stairs"}})
+	
+	result := callTool(t, HandleGetZoneState(fb.client()), map[string]interface{}{"zone_id": "downstairs"})
+	text := resultText(result)
+	if result.IsError {
+		t.Fatalf("get_zone_state failed: %s", text)
+	}
+	for _, expected := range []string{
+		"Type: zone",
+		"Brightness: 40%",
+		"Lights: 2 (1 on)",
+		"- Hall (light-1): on, 40%",
+		"- Stairs (light-2): off",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, text)
+		}
+	}
+	
+	if result := callTool(t, HandleGetZoneState(fb.client()), map[string]interface{}{"zone_id": "room-1"}); !result.IsError {
+		t.Error("Expected a room to be rejected as a zone")
+	}
+}
+
+func TestParseXY(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    client.XY
+		wantErr bool
+	}{
+		{"0.45,0.41", client.XY{X: 0.45, Y: 0.41}, false},
+		{" 0.1 , 0.9 ", client.XY{X: 0.1, Y: 0.9}, false},
+		{"0,1", client.XY{X: 0, Y: 1}, false},
+		{"1.2,0.4", client.XY{}, true},
+		{"-0.1,0.4", client.XY{}, true},
+		{"0.4", client.XY{}, true},
+		{"0.4,abc", client.XY{}, true},
+	}
+	
+	for _, tt := range tests {
+		got, err := parseXY(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseXY(%q) = %v, want error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseXY(%q) = %v, %v; want %v", tt.input, got, err, tt.want)
+		}
+	}
+}
+
+func TestHandleLightColorWithXY(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Color: &client.Color{}})
+	
+	result := callTool(t, HandleLightColor(fb.client()), map[string]interface{}{"light_id": "light-1", "xy": "0.45,0.41"})
+	if result.IsError {
+		t.Fatalf("light_color with xy failed: %s", resultText(result))
+	}
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1")
+	if len(puts) != 1 || fmt.Sprint(puts[0].Body["color"]) != "map[xy:map[x:0.45 y:0.41]]" {
+		t.Errorf("Expected the exact xy to be sent, got %+v", puts)
+	}
+	
+	result = callTool(t, HandleGroupColor(fb.client()), map[string]interface{}{"group_id": "group-1", "xy": "0.45,0.41", "color": "red"})
+	if !result.IsError {
+		t.Error("Expected color and xy together to be rejected")
+	}
+}
+
+func TestHandleLightColorClampsToGamut(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Color: &client.Color{GamutType: "B"}})
+	
+	result := callTool(t, HandleLightColor(fb.client()), map[string]interface{}{"light_id": "light-1", "color": "#00FF00"})
+	if result.IsError {
+		t.Fatalf("light_color failed: %s", resultText(result))
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1")
+	if len(puts) != 1 {
+		t.Fatalf("Expected a single PUT, got %d", len(puts))
+	}
+	x, y := client.HexToXY("#00FF00")
+	if fmt.Sprint(puts[0].Body["color"]) == fmt.Sprint(map[string]interface{}{"xy": map[string]interface{}{"x": x, "y": y}}) {
+		t.Errorf("Expected green to be clamped to gamut B, got the unclamped %v", puts[0].Body["color"])
+	}
+}
+
+func TestHandleIdentifyGroupStaggers(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("room", client.Room{
+		ID:       "room-1",
+		Metadata: client.Metadata{Name: "Lounge"},
+		Services: []client.ResourceIdentifier{{RID: "group-1", RType: "grouped_light"}},
+		Children: []client.ResourceIdentifier{{RID: "device-1", RType: "device"}},
+	})
+	fb.Add("device", client.Device{ID: "device-1", Services: []client.ResourceIdentifier{
+		{RID: "light-1", RType: "light"},
+		{RID: "light-2", RType: "light"},
+	}})
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Lamp"}})
+	fb.Add("light", client.Light{ID: "light-2", Metadata: client.Metadata{Name: "Shelf"}})
+	
+	result := callTool(t, HandleIdentifyGroup(fb.client()), map[string]interface{}{"group_id": "Lounge", "stagger_ms": 100.0})
+	text := resultText(result)
+	if result.IsError || !strings.Contains(text, "1. Lamp (light-1)") || !strings.Contains(text, "2. Shelf (light-2)") {
+		t.Fatalf("Expected both lights listed in blink order, got:\n%s", text)
+	}
+	
+	// The wave carries on after the call returns
+	deadline := time.Now().Add(2 * time.Second)
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/")
+	for len(puts) < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		puts = fb.RequestsFor("PUT", "/clip/v2/resource/light/")
+	}
+	if len(puts) != 2 {
+		t.Fatalf("Expected an alert per light, got %d", len(puts))
+	}
+	if fmt.Sprint(puts[0].Body["alert"]) != "map[action:breathe]" {
+		t.Errorf("Expected a breathe alert, got %v", puts[0].Body)
+	}
+	if gap := puts[1].Time.Sub(puts[0].Time); gap < 80*time.Millisecond {
+		t.Errorf("Expected the lights to be staggered, gap was %s", gap)
+	}
+}
+
+func TestHandleListLightsByRoom(t *testing.T) {
+	fb := newMixedRoom(t)
+	fb.Add("light", client.Light{ID: "light-9", Metadata: client.Metadata{Name: "Garden"}, On: client.OnState{On: true}, Dimming: client.Dimming{Brightness: 25}})
+	
+	result := callTool(t, HandleListLightsByRoom(fb.client()), nil)
+	text := resultText(result)
+	if result.IsError {
+		t.Fatalf("list_lights_by_room failed: %s", text)
+	}
+	for _, expected := range []string{
+		"4 lights in 1 rooms",
+		"Living Room (3 lights):",
+		"(ID: light-2)",
+		"Unassigned (1 lights):\n  - Garden: on, brightness: 25% (ID: light-9)",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, text)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Preview duration defaults and limits in milliseconds
+const (
+	defaultPreviewMs = 5000
+	maxPreviewMs     = 5 * 60 * 1000
+)
+
+// scenePreview is a scene that has been applied temporarily and will revert when its timer fires
+type scenePreview struct {
+	ID        string
+	SceneName string
+	Snapshot  client.Snapshot
+	Expires   time.Time
+	client    *client.Client // The bridge the scene was applied on
+	stop      chan struct{}
+}
+
+// PreviewRegistry tracks scene previews that are waiting to revert
+type PreviewRegistry struct {
+	previews map[string]*scenePreview
+	mu       sync.Mutex
+}
+
+// Global preview registry instance
+var globalPreviewRegistry = &PreviewRegistry{
+	previews: make(map[string]*scenePreview),
+}
+
+// take removes and returns a preview, so only one of the timer and stop_preview acts on it
+func (r *PreviewRegistry) take(previewID string) (*scenePreview, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	preview, exists := r.previews[previewID]
+	if exists {
+		delete(r.previews, previewID)
+	}
+	return preview, exists
+}
+
+func (r *PreviewRegistry) add(preview *scenePreview) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.previews[preview.ID] = preview
+}
+
+// HandlePreviewScene applies a scene for a while and then restores the lights it changed
+func HandlePreviewScene(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		sceneID, ok := args["scene_id"].(string)
+		if !ok || sceneID == "" {
+			return mcp.NewToolResultError("scene_id is required"), nil
+		}
+
+		durationMs := defaultPreviewMs
+		if d, ok := args["duration_ms"].(float64); ok {
+			if d <= 0 || d > maxPreviewMs {
+				return mcp.NewToolResultError(fmt.Sprintf("duration_ms must be between 1 and %d", maxPreviewMs)), nil
+			}
+			durationMs = int(d)
+		}
+
+		resolvedID, err := resolveSceneID(ctx, hueClient, sceneID)
+		if err != nil {
+			return toolError("Failed to resolve scene", err), nil
+		}
+
+		scene, err := hueClient.GetScene(ctx, resolvedID)
+		if err != nil {
+			return toolError("Failed to get scene", err), nil
+		}
+
+		lightIDs, err := sceneLightIDs(ctx, hueClient, scene)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		snapshot, err := hueClient.CaptureStates(ctx, lightIDs)
+		if err != nil {
+			return toolError("Failed to capture light states", err), nil
+		}
+
+		if err := hueClient.ActivateScene(ctx, resolvedID); err != nil {
+			return toolError("Failed to activate scene", err), nil
+		}
+
+		duration := time.Duration(durationMs) * time.Millisecond
+		preview := &scenePreview{
+			ID:        fmt.Sprintf("preview_%d", time.Now().UnixNano()),
+			SceneName: scene.Metadata.Name,
+			Snapshot:  snapshot,
+			Expires:   time.Now().Add(duration),
+			client:    hueClient,
+			stop:      make(chan struct{}),
+		}
+		globalPreviewRegistry.add(preview)
+		go runPreview(preview, duration)
+
+		return mcp.NewToolResultText(fmt.Sprintf("Previewing scene '%s' on %d lights for %s (preview ID: %s)\nUse stop_preview to keep the scene, or stop_preview with restore to revert now",
+			scene.Metadata.Name, len(lightIDs), duration, preview.ID)), nil
+	}
+}
+
+// runPreview waits out a preview and restores the captured states, unless stop_preview ends it first
+func runPreview(preview *scenePreview, duration time.Duration) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		// stop_preview may have claimed the preview just as the timer fired
+		if _, exists := globalPreviewRegistry.take(preview.ID); !exists {
+			return
+		}
+		if err := preview.client.ApplyStates(context.Background(), preview.Snapshot); err != nil {
+			log.Printf("Preview %s failed to restore: %v", preview.ID, err)
+		}
+	case <-preview.stop:
+	}
+}
+
+// HandleStopPreview ends a scene preview, keeping the scene or restoring the previous states now.
+// Restoring uses the bridge the preview ran on.
+func HandleStopPreview(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		previewID, ok := args["preview_id"].(string)
+		if !ok || previewID == "" {
+			return mcp.NewToolResultError("preview_id is required"), nil
+		}
+		restore, _ := args["restore"].(bool)
+
+		preview, exists := globalPreviewRegistry.take(previewID)
+		if !exists {
+			return mcp.NewToolResultError(fmt.Sprintf("Preview %s not found (it may have already reverted)", previewID)), nil
+		}
+
+		close(preview.stop)
+
+		if restore {
+			if err := preview.client.ApplyStates(ctx, preview.Snapshot); err != nil {
+				return toolError("Preview stopped but restoring failed", err), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Preview of '%s' stopped and previous light states restored", preview.SceneName)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Keeping scene '%s'", preview.SceneName)), nil
+	}
+}
+
+// sceneLightIDs returns the lights a scene sets, falling back to its room or zone's lights
+func sceneLightIDs(ctx context.Context, hueClient *client.Client, scene *client.Scene) ([]string, error) {
+	var lightIDs []string
+	for _, action := range scene.Actions {
+		if action.Target.RType == "light" {
+			lightIDs = append(lightIDs, action.Target.RID)
+		}
+	}
+	if len(lightIDs) > 0 {
+		return lightIDs, nil
+	}
+
+	lightIDs, err := hueClient.GetGroupLights(ctx, scene.Group.RID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scene lights: %w", err)
+	}
+	if len(lightIDs) == 0 {
+		return nil, fmt.Errorf("scene %s has no lights", scene.Metadata.Name)
+	}
+	return lightIDs, nil
+}
@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+var previewIDPattern = regexp.MustCompile(`preview_\d+`)
+
+func TestPreviewSceneRevertsAfterDuration(t *testing.T) {
+	fb := newPreviewBridge(t)
+	
+	result := callTool(t, HandlePreviewScene(fb.client()), map[string]interface{}{"scene_id": "Sunset", "duration_ms": 50.0})
+	if result.IsError {
+		t.Fatalf("preview_scene failed: %s", resultText(result))
+	}
+	if len(fb.RequestsFor("PUT", "/clip/v2/resource/scene/scene-1")) != 1 {
+		t.Fatal("Expected the scene to be activated")
+	}
+	
+	deadline := time.Now().Add(2 * time.Second)
+	for len(fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1")) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the light to be restored after the preview")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	
+	previewID := previewIDPattern.FindString(resultText(result))
+	if result := callTool(t, HandleStopPreview(fb.client()), map[string]interface{}{"preview_id": previewID}); !result.IsError {
+		t.Error("Expected stopping a finished preview to fail")
+	}
+}
+
+func TestStopPreviewKeepsScene(t *testing.T) {
+	fb := newPreviewBridge(t)
+	
+	result := callTool(t, HandlePreviewScene(fb.client()), map[string]interface{}{"scene_id": "scene-1", "duration_ms": 100.0})
+	previewID := previewIDPattern.FindString(resultText(result))
+	if previewID == "" {
+		t.Fatalf("Expected a preview ID in: %s", resultText(result))
+	}
+	
+	result = callTool(t, HandleStopPreview(fb.client()), map[string]interface{}{"preview_id": previewID})
+	if result.IsError || !strings.Contains(resultText(result), "Keeping scene 'Sunset'") {
+		t.Fatalf("Expected the scene to be kept, got: %s", resultText(result))
+	}
+	
+	time.Sleep(200 * time.Millisecond)
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/"); len(puts) != 0 {
+		t.Errorf("Expected no restore after keeping the scene, got %d PUTs", len(puts))
+	}
+}
+
+func TestStopPreviewRestoresNow(t *testing.T) {
+	fb := newPreviewBridge(t)
+	
+	result := callTool(t, HandlePreviewScene(fb.client()), map[string]interface{}{"scene_id": "scene-1", "duration_ms": 60000.0})
+	previewID := previewIDPattern.FindString(resultText(result))
+	
+	result = callTool(t, HandleStopPreview(fb.client()), map[string]interface{}{"preview_id": previewID, "restore": true})
+	if result.IsError {
+		t.Fatalf("stop_preview failed: %s", resultText(result))
+	}
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1"); len(puts) != 1 {
+		t.Errorf("Expected the light to be restored immediately, got %d PUTs", len(puts))
+	}
+}
@@ -0,0 +1,293 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+// GroupPreference decides which group wins when a name matches both a room and a zone
+type GroupPreference string
+
+const (
+	GroupPreferRooms   GroupPreference = "rooms"
+	GroupPreferZones   GroupPreference = "zones"
+	GroupPreferNeither GroupPreference = "error"
+)
+
+// groupPreference is the active preference for ambiguous group names
+var groupPreference = GroupPreferRooms
+
+// SetGroupPreference sets how a name shared by a room and a zone is resolved (rooms, zones or error)
+func SetGroupPreference(preference string) error {
+	switch p := GroupPreference(strings.ToLower(preference)); p {
+	case GroupPreferRooms, GroupPreferZones, GroupPreferNeither:
+		groupPreference = p
+		return nil
+	default:
+		return fmt.Errorf("invalid group preference '%s' (expected rooms, zones or error)", preference)
+	}
+}
+
+// namedResource is a resource ID paired with its display name
+type namedResource struct {
+	ID   string
+	Name string
+	Kind string // room or zone, for groups
+	Room string // owning room or zone name, for scenes
+}
+
+// ResolveLightID takes a light name or ID and returns the light ID
+func ResolveLightID(ctx context.Context, hueClient *client.Client, nameOrID string) (string, error) {
+	return resolveLightID(ctx, hueClient, nameOrID)
+}
+
+// resolveLightID takes a light name or ID and returns the light ID
+func resolveLightID(ctx context.Context, hueClient *client.Client, nameOrID string) (string, error) {
+	if looksLikeID(nameOrID) {
+		return nameOrID, nil
+	}
+
+	lights, err := hueClient.GetLights(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get lights: %w", err)
+	}
+
+	var candidates []namedResource
+	for _, light := range lights {
+		candidates = append(candidates, namedResource{ID: light.ID, Name: light.Metadata.Name})
+	}
+
+	id, err := matchResource("light", nameOrID, candidates)
+	if err != nil {
+		return "", err
+	}
+	noteResolvedTarget(ctx, nameOrID, id)
+	return id, nil
+}
+
+// ResolveGroupID takes a room/zone name or a group ID and returns the grouped_light ID
+func ResolveGroupID(ctx context.Context, hueClient *client.Client, nameOrID string) (string, error) {
+	return resolveGroupID(ctx, hueClient, nameOrID)
+}
+
+// resolveGroupID takes a room/zone name or a group ID and returns the grouped_light ID.
+// Names shared by a room and a zone are settled by the group preference.
+func resolveGroupID(ctx context.Context, hueClient *client.Client, nameOrID string) (string, error) {
+	if looksLikeID(nameOrID) {
+		return nameOrID, nil
+	}
+
+	rooms, err := hueClient.GetRooms(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get rooms: %w", err)
+	}
+
+	zones, err := hueClient.GetZones(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get zones: %w", err)
+	}
+
+	var candidates []namedResource
+	addGroup := func(services []client.ResourceIdentifier, name, kind string) {
+		for _, service := range services {
+			if service.RType == "grouped_light" {
+				candidates = append(candidates, namedResource{ID: service.RID, Name: name, Kind: kind})
+				return
+			}
+		}
+	}
+
+	for _, room := range rooms {
+		addGroup(room.Services, room.Metadata.Name, "room")
+	}
+	for _, zone := range zones {
+		addGroup(zone.Services, zone.Metadata.Name, "zone")
+	}
+
+	id, err := matchResource("group", nameOrID, candidates)
+	if err != nil {
+		return "", err
+	}
+	noteResolvedTarget(ctx, nameOrID, id)
+	return id, nil
+}
+
+// ResolveSceneID takes a scene name or ID and returns the scene ID. A scene name shared by
+// several rooms can be narrowed with a room, as in "Nightlight:Master Bedroom".
+func ResolveSceneID(ctx context.Context, hueClient *client.Client, nameOrID string) (string, error) {
+	return resolveSceneID(ctx, hueClient, nameOrID)
+}
+
+// resolveSceneID takes a scene name or ID, optionally followed by ":Room", and returns the scene ID
+func resolveSceneID(ctx context.Context, hueClient *client.Client, nameOrID string) (string, error) {
+	if looksLikeID(nameOrID) {
+		return nameOrID, nil
+	}
+
+	scenes, err := hueClient.GetScenes(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get scenes: %w", err)
+	}
+
+	groupNames := make(map[string]string)
+	if rooms, err := hueClient.GetRooms(ctx); err == nil {
+		for _, room := range rooms {
+			groupNames[room.ID] = room.Metadata.Name
+		}
+	}
+	if zones, err := hueClient.GetZones(ctx); err == nil {
+		for _, zone := range zones {
+			groupNames[zone.ID] = zone.Metadata.Name
+		}
+	}
+
+	var candidates []namedResource
+	for _, scene := range scenes {
+		candidates = append(candidates, namedResource{ID: scene.ID, Name: scene.Metadata.Name, Room: groupNames[scene.Group.RID]})
+	}
+
+	// A room specifier narrows scenes that share a name; without a match the name alone is used
+	query := nameOrID
+	if sceneName, roomFilter, ok := strings.Cut(nameOrID, ":"); ok {
+		query = strings.TrimSpace(sceneName)
+		roomFilter = strings.ToLower(strings.TrimSpace(roomFilter))
+
+		var inRoom []namedResource
+		for _, c := range candidates {
+			if strings.EqualFold(c.Name, query) && strings.Contains(strings.ToLower(c.Room), roomFilter) {
+				inRoom = append(inRoom, c)
+			}
+		}
+		if len(inRoom) == 1 {
+			noteResolvedTarget(ctx, nameOrID, inRoom[0].ID)
+			return inRoom[0].ID, nil
+		}
+		if len(inRoom) > 1 {
+			return "", fmt.Errorf("multiple scenes match '%s':\n%s", nameOrID, formatResourceMatches(inRoom))
+		}
+	}
+
+	id, err := matchResource("scene", query, candidates)
+	if err != nil {
+		shared := 0
+		for _, c := range candidates {
+			if strings.EqualFold(c.Name, query) {
+				shared++
+			}
+		}
+		if shared > 1 {
+			return "", fmt.Errorf("%w\nSpecify the room like: '%s:Room Name'", err, query)
+		}
+		return "", err
+	}
+	noteResolvedTarget(ctx, nameOrID, id)
+	return id, nil
+}
+
+// isGroupTarget reports whether an ID refers to a grouped_light rather than a light
+func isGroupTarget(ctx context.Context, hueClient *client.Client, targetID string) (bool, error) {
+	if _, err := hueClient.GetLight(ctx, targetID); err == nil {
+		return false, nil
+	}
+	if _, err := hueClient.GetGroup(ctx, targetID); err == nil {
+		return true, nil
+	}
+	return false, fmt.Errorf("target %s is neither a light nor a group", targetID)
+}
+
+// looksLikeID reports whether the input looks like a v2 resource UUID
+func looksLikeID(nameOrID string) bool {
+	return strings.Contains(nameOrID, "-") && len(nameOrID) > 30
+}
+
+// matchResource finds a single resource by ID, exact name or partial name
+func matchResource(kind, nameOrID string, candidates []namedResource) (string, error) {
+	for _, c := range candidates {
+		if c.ID == nameOrID {
+			return c.ID, nil
+		}
+	}
+
+	var exact []namedResource
+	for _, c := range candidates {
+		if strings.EqualFold(c.Name, nameOrID) {
+			exact = append(exact, c)
+		}
+	}
+	exact = preferGroupKind(exact)
+
+	if len(exact) == 1 {
+		return exact[0].ID, nil
+	}
+	if len(exact) > 1 {
+		return "", fmt.Errorf("multiple %ss named '%s':\n%s", kind, nameOrID, formatResourceMatches(exact))
+	}
+
+	partial := preferGroupKind(containingName(candidates, nameOrID))
+
+	switch len(partial) {
+	case 0:
+		return "", fmt.Errorf("no %s found matching '%s'", kind, nameOrID)
+	case 1:
+		return partial[0].ID, nil
+	default:
+		return "", fmt.Errorf("multiple %ss match '%s':\n%s", kind, nameOrID, formatResourceMatches(partial))
+	}
+}
+
+// containingName returns the candidates whose name contains query, ignoring case
+func containingName(candidates []namedResource, query string) []namedResource {
+	var matches []namedResource
+	queryLower := strings.ToLower(query)
+	for _, c := range candidates {
+		if strings.Contains(strings.ToLower(c.Name), queryLower) {
+			matches = append(matches, c)
+		}
+	}
+	return matches
+}
+
+// preferGroupKind narrows matches spanning rooms and zones to the preferred kind.
+// Matches are returned untouched when the preference is to error on ambiguity.
+func preferGroupKind(matches []namedResource) []namedResource {
+	var preferred string
+	switch groupPreference {
+	case GroupPreferRooms:
+		preferred = "room"
+	case GroupPreferZones:
+		preferred = "zone"
+	default:
+		return matches
+	}
+
+	var kept []namedResource
+	for _, match := range matches {
+		if match.Kind == preferred {
+			kept = append(kept, match)
+		}
+	}
+
+	if len(kept) == 0 {
+		return matches
+	}
+	return kept
+}
+
+func formatResourceMatches(matches []namedResource) string {
+	var lines []string
+	for _, match := range matches {
+		if match.Kind != "" {
+			lines = append(lines, fmt.Sprintf("  - %s (%s, ID: %s)", match.Name, match.Kind, match.ID))
+			continue
+		}
+		if match.Room != "" {
+			lines = append(lines, fmt.Sprintf("  - %s (%s) [ID: %s]", match.Name, match.Room, match.ID))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  - %s (ID: %s)", match.Name, match.ID))
+	}
+	return strings.Join(lines, "\n")
+}
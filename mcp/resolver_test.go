@@ -0,0 +1,84 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func withGroupPreference(t *testing.T, preference string) {
+	previous := groupPreference
+	if err := SetGroupPreference(preference); err != nil {
+		t.Fatalf("SetGroupPreference(%s) failed: %v", preference, err)
+	}
+	t.Cleanup(func() { groupPreference = previous })
+}
+
+func TestResolveGroupIDSharedNamePreference(t *testing.T) {
+	tests := []struct {
+		preference string
+		name       string
+		expected   string
+	}{
+		{"rooms", "Downstairs", "room-group"},
+		{"zones", "Downstairs", "zone-group"},
+		{"rooms", "downst", "room-group"},
+		{"zones", "downst", "zone-group"},
+	}
+	
+	for _, test := range tests {
+		fb := newSharedNameBridge(t)
+		withGroupPreference(t, test.preference)
+		
+		id, err := resolveGroupID(context.Background(), fb.client(), test.name)
+		if err != nil {
+			t.Errorf("%s preference, %q: unexpected error: %v", test.preference, test.name, err)
+			continue
+		}
+		if id != test.expected {
+			t.Errorf("%s preference, %q: expected %s, got %s", test.preference, test.name, test.expected, id)
+		}
+	}
+}
+
+func TestResolveGroupIDSharedNameErrorPreference(t *testing.T) {
+	fb := newSharedNameBridge(t)
+	withGroupPreference(t, "error")
+	
+	_, err := resolveGroupID(context.Background(), fb.client(), "Downstairs")
+	if err == nil {
+		t.Fatal("Expected an error for a name shared by a room and a zone")
+	}
+	
+	for _, want := range []string{"room, ID: room-group", "zone, ID: zone-group"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to list %q, got: %v", want, err)
+		}
+	}
+}
+
+func TestSetGroupPreferenceInvalid(t *testing.T) {
+	if err := SetGroupPreference("houses"); err == nil {
+		t.Error("Expected error for an unknown group preference")
+	}
+}
+
+func TestResolveSceneIDRoomSpecifier(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("room", client.Room{ID: "room-1", Metadata: client.Metadata{Name: "Master Bedroom"}})
+	fb.Add("room", client.Room{ID: "room-2", Metadata: client.Metadata{Name: "Kitchen"}})
+	fb.Add("scene", client.Scene{ID: "scene-1", Metadata: client.Metadata{Name: "Nightlight"}, Group: client.ResourceIdentifier{RID: "room-1", RType: "room"}})
+	fb.Add("scene", client.Scene{ID: "scene-2", Metadata: client.Metadata{Name: "Nightlight"}, Group: client.ResourceIdentifier{RID: "room-2", RType: "room"}})
+	
+	id, err := resolveSceneID(context.Background(), fb.client(), "nightlight:master")
+	if err != nil || id != "scene-1" {
+		t.Errorf("Expected scene-1 for the master bedroom, got %q (%v)", id, err)
+	}
+	
+	_, err = resolveSceneID(context.Background(), fb.client(), "Nightlight")
+	if err == nil || !strings.Contains(err.Error(), "Kitchen") || !strings.Contains(err.Error(), "Nightlight:Room Name") {
+		t.Errorf("Expected an ambiguity error listing rooms with a hint, got: %v", err)
+	}
+}
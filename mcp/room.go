@@ -3,6 +3,7 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/kungfusheep/hue/client"
@@ -13,9 +14,32 @@ import (
 // HandleListRooms returns a handler for listing rooms
 func HandleListRooms(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		asJSON, err := listFormat(request.GetArguments())
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		rooms, err := hueClient.GetRooms(ctx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to list rooms: %v", err)), nil
+			return toolError("Failed to list rooms", err), nil
+		}
+		devices, err := hueClient.GetDevices(ctx)
+		if err != nil {
+			return toolError("Failed to list devices", err), nil
+		}
+		deviceLights := deviceLightIDs(devices)
+
+		if asJSON {
+			items := make([]listItem, 0, len(rooms))
+			for _, room := range rooms {
+				items = append(items, listItem{
+					ID:     room.ID,
+					Name:   room.Metadata.Name,
+					Type:   "room",
+					Lights: childLightIDs(room.Children, deviceLights),
+				})
+			}
+			return jsonListResult(items)
 		}
 
 		var result strings.Builder
@@ -24,10 +48,8 @@ func HandleListRooms(hueClient *client.Client) server.ToolHandlerFunc {
 			result.WriteString(fmt.Sprintf("- %s (ID: %s)\n", room.Metadata.Name, room.ID))
 			
 			// List lights in the room
-			for _, child := range room.Children {
-				if child.RType == "light" {
-					result.WriteString(fmt.Sprintf("  └─ Light: %s\n", child.RID))
-				}
+			for _, lightID := range childLightIDs(room.Children, deviceLights) {
+				result.WriteString(fmt.Sprintf("  └─ Light: %s\n", lightID))
 			}
 		}
 
@@ -35,12 +57,129 @@ func HandleListRooms(hueClient *client.Client) server.ToolHandlerFunc {
 	}
 }
 
+// HandleListLightsByRoom returns a handler listing lights nested under their rooms,
+// with lights that aren't in any room listed as unassigned
+func HandleListLightsByRoom(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		rooms, err := hueClient.GetRooms(ctx)
+		if err != nil {
+			return toolError("Failed to list rooms", err), nil
+		}
+		devices, err := hueClient.GetDevices(ctx)
+		if err != nil {
+			return toolError("Failed to list devices", err), nil
+		}
+		lights, err := hueClient.GetLights(ctx)
+		if err != nil {
+			return toolError("Failed to list lights", err), nil
+		}
+
+		deviceLights := deviceLightIDs(devices)
+		byID := make(map[string]client.Light, len(lights))
+		for _, light := range lights {
+			byID[light.ID] = light
+		}
+
+		sort.Slice(rooms, func(i, j int) bool {
+			return strings.ToLower(rooms[i].Metadata.Name) < strings.ToLower(rooms[j].Metadata.Name)
+		})
+
+		var result strings.Builder
+		assigned := make(map[string]bool)
+		for _, room := range rooms {
+			var roomLights []client.Light
+			for _, id := range childLightIDs(room.Children, deviceLights) {
+				if light, ok := byID[id]; ok && !assigned[id] {
+					assigned[id] = true
+					roomLights = append(roomLights, light)
+				}
+			}
+			writeRoomLights(&result, room.Metadata.Name, roomLights)
+		}
+
+		var unassigned []client.Light
+		for _, light := range lights {
+			if !assigned[light.ID] {
+				unassigned = append(unassigned, light)
+			}
+		}
+		if len(unassigned) > 0 {
+			writeRoomLights(&result, "Unassigned", unassigned)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("%d lights in %d rooms:\n%s", len(lights), len(rooms), result.String())), nil
+	}
+}
+
+// deviceLightIDs maps each device to the light services it owns
+func deviceLightIDs(devices []client.Device) map[string][]string {
+	deviceLights := make(map[string][]string)
+	for _, device := range devices {
+		for _, svc := range device.Services {
+			if svc.RType == "light" {
+				deviceLights[device.ID] = append(deviceLights[device.ID], svc.RID)
+			}
+		}
+	}
+	return deviceLights
+}
+
+// childLightIDs returns the lights among a room or zone's children. Rooms hold devices,
+// which are expanded to their light services; zones hold lights directly.
+func childLightIDs(children []client.ResourceIdentifier, deviceLights map[string][]string) []string {
+	var ids []string
+	for _, child := range children {
+		switch child.RType {
+		case "device":
+			ids = append(ids, deviceLights[child.RID]...)
+		case "light":
+			ids = append(ids, child.RID)
+		}
+	}
+	return ids
+}
+
+// writeRoomLights writes a room heading followed by each of its lights' on state and brightness
+func writeRoomLights(result *strings.Builder, roomName string, lights []client.Light) {
+	sort.Slice(lights, func(i, j int) bool {
+		return strings.ToLower(lights[i].Metadata.Name) < strings.ToLower(lights[j].Metadata.Name)
+	})
+
+	result.WriteString(fmt.Sprintf("%s (%d lights):\n", roomName, len(lights)))
+	for _, light := range lights {
+		status := "off"
+		if light.On.On {
+			status = fmt.Sprintf("on, brightness: %.0f%%", light.Dimming.Brightness)
+		}
+		result.WriteString(fmt.Sprintf("  - %s: %s (ID: %s)\n", light.Metadata.Name, status, light.ID))
+	}
+}
+
 // HandleListZones returns a handler for listing zones
 func HandleListZones(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		asJSON, err := listFormat(request.GetArguments())
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		zones, err := hueClient.GetZones(ctx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to list zones: %v", err)), nil
+			return toolError("Failed to list zones", err), nil
+		}
+
+		if asJSON {
+			items := make([]listItem, 0, len(zones))
+			for _, zone := range zones {
+				item := listItem{ID: zone.ID, Name: zone.Metadata.Name, Type: "zone"}
+				for _, child := range zone.Children {
+					if child.RType == "light" {
+						item.Lights = append(item.Lights, child.RID)
+					}
+				}
+				items = append(items, item)
+			}
+			return jsonListResult(items)
 		}
 
 		var result strings.Builder
@@ -53,12 +192,59 @@ func HandleListZones(hueClient *client.Client) server.ToolHandlerFunc {
 	}
 }
 
+// HandleGetZoneState returns a handler for a zone's aggregate state and member lights
+func HandleGetZoneState(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		zoneRef, ok := args["zone_id"].(string)
+		if !ok || zoneRef == "" {
+			return mcp.NewToolResultError("zone_id is required"), nil
+		}
+
+		zones, err := hueClient.GetZones(ctx)
+		if err != nil {
+			return toolError("Failed to list zones", err), nil
+		}
+
+		// Only zones are candidates, so a room sharing the name is never picked
+		var candidates []namedResource
+		var zoneID string
+		groupedLights := make(map[string]string)
+		for _, zone := range zones {
+			for _, service := range zone.Services {
+				if service.RType == "grouped_light" {
+					groupedLights[zone.ID] = service.RID
+					candidates = append(candidates, namedResource{ID: zone.ID, Name: zone.Metadata.Name, Kind: "zone"})
+					if service.RID == zoneRef {
+						zoneID = zone.ID
+					}
+					break
+				}
+			}
+		}
+
+		if zoneID == "" {
+			zoneID, err = matchResource("zone", zoneRef, candidates)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+
+		state, err := groupStateText(ctx, hueClient, groupedLights[zoneID], true)
+		if err != nil {
+			return toolError("Failed to get zone state", err), nil
+		}
+
+		return mcp.NewToolResultText(state), nil
+	}
+}
+
 // HandleListDevices returns a handler for listing devices
 func HandleListDevices(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		devices, err := hueClient.GetDevices(ctx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to list devices: %v", err)), nil
+			return toolError("Failed to list devices", err), nil
 		}
 
 		var result strings.Builder
@@ -71,8 +257,8 @@ func HandleListDevices(hueClient *client.Client) server.ToolHandlerFunc {
 			
 			if device.PowerState != nil {
 				result.WriteString(fmt.Sprintf("  Power: %s", device.PowerState.PowerState))
-				if device.PowerState.BatteryLevel > 0 {
-					result.WriteString(fmt.Sprintf(", Battery: %.0f%%", device.PowerState.BatteryLevel))
+				if level := device.PowerState.BatteryLevel; level != nil && *level > 0 {
+					result.WriteString(fmt.Sprintf(", Battery: %.0f%%", *level))
 				}
 				result.WriteString("\n")
 			}
@@ -93,7 +279,7 @@ func HandleGetDevice(hueClient *client.Client) server.ToolHandlerFunc {
 
 		device, err := hueClient.GetDevice(ctx, deviceID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to get device: %v", err)), nil
+			return toolError("Failed to get device", err), nil
 		}
 
 		var result strings.Builder
@@ -106,8 +292,8 @@ func HandleGetDevice(hueClient *client.Client) server.ToolHandlerFunc {
 		
 		if device.PowerState != nil {
 			result.WriteString(fmt.Sprintf("Power State: %s\n", device.PowerState.PowerState))
-			if device.PowerState.BatteryLevel > 0 {
-				result.WriteString(fmt.Sprintf("Battery Level: %.0f%%\n", device.PowerState.BatteryLevel))
+			if level := device.PowerState.BatteryLevel; level != nil && *level > 0 {
+				result.WriteString(fmt.Sprintf("Battery Level: %.0f%%\n", *level))
 				result.WriteString(fmt.Sprintf("Battery State: %s\n", device.PowerState.BatteryState))
 			}
 		}
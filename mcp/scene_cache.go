@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -66,8 +67,9 @@ func (sc *SceneCache) SaveScene(name string, commands []map[string]interface{},
 
 // GetScene retrieves a scene from the cache
 func (sc *SceneCache) GetScene(name string) (*CachedScene, error) {
-	sc.mu.RLock()
-	defer sc.mu.RUnlock()
+	// Write lock for the whole lookup since it mutates UsageCount
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
 
 	scene, exists := sc.scenes[name]
 	if !exists {
@@ -75,11 +77,7 @@ func (sc *SceneCache) GetScene(name string) (*CachedScene, error) {
 	}
 
 	// Increment usage count
-	sc.mu.RUnlock()
-	sc.mu.Lock()
 	scene.UsageCount++
-	sc.mu.Unlock()
-	sc.mu.RLock()
 
 	return scene, nil
 }
@@ -110,6 +108,95 @@ func (sc *SceneCache) DeleteScene(name string) error {
 	return nil
 }
 
+// snapshotLightCommands turns captured light states into batch commands that restore them
+func snapshotLightCommands(snapshot client.Snapshot) []map[string]interface{} {
+	var commands []map[string]interface{}
+	for _, state := range snapshot.Lights {
+		if !state.On {
+			commands = append(commands, map[string]interface{}{"action": "light_off", "target_id": state.LightID})
+			continue
+		}
+
+		commands = append(commands, map[string]interface{}{"action": "light_on", "target_id": state.LightID})
+		if state.Brightness > 0 {
+			commands = append(commands, map[string]interface{}{
+				"action":    "light_brightness",
+				"target_id": state.LightID,
+				"value":     strconv.FormatFloat(state.Brightness, 'f', 1, 64),
+			})
+		}
+		switch {
+		case state.Mirek > 0:
+			commands = append(commands, map[string]interface{}{
+				"action":    "light_color_temp",
+				"target_id": state.LightID,
+				"value":     strconv.Itoa(state.Mirek),
+			})
+		case state.Color != nil:
+			// Brightness is restored by the command above, so the color is kept at full
+			// intensity where the hex round trip loses the least precision
+			commands = append(commands, map[string]interface{}{
+				"action":    "light_color",
+				"target_id": state.LightID,
+				"value":     client.XYToHex(state.Color.X, state.Color.Y, 100),
+			})
+		}
+	}
+	return commands
+}
+
+// HandleSnapshotLights captures the current state of specific lights into a cached scene
+func HandleSnapshotLights(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		name, ok := args["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+
+		lightIDsJSON, ok := args["light_ids"].(string)
+		if !ok {
+			return mcp.NewToolResultError("light_ids is required"), nil
+		}
+
+		var lightIDs []string
+		if err := json.Unmarshal([]byte(lightIDsJSON), &lightIDs); err != nil {
+			return toolError("Failed to parse light_ids JSON", err), nil
+		}
+		if len(lightIDs) == 0 {
+			return mcp.NewToolResultError("light_ids must list at least one light"), nil
+		}
+
+		resolved := make([]string, len(lightIDs))
+		for i, nameOrID := range lightIDs {
+			lightID, err := resolveLightID(ctx, hueClient, nameOrID)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resolved[i] = lightID
+		}
+
+		snapshot, err := hueClient.CaptureStates(ctx, resolved)
+		if err != nil {
+			return toolError("Failed to capture light states", err), nil
+		}
+
+		description, _ := args["description"].(string)
+		if description == "" {
+			description = fmt.Sprintf("Snapshot of %d lights", len(snapshot.Lights))
+		}
+
+		commands := snapshotLightCommands(snapshot)
+		if err := globalSceneCache.SaveScene(name, commands, 0, description); err != nil {
+			return toolError("Failed to cache scene", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Snapshot '%s' saved\nLights: %d\nCommands: %d\nUse recall_scene to restore it", 
+			name, len(snapshot.Lights), len(commands))), nil
+	}
+}
+
 // HandleRecallScene executes a cached scene
 func HandleRecallScene(client *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -123,7 +210,7 @@ func HandleRecallScene(client *client.Client) server.ToolHandlerFunc {
 		// Get the cached scene
 		scene, err := globalSceneCache.GetScene(sceneName)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to recall scene: %v", err)), nil
+			return toolError("Failed to recall scene", err), nil
 		}
 
 		// Generate batch ID for tracking
@@ -191,7 +278,7 @@ func HandleClearCachedScene(client *client.Client) server.ToolHandlerFunc {
 
 		err := globalSceneCache.DeleteScene(sceneName)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to clear scene: %v", err)), nil
+			return toolError("Failed to clear scene", err), nil
 		}
 
 		return mcp.NewToolResultText(fmt.Sprintf("Scene '%s' has been cleared from cache", sceneName)), nil
@@ -210,13 +297,13 @@ func HandleExportScene(client *client.Client) server.ToolHandlerFunc {
 
 		scene, err := globalSceneCache.GetScene(sceneName)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to export scene: %v", err)), nil
+			return toolError("Failed to export scene", err), nil
 		}
 
 		// Export as JSON for sharing/backup
 		jsonData, err := json.MarshalIndent(scene, "", "  ")
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize scene: %v", err)), nil
+			return toolError("Failed to serialize scene", err), nil
 		}
 
 		return mcp.NewToolResultText(fmt.Sprintf("Scene export for '%s':\n\n```json\n%s\n```", sceneName, string(jsonData))), nil
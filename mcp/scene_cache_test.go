@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestSceneCacheGetSceneConcurrent(t *testing.T) {
+	cache := &SceneCache{scenes: make(map[string]*CachedScene)}
+	
+	commands := []map[string]interface{}{{"action": "light_on", "target_id": "light-1"}}
+	if err := cache.SaveScene("movie", commands, 100, "Movie night"); err != nil {
+		t.Fatalf("SaveScene failed: %v", err)
+	}
+	
+	const calls = 50
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cache.GetScene("movie"); err != nil {
+				t.Errorf("GetScene failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	
+	if got := cache.scenes["movie"].UsageCount; got != calls {
+		t.Errorf("Expected usage count %d, got %d", calls, got)
+	}
+}
+
+func TestSceneCacheGetSceneMissing(t *testing.T) {
+	cache := &SceneCache{scenes: make(map[string]*CachedScene)}
+	
+	if _, err := cache.GetScene("missing"); err == nil {
+		t.Error("Expected error for missing scene")
+	}
+}
+
+func TestHandleSnapshotLights(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{
+		ID:       "light-1",
+		Metadata: client.Metadata{Name: "Desk Lamp"},
+		On:       client.OnState{On: true},
+		Dimming:  client.Dimming{Brightness: 42},
+		Color:    &client.Color{XY: client.XY{X: 0.6400, Y: 0.3300}},
+	})
+	fb.Add("light", client.Light{
+		ID:       "light-2",
+		Metadata: client.Metadata{Name: "Hallway"},
+		On:       client.OnState{On: false},
+	})
+	fb.Add("light", client.Light{ID: "light-3", Metadata: client.Metadata{Name: "Not Included"}, On: client.OnState{On: true}})
+	fb.Add("light", client.Light{
+		ID:               "light-4",
+		Metadata:         client.Metadata{Name: "Reading Lamp"},
+		On:               client.OnState{On: true},
+		Dimming:          client.Dimming{Brightness: 80},
+		Color:            &client.Color{XY: client.XY{X: 0.4573, Y: 0.4100}},
+		ColorTemperature: &client.ColorTemperature{Mirek: 370, MirekValid: true},
+	})
+	hueClient := fb.client()
+	t.Cleanup(func() { globalSceneCache.DeleteScene("reading") })
+	
+	result := callTool(t, HandleSnapshotLights(hueClient), map[string]interface{}{
+		"name":      "reading",
+		"light_ids": `["light-1", "Hallway", "Reading Lamp"]`,
+	})
+	if result.IsError {
+		t.Fatalf("snapshot_lights failed: %s", resultText(result))
+	}
+	
+	scene, err := globalSceneCache.GetScene("reading")
+	if err != nil {
+		t.Fatalf("Expected snapshot in the scene cache: %v", err)
+	}
+	
+	var got []string
+	for _, cmd := range scene.Commands {
+		got = append(got, fmt.Sprintf("%s %s %v", cmd["action"], cmd["target_id"], cmd["value"]))
+	}
+	expected := []string{
+		"light_on light-1 <nil>",
+		"light_brightness light-1 42.0",
+		"light_color light-1 #FF0000",
+		"light_off light-2 <nil>",
+		"light_on light-4 <nil>",
+		"light_brightness light-4 80.0",
+		"light_color_temp light-4 370",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Unexpected snapshot commands:\n%s\nexpected:\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+	
+	// Every captured command must be replayable
+	for _, cmd := range scene.Commands {
+		value, _ := cmd["value"].(string)
+		if _, err := executeBatchCommand(context.Background(), hueClient, cmd["action"].(string), cmd["target_id"].(string), value, 0); err != nil {
+			t.Errorf("Failed to replay %v: %v", cmd, err)
+		}
+	}
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/"); len(puts) != len(scene.Commands) {
+		t.Errorf("Expected %d light PUTs on recall, got %d", len(scene.Commands), len(puts))
+	}
+}
@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// SceneIndex assigns stable 1-based numbers to bridge scenes for the lifetime of the server.
+// Scenes are numbered by name when first seen; later scenes are appended so existing
+// numbers never shift.
+type SceneIndex struct {
+	ids       []string
+	positions map[string]int
+	mu        sync.Mutex
+}
+
+// sceneIndexes keeps separate numbering per bridge client, so listing one bridge's scenes
+// never renumbers another's
+var (
+	sceneIndexes   = make(map[*client.Client]*SceneIndex)
+	sceneIndexesMu sync.Mutex
+)
+
+// sceneIndexFor returns the scene index for a bridge, creating it on first use
+func sceneIndexFor(hueClient *client.Client) *SceneIndex {
+	sceneIndexesMu.Lock()
+	defer sceneIndexesMu.Unlock()
+
+	index, ok := sceneIndexes[hueClient]
+	if !ok {
+		index = newSceneIndex()
+		sceneIndexes[hueClient] = index
+	}
+	return index
+}
+
+func newSceneIndex() *SceneIndex {
+	return &SceneIndex{positions: make(map[string]int)}
+}
+
+// Update numbers any scenes not yet in the index
+func (si *SceneIndex) Update(scenes []client.Scene) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	var unseen []client.Scene
+	for _, scene := range scenes {
+		if _, ok := si.positions[scene.ID]; !ok {
+			unseen = append(unseen, scene)
+		}
+	}
+
+	sort.Slice(unseen, func(i, j int) bool {
+		a, b := strings.ToLower(unseen[i].Metadata.Name), strings.ToLower(unseen[j].Metadata.Name)
+		if a != b {
+			return a < b
+		}
+		return unseen[i].ID < unseen[j].ID
+	})
+
+	for _, scene := range unseen {
+		si.ids = append(si.ids, scene.ID)
+		si.positions[scene.ID] = len(si.ids)
+	}
+}
+
+// Index returns the number assigned to a scene
+func (si *SceneIndex) Index(sceneID string) (int, bool) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	index, ok := si.positions[sceneID]
+	return index, ok
+}
+
+// SceneID returns the scene assigned to a number
+func (si *SceneIndex) SceneID(index int) (string, bool) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	if index < 1 || index > len(si.ids) {
+		return "", false
+	}
+	return si.ids[index-1], true
+}
+
+// HandleRecallSceneByIndex returns a handler for activating a scene by its list_scenes number
+func HandleRecallSceneByIndex(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		indexArg, ok := args["index"].(float64)
+		if !ok {
+			return mcp.NewToolResultError("index is required"), nil
+		}
+		index := int(indexArg)
+
+		scenes, err := hueClient.GetScenes(ctx)
+		if err != nil {
+			return toolError("Failed to list scenes", err), nil
+		}
+		sceneIndex := sceneIndexFor(hueClient)
+		sceneIndex.Update(scenes)
+
+		sceneID, ok := sceneIndex.SceneID(index)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("No scene numbered %d; use list_scenes to see the numbers", index)), nil
+		}
+
+		name := ""
+		for _, scene := range scenes {
+			if scene.ID == sceneID {
+				name = scene.Metadata.Name
+			}
+		}
+		if name == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("Scene %d (ID: %s) no longer exists", index, sceneID)), nil
+		}
+
+		err = hueClient.ActivateScene(ctx, sceneID)
+		if err != nil {
+			return toolError("Failed to activate scene", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Scene %d (%s) activated", index, name)), nil
+	}
+}
@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestSceneIndexStable(t *testing.T) {
+	index := newSceneIndex()
+	index.Update([]client.Scene{
+		{ID: "scene-c", Metadata: client.Metadata{Name: "Relax"}},
+		{ID: "scene-a", Metadata: client.Metadata{Name: "Bright"}},
+		{ID: "scene-b", Metadata: client.Metadata{Name: "concentrate"}},
+	})
+	
+	expected := map[string]int{"scene-a": 1, "scene-b": 2, "scene-c": 3}
+	for id, want := range expected {
+		if got, _ := index.Index(id); got != want {
+			t.Errorf("Expected %s at %d, got %d", id, want, got)
+		}
+	}
+	
+	// A new scene sorting first by name must not shift existing numbers
+	index.Update([]client.Scene{
+		{ID: "scene-b", Metadata: client.Metadata{Name: "concentrate"}},
+		{ID: "scene-0", Metadata: client.Metadata{Name: "Arctic"}},
+		{ID: "scene-c", Metadata: client.Metadata{Name: "Relax"}},
+	})
+	
+	for id, want := range expected {
+		if got, _ := index.Index(id); got != want {
+			t.Errorf("After update expected %s to stay at %d, got %d", id, want, got)
+		}
+	}
+	if got, _ := index.SceneID(4); got != "scene-0" {
+		t.Errorf("Expected new scene appended at 4, got %s", got)
+	}
+	if _, ok := index.SceneID(5); ok {
+		t.Error("Expected no scene at 5")
+	}
+}
+
+func TestHandleRecallSceneByIndex(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("scene", client.Scene{ID: "scene-relax", Metadata: client.Metadata{Name: "Relax"}})
+	fb.Add("scene", client.Scene{ID: "scene-bright", Metadata: client.Metadata{Name: "Bright"}})
+	fb.Add("scene", client.Scene{ID: "scene-dimmed", Metadata: client.Metadata{Name: "Dimmed"}})
+	hueClient := fb.client()
+	
+	list := resultText(callTool(t, HandleListScenes(hueClient), nil))
+	if !strings.Contains(list, "3. Relax: scene-relax") {
+		t.Fatalf("Expected Relax listed as scene 3, got:\n%s", list)
+	}
+	
+	result := callTool(t, HandleRecallSceneByIndex(hueClient), map[string]interface{}{"index": float64(3)})
+	if result.IsError {
+		t.Fatalf("recall_scene_by_index failed: %s", resultText(result))
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/scene/")
+	if len(puts) != 1 || puts[0].Path != "/clip/v2/resource/scene/scene-relax" {
+		t.Fatalf("Expected scene-relax to be recalled, got %+v", puts)
+	}
+	
+	result = callTool(t, HandleRecallSceneByIndex(hueClient), map[string]interface{}{"index": float64(9)})
+	if !result.IsError {
+		t.Error("Expected an error for an unknown scene number")
+	}
+}
+
+func TestSceneIndexIsPerBridge(t *testing.T) {
+	first := newFakeBridge(t)
+	first.Add("scene", client.Scene{ID: "scene-relax", Metadata: client.Metadata{Name: "Relax"}})
+	firstClient := first.client()
+	
+	second := newFakeBridge(t)
+	second.Add("scene", client.Scene{ID: "scene-arctic", Metadata: client.Metadata{Name: "Arctic"}})
+	second.Add("scene", client.Scene{ID: "scene-bright", Metadata: client.Metadata{Name: "Bright"}})
+	secondClient := second.client()
+	
+	callTool(t, HandleListScenes(firstClient), nil)
+	if list := resultText(callTool(t, HandleListScenes(secondClient), nil)); !strings.Contains(list, "1. Arctic: scene-arctic") {
+		t.Fatalf("Expected the second bridge to number its own scenes from 1, got:\n%s", list)
+	}
+	
+	result := callTool(t, HandleRecallSceneByIndex(firstClient), map[string]interface{}{"index": float64(1)})
+	if result.IsError {
+		t.Fatalf("recall_scene_by_index failed: %s", resultText(result))
+	}
+	if puts := first.RequestsFor("PUT", "/clip/v2/resource/scene/"); len(puts) != 1 || puts[0].Path != "/clip/v2/resource/scene/scene-relax" {
+		t.Errorf("Expected the first bridge's scene 1 to be recalled, got %+v", puts)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
@@ -19,6 +20,15 @@ var globalScheduler *scheduler.Scheduler
 // InitScheduler initializes the global scheduler
 func InitScheduler(client *client.Client) {
 	globalScheduler = scheduler.NewScheduler(client)
+	
+	path, err := dataFile("schedules.json")
+	if err != nil {
+		log.Printf("Schedules will not be persisted: %v", err)
+		return
+	}
+	if err := globalScheduler.EnableSchedulePersistence(path); err != nil {
+		log.Printf("Failed to load schedules from %s: %v", path, err)
+	}
 }
 
 // GetScheduler returns the global scheduler instance
@@ -26,6 +36,28 @@ func GetScheduler() *scheduler.Scheduler {
 	return globalScheduler
 }
 
+// sequenceForTarget converts a light effect into a group effect when the target is a group.
+// With onlyOn, the effect runs per light on just the target's lights that are already on.
+func sequenceForTarget(ctx context.Context, hueClient *client.Client, seq *scheduler.Sequence, targetID string, onlyOn bool) (*scheduler.Sequence, error) {
+	isGroup, err := isGroupTarget(ctx, hueClient, targetID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if onlyOn {
+		lightIDs, err := targetOnLights(ctx, hueClient, targetID, isGroup)
+		if err != nil {
+			return nil, err
+		}
+		return scheduler.CreateMultiLightEffect(seq, lightIDs), nil
+	}
+	
+	if isGroup {
+		return scheduler.CreateGroupEffect(seq, targetID), nil
+	}
+	return seq, nil
+}
+
 // HandleFlashEffect creates a flash effect
 func HandleFlashEffect(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -51,11 +83,16 @@ func HandleFlashEffect(hueClient *client.Client) server.ToolHandlerFunc {
 			flashDuration = time.Duration(fd) * time.Millisecond
 		}
 		
+		onlyOn, _ := args["only_on"].(bool)
+		
 		// Create and execute the flash effect
-		seq := scheduler.CreateFlashEffect(targetID, color, flashCount, flashDuration)
+		seq, err := sequenceForTarget(ctx, hueClient, scheduler.CreateFlashEffect(targetID, color, flashCount, flashDuration), targetID, onlyOn)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 		seqID, err := globalScheduler.ExecuteSequence(seq)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to start flash effect: %v", err)), nil
+			return toolError("Failed to start flash effect", err), nil
 		}
 		
 		return mcp.NewToolResultText(fmt.Sprintf("Flash effect started on %s\nSequence ID: %s\nColor: %s\nFlashes: %d", 
@@ -93,11 +130,16 @@ func HandlePulseEffect(hueClient *client.Client) server.ToolHandlerFunc {
 			pulseCount = int(pc)
 		}
 		
+		onlyOn, _ := args["only_on"].(bool)
+		
 		// Create and execute the pulse effect
-		seq := scheduler.CreatePulseEffect(targetID, minBrightness, maxBrightness, pulseDuration, pulseCount)
+		seq, err := sequenceForTarget(ctx, hueClient, scheduler.CreatePulseEffect(targetID, minBrightness, maxBrightness, pulseDuration, pulseCount), targetID, onlyOn)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 		seqID, err := globalScheduler.ExecuteSequence(seq)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to start pulse effect: %v", err)), nil
+			return toolError("Failed to start pulse effect", err), nil
 		}
 		
 		return mcp.NewToolResultText(fmt.Sprintf("Pulse effect started on %s\nSequence ID: %s\nBrightness: %.0f%% - %.0f%%\nPulses: %d", 
@@ -124,7 +166,7 @@ func HandleColorLoopEffect(hueClient *client.Client) server.ToolHandlerFunc {
 		
 		var colors []string
 		if err := json.Unmarshal([]byte(colorsJSON), &colors); err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to parse colors JSON: %v", err)), nil
+			return toolError("Failed to parse colors JSON", err), nil
 		}
 		
 		transitionTime := 1 * time.Second
@@ -132,11 +174,16 @@ func HandleColorLoopEffect(hueClient *client.Client) server.ToolHandlerFunc {
 			transitionTime = time.Duration(tt) * time.Millisecond
 		}
 		
+		onlyOn, _ := args["only_on"].(bool)
+		
 		// Create and execute the color loop effect
-		seq := scheduler.CreateColorLoopEffect(targetID, colors, transitionTime)
+		seq, err := sequenceForTarget(ctx, hueClient, scheduler.CreateColorLoopEffect(targetID, colors, transitionTime), targetID, onlyOn)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 		seqID, err := globalScheduler.ExecuteSequence(seq)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to start color loop: %v", err)), nil
+			return toolError("Failed to start color loop", err), nil
 		}
 		
 		return mcp.NewToolResultText(fmt.Sprintf("Color loop started on %s\nSequence ID: %s\nColors: %d\nTransition time: %v", 
@@ -144,6 +191,58 @@ func HandleColorLoopEffect(hueClient *client.Client) server.ToolHandlerFunc {
 	}
 }
 
+// HandleWhiteLoopEffect cycles a light or group through white color temperatures
+func HandleWhiteLoopEffect(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		targetID, ok := args["target_id"].(string)
+		if !ok {
+			return mcp.NewToolResultError("target_id is required"), nil
+		}
+		
+		// Default to a gentle drift between warm and neutral white
+		kelvinsJSON, ok := args["kelvins"].(string)
+		if !ok || kelvinsJSON == "" {
+			kelvinsJSON = "[2700,4000]"
+		}
+		
+		var kelvins []int
+		if err := json.Unmarshal([]byte(kelvinsJSON), &kelvins); err != nil {
+			return toolError("Failed to parse kelvins JSON", err), nil
+		}
+		if len(kelvins) < 2 {
+			return mcp.NewToolResultError("kelvins needs at least two temperatures to loop between"), nil
+		}
+		mireks := make([]int, len(kelvins))
+		for i, k := range kelvins {
+			if k < 2000 || k > 6535 {
+				return mcp.NewToolResultError("kelvin must be between 2000 and 6535"), nil
+			}
+			mireks[i] = client.KelvinToMirek(k)
+		}
+		
+		transitionTime := 30 * time.Second
+		if tt, ok := args["transition_time_ms"].(float64); ok {
+			transitionTime = time.Duration(tt) * time.Millisecond
+		}
+		
+		onlyOn, _ := args["only_on"].(bool)
+		
+		seq, err := sequenceForTarget(ctx, hueClient, scheduler.CreateColorTempLoopEffect(targetID, mireks, transitionTime), targetID, onlyOn)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		seqID, err := globalScheduler.ExecuteSequence(seq)
+		if err != nil {
+			return toolError("Failed to start white loop", err), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("White loop started on %s\nSequence ID: %s\nTemperatures: %v K\nTransition time: %v", 
+			targetID, seqID, kelvins, transitionTime)), nil
+	}
+}
+
 // HandleStrobeEffect creates a strobe effect
 func HandleStrobeEffect(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -169,11 +268,16 @@ func HandleStrobeEffect(hueClient *client.Client) server.ToolHandlerFunc {
 			duration = time.Duration(d) * time.Millisecond
 		}
 		
+		onlyOn, _ := args["only_on"].(bool)
+		
 		// Create and execute the strobe effect
-		seq := scheduler.CreateStrobeEffect(targetID, color, strobeRate, duration)
+		seq, err := sequenceForTarget(ctx, hueClient, scheduler.CreateStrobeEffect(targetID, color, strobeRate, duration), targetID, onlyOn)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 		seqID, err := globalScheduler.ExecuteSequence(seq)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to start strobe effect: %v", err)), nil
+			return toolError("Failed to start strobe effect", err), nil
 		}
 		
 		return mcp.NewToolResultText(fmt.Sprintf("Strobe effect started on %s\nSequence ID: %s\nColor: %s\nRate: %v", 
@@ -201,11 +305,16 @@ func HandleAlertEffect(hueClient *client.Client) server.ToolHandlerFunc {
 			normalColor = "#FFFFFF" // Default to white
 		}
 		
+		onlyOn, _ := args["only_on"].(bool)
+		
 		// Create and execute the alert effect
-		seq := scheduler.CreateAlertEffect(targetID, alertColor, normalColor)
+		seq, err := sequenceForTarget(ctx, hueClient, scheduler.CreateAlertEffect(targetID, alertColor, normalColor), targetID, onlyOn)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 		seqID, err := globalScheduler.ExecuteSequence(seq)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to start alert effect: %v", err)), nil
+			return toolError("Failed to start alert effect", err), nil
 		}
 		
 		return mcp.NewToolResultText(fmt.Sprintf("Alert effect started on %s\nSequence ID: %s\nAlert color: %s", 
@@ -213,6 +322,257 @@ func HandleAlertEffect(hueClient *client.Client) server.ToolHandlerFunc {
 	}
 }
 
+// HandleFadeEffect creates a smooth fade between two colors and brightness levels
+func HandleFadeEffect(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		targetID, ok := args["target_id"].(string)
+		if !ok {
+			return mcp.NewToolResultError("target_id is required"), nil
+		}
+		
+		// White fades use color temperature steps instead of blending colors
+		startKelvin, hasStartKelvin := args["start_kelvin"].(float64)
+		endKelvin, hasEndKelvin := args["end_kelvin"].(float64)
+		whiteFade := hasStartKelvin || hasEndKelvin
+		
+		colors := make([]string, 2)
+		if whiteFade {
+			if !hasStartKelvin || !hasEndKelvin {
+				return mcp.NewToolResultError("start_kelvin and end_kelvin must be given together"), nil
+			}
+			for _, k := range []float64{startKelvin, endKelvin} {
+				if k < 2000 || k > 6535 {
+					return mcp.NewToolResultError("kelvin must be between 2000 and 6535"), nil
+				}
+			}
+			colors[0], colors[1] = fmt.Sprintf("%.0fK", startKelvin), fmt.Sprintf("%.0fK", endKelvin)
+		} else {
+			for i, name := range []string{"start_color", "end_color"} {
+				color, ok := args[name].(string)
+				if !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is required (or start_kelvin and end_kelvin)", name)), nil
+				}
+				hex, err := parseColor(color)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Invalid %s: %v", name, err)), nil
+				}
+				colors[i] = hex
+			}
+		}
+		
+		startBrightness := 100.0
+		if sb, ok := args["start_brightness"].(float64); ok {
+			startBrightness = sb
+		}
+		
+		endBrightness := 100.0
+		if eb, ok := args["end_brightness"].(float64); ok {
+			endBrightness = eb
+		}
+		
+		duration := 2 * time.Second
+		if d, ok := args["duration_ms"].(float64); ok {
+			duration = time.Duration(d) * time.Millisecond
+		}
+		
+		steps := 10
+		if st, ok := args["steps"].(float64); ok && st >= 1 {
+			steps = int(st)
+		}
+		
+		onlyOn, _ := args["only_on"].(bool)
+		
+		// Create and execute the fade effect
+		var fade *scheduler.Sequence
+		if whiteFade {
+			fade = scheduler.CreateColorTempFadeEffect(targetID, int(startKelvin), int(endKelvin), startBrightness, endBrightness, duration, steps)
+		} else {
+			fade = scheduler.CreateFadeEffect(targetID, colors[0], colors[1], startBrightness, endBrightness, duration, steps)
+		}
+		seq, err := sequenceForTarget(ctx, hueClient, fade, targetID, onlyOn)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		seqID, err := globalScheduler.ExecuteSequence(seq)
+		if err != nil {
+			return toolError("Failed to start fade effect", err), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("Fade effect started on %s\nSequence ID: %s\nColor: %s → %s\nBrightness: %.0f%% → %.0f%%\nDuration: %v", 
+			targetID, seqID, colors[0], colors[1], startBrightness, endBrightness, duration)), nil
+	}
+}
+
+// HandleSunriseEffect creates a gradual wake-up sunrise
+func HandleSunriseEffect(hueClient *client.Client) server.ToolHandlerFunc {
+	return handleDaylightEffect(hueClient, "Sunrise", func(targetID string, duration time.Duration, steps int, _ float64) *scheduler.Sequence {
+		return scheduler.CreateSunriseEffect(targetID, duration, steps)
+	})
+}
+
+// HandleSunsetEffect creates a gradual fade down to a warm, dim glow
+func HandleSunsetEffect(hueClient *client.Client) server.ToolHandlerFunc {
+	return handleDaylightEffect(hueClient, "Sunset", scheduler.CreateSunsetEffect)
+}
+
+// handleDaylightEffect runs a sunrise or sunset builder from duration_minutes and steps arguments,
+// passing it the target's current brightness
+func handleDaylightEffect(hueClient *client.Client, name string, build func(string, time.Duration, int, float64) *scheduler.Sequence) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		targetID, ok := args["target_id"].(string)
+		if !ok {
+			return mcp.NewToolResultError("target_id is required"), nil
+		}
+		
+		durationMinutes := 30.0
+		if dm, ok := args["duration_minutes"].(float64); ok && dm > 0 {
+			durationMinutes = dm
+		}
+		
+		steps := 30
+		if st, ok := args["steps"].(float64); ok && st >= 1 {
+			steps = int(st)
+		}
+		
+		// Create and execute the effect
+		seq, err := sequenceForTarget(ctx, hueClient, build(targetID, time.Duration(durationMinutes*float64(time.Minute)), steps, targetBrightness(ctx, hueClient, targetID)), targetID, false)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		seqID, err := globalScheduler.ExecuteSequence(seq)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to start %s effect: %v", strings.ToLower(name), err)), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("%s effect started on %s\nSequence ID: %s\nDuration: %.0f minutes\nSteps: %d", 
+			name, targetID, seqID, durationMinutes, steps)), nil
+	}
+}
+
+// targetBrightness returns the brightness of a light or group, or 0 when it is off or can't be read
+func targetBrightness(ctx context.Context, hueClient *client.Client, targetID string) float64 {
+	if light, err := hueClient.GetLight(ctx, targetID); err == nil {
+		if !light.On.On {
+			return 0
+		}
+		return light.Dimming.Brightness
+	}
+	if group, err := hueClient.GetGroup(ctx, targetID); err == nil && group.On.On {
+		return group.Dimming.Brightness
+	}
+	return 0
+}
+
+// HandleWindDown creates a sleep wind-down on a group
+func HandleWindDown(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		groupID, ok := args["group_id"].(string)
+		if !ok || groupID == "" {
+			return mcp.NewToolResultError("group_id is required"), nil
+		}
+		
+		durationMinutes := 30.0
+		if dm, ok := args["duration_minutes"].(float64); ok && dm > 0 {
+			durationMinutes = dm
+		}
+		duration := time.Duration(durationMinutes * float64(time.Minute))
+		
+		// Start from the group's current level so lights that are already dim don't brighten
+		var brightness float64
+		var mirek int
+		if group, err := hueClient.GetGroup(ctx, groupID); err == nil && group.On.On {
+			brightness = group.Dimming.Brightness
+			if group.ColorTemperature != nil && group.ColorTemperature.MirekValid {
+				mirek = group.ColorTemperature.Mirek
+			}
+		}
+		
+		// Create and execute the wind down on the whole group
+		seq := scheduler.CreateGroupEffect(scheduler.CreateWindDownEffect(groupID, duration, brightness, mirek), groupID)
+		seqID, err := globalScheduler.ExecuteSequence(seq)
+		if err != nil {
+			return toolError("Failed to start wind down", err), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("Wind down started on %s\nSequence ID: %s\nDuration: %.0f minutes (lights off at the end)", 
+			groupID, seqID, durationMinutes)), nil
+	}
+}
+
+// HandleWakeUp ramps a light up in brightness while shifting from warm to cool white
+func HandleWakeUp(hueClient *client.Client) server.ToolHandlerFunc {
+	return handleColorTempRamp(hueClient, "Wake up", 2000, 4000, "end_brightness", scheduler.CreateWakeUpSequence)
+}
+
+// HandleSleepMode ramps a light down in brightness while shifting from cool to warm white, ending off
+func HandleSleepMode(hueClient *client.Client) server.ToolHandlerFunc {
+	return handleColorTempRamp(hueClient, "Sleep mode", 4000, 2000, "start_brightness", scheduler.CreateSleepSequence)
+}
+
+// handleColorTempRamp runs a wake-up or sleep builder from duration, Kelvin and brightness arguments
+func handleColorTempRamp(hueClient *client.Client, name string, defaultStartKelvin, defaultEndKelvin int, brightnessArg string,
+	build func(string, time.Duration, int, int, int, float64) *scheduler.Sequence) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		targetID, ok := args["target_id"].(string)
+		if !ok || targetID == "" {
+			return mcp.NewToolResultError("target_id is required"), nil
+		}
+		
+		durationMinutes := 30.0
+		if dm, ok := args["duration_minutes"].(float64); ok && dm > 0 {
+			durationMinutes = dm
+		}
+		
+		steps := 30
+		if st, ok := args["steps"].(float64); ok && st >= 1 {
+			steps = int(st)
+		}
+		
+		startKelvin, endKelvin := defaultStartKelvin, defaultEndKelvin
+		if k, ok := args["start_kelvin"].(float64); ok {
+			startKelvin = int(k)
+		}
+		if k, ok := args["end_kelvin"].(float64); ok {
+			endKelvin = int(k)
+		}
+		for _, k := range []int{startKelvin, endKelvin} {
+			if k < 2000 || k > 6535 {
+				return mcp.NewToolResultError("kelvin must be between 2000 and 6535"), nil
+			}
+		}
+		
+		brightness := 100.0
+		if b, ok := args[brightnessArg].(float64); ok {
+			if b < 1 || b > 100 {
+				return mcp.NewToolResultError(fmt.Sprintf("%s must be between 1 and 100", brightnessArg)), nil
+			}
+			brightness = b
+		}
+		
+		// Create and execute the ramp
+		duration := time.Duration(durationMinutes * float64(time.Minute))
+		seq, err := sequenceForTarget(ctx, hueClient, build(targetID, duration, steps, startKelvin, endKelvin, brightness), targetID, false)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		seqID, err := globalScheduler.ExecuteSequence(seq)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to start %s: %v", strings.ToLower(name), err)), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("%s started on %s\nSequence ID: %s\nDuration: %.0f minutes\nColor temperature: %dK → %dK\nBrightness: %s %.0f%%",
+			name, targetID, seqID, durationMinutes, startKelvin, endKelvin, strings.ReplaceAll(brightnessArg, "_", " "), brightness)), nil
+	}
+}
+
 // HandleStopSequence stops one or more running sequences
 func HandleStopSequence(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -223,7 +583,7 @@ func HandleStopSequence(hueClient *client.Client) server.ToolHandlerFunc {
 			// Parse JSON array of IDs
 			var sequenceIDs []string
 			if err := json.Unmarshal([]byte(sequenceIDsJSON), &sequenceIDs); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to parse sequence_ids JSON: %v", err)), nil
+				return toolError("Failed to parse sequence_ids JSON", err), nil
 			}
 			
 			// Stop all sequences
@@ -265,13 +625,62 @@ func HandleStopSequence(hueClient *client.Client) server.ToolHandlerFunc {
 		
 		err := globalScheduler.StopSequence(sequenceID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to stop sequence: %v", err)), nil
+			return toolError("Failed to stop sequence", err), nil
 		}
 		
 		return mcp.NewToolResultText(fmt.Sprintf("Sequence %s stopped", sequenceID)), nil
 	}
 }
 
+// HandleStopAllSequences stops every running sequence
+func HandleStopAllSequences(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		stopped := globalScheduler.StopAll()
+		
+		if stopped == 0 {
+			return mcp.NewToolResultText("No running sequences to stop"), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("Stopped %d running sequences", stopped)), nil
+	}
+}
+
+// HandlePauseSequence freezes a running sequence on its current command
+func HandlePauseSequence(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		sequenceID, ok := args["sequence_id"].(string)
+		if !ok || sequenceID == "" {
+			return mcp.NewToolResultError("sequence_id is required"), nil
+		}
+		
+		if err := globalScheduler.PauseSequence(sequenceID); err != nil {
+			return toolError("Failed to pause sequence", err), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("Sequence %s paused; lights hold their current state until resumed", sequenceID)), nil
+	}
+}
+
+// HandleResumeSequence continues a paused sequence from where it stopped
+func HandleResumeSequence(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		sequenceID, ok := args["sequence_id"].(string)
+		if !ok || sequenceID == "" {
+			return mcp.NewToolResultError("sequence_id is required"), nil
+		}
+		
+		if err := globalScheduler.ResumeSequence(sequenceID); err != nil {
+			return toolError("Failed to resume sequence", err), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("Sequence %s resumed", sequenceID)), nil
+	}
+}
+
 // HandleListSequences lists all sequences
 func HandleListSequences(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -284,10 +693,15 @@ func HandleListSequences(hueClient *client.Client) server.ToolHandlerFunc {
 		result := fmt.Sprintf("Active sequences (%d):\n", len(sequences))
 		for id, seq := range sequences {
 			status := "stopped"
-			if seq.Running {
+			if seq.Paused {
+				status = "paused"
+			} else if seq.Running {
 				status = "running"
 			}
 			result += fmt.Sprintf("- %s: %s [%s]\n", id, seq.Name, status)
+			if seq.Running {
+				result += fmt.Sprintf("  Progress: %s\n", seq.Progress(time.Now()))
+			}
 		}
 		
 		return mcp.NewToolResultText(result), nil
@@ -306,19 +720,277 @@ func HandleCustomSequence(hueClient *client.Client) server.ToolHandlerFunc {
 		
 		var seq scheduler.Sequence
 		if err := json.Unmarshal([]byte(sequenceJSON), &seq); err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to parse sequence JSON: %v", err)), nil
+			return toolError("Failed to parse sequence JSON", err), nil
 		}
 		
 		if seq.Name == "" {
 			seq.Name = "Custom Sequence"
 		}
 		
+		if err := validateAndResolveSequence(ctx, hueClient, &seq); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid sequence:\n%v", err)), nil
+		}
+		
+		if dryRun, _ := args["dry_run"].(bool); dryRun {
+			preview, err := dryRunSequence(ctx, hueClient, &seq)
+			if err != nil {
+				return toolError("Failed to preview sequence", err), nil
+			}
+			return mcp.NewToolResultText(preview), nil
+		}
+		
 		seqID, err := globalScheduler.ExecuteSequence(&seq)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to start custom sequence: %v", err)), nil
+			return toolError("Failed to start custom sequence", err), nil
 		}
 		
 		return mcp.NewToolResultText(fmt.Sprintf("Custom sequence started: %s\nSequence ID: %s\nCommands: %d\nLoop: %v", 
 			seq.Name, seqID, len(seq.Commands), seq.Loop)), nil
 	}
-}
\ No newline at end of file
+}
+
+// HandleScheduleSequence schedules a sequence to run at a time or on a cron expression
+func HandleScheduleSequence(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		sequenceJSON, ok := args["sequence"].(string)
+		if !ok {
+			return mcp.NewToolResultError("sequence JSON is required"), nil
+		}
+		
+		at, _ := args["at"].(string)
+		cron, _ := args["cron"].(string)
+		if (at == "") == (cron == "") {
+			return mcp.NewToolResultError("exactly one of at or cron is required"), nil
+		}
+		
+		var seq scheduler.Sequence
+		if err := json.Unmarshal([]byte(sequenceJSON), &seq); err != nil {
+			return toolError("Failed to parse sequence JSON", err), nil
+		}
+		
+		if seq.Name == "" {
+			seq.Name = "Scheduled Sequence"
+		}
+		
+		if err := validateAndResolveSequence(ctx, hueClient, &seq); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid sequence:\n%v", err)), nil
+		}
+		
+		var scheduleID string
+		var err error
+		if at != "" {
+			when, parseErr := parseScheduleTime(at, time.Now())
+			if parseErr != nil {
+				return mcp.NewToolResultError(parseErr.Error()), nil
+			}
+			scheduleID, err = globalScheduler.ScheduleAt(&seq, when)
+		} else {
+			scheduleID, err = globalScheduler.ScheduleCron(&seq, cron)
+		}
+		if err != nil {
+			return toolError("Failed to schedule sequence", err), nil
+		}
+		
+		for _, sched := range globalScheduler.GetSchedules() {
+			if sched.ID == scheduleID {
+				return mcp.NewToolResultText(fmt.Sprintf("Scheduled %s\nSchedule ID: %s\nNext run: %s", 
+					seq.Name, scheduleID, sched.NextRun.Format("Mon 2006-01-02 15:04 MST"))), nil
+			}
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("Scheduled %s\nSchedule ID: %s", seq.Name, scheduleID)), nil
+	}
+}
+
+// HandleListSchedules lists pending schedules
+func HandleListSchedules(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		schedules := globalScheduler.GetSchedules()
+		
+		if len(schedules) == 0 {
+			return mcp.NewToolResultText("No schedules"), nil
+		}
+		
+		result := fmt.Sprintf("Schedules (%d):\n", len(schedules))
+		for _, sched := range schedules {
+			when := "once"
+			if sched.Cron != "" {
+				when = fmt.Sprintf("cron '%s'", sched.Cron)
+			}
+			
+			next := "running"
+			if !sched.NextRun.IsZero() {
+				next = sched.NextRun.Format("Mon 2006-01-02 15:04 MST")
+			}
+			
+			result += fmt.Sprintf("- %s: %s [%s, next: %s]\n", sched.ID, sched.Sequence.Name, when, next)
+		}
+		
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+// HandleCancelSchedule cancels a schedule and stops any run it started
+func HandleCancelSchedule(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		
+		scheduleID, ok := args["schedule_id"].(string)
+		if !ok {
+			return mcp.NewToolResultError("schedule_id is required"), nil
+		}
+		
+		if err := globalScheduler.CancelSchedule(scheduleID); err != nil {
+			return toolError("Failed to cancel schedule", err), nil
+		}
+		
+		return mcp.NewToolResultText(fmt.Sprintf("Schedule %s cancelled", scheduleID)), nil
+	}
+}
+
+// parseScheduleTime accepts RFC3339, "2006-01-02 15:04" or "15:04" (the next occurrence) in local time
+func parseScheduleTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	
+	if t, err := time.ParseInLocation("2006-01-02 15:04", value, now.Location()); err == nil {
+		return t, nil
+	}
+	
+	if t, err := time.ParseInLocation("15:04", value, now.Location()); err == nil {
+		when := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if !when.After(now) {
+			when = when.AddDate(0, 0, 1)
+		}
+		return when, nil
+	}
+	
+	return time.Time{}, fmt.Errorf("invalid time '%s' (use HH:MM, YYYY-MM-DD HH:MM or RFC3339)", value)
+}
+
+// maxSequenceDelay bounds the delay allowed before a single command
+const maxSequenceDelay = 24 * time.Hour
+
+// validSequenceActions lists the actions supported for each command type
+var validSequenceActions = map[string][]string{
+	"light": {"on", "off", "brightness", "color", "color_temp", "effect"},
+	"group": {"on", "off", "brightness", "color", "color_temp", "effect"},
+	"scene": {"recall", "activate"},
+}
+
+// validateAndResolveSequence checks every command in a sequence, reporting problems with
+// their JSON path, and resolves target names and named colors before execution
+func validateAndResolveSequence(ctx context.Context, hueClient *client.Client, seq *scheduler.Sequence) error {
+	if len(seq.Commands) == 0 {
+		return fmt.Errorf("commands: at least one command is required")
+	}
+	
+	var problems []string
+	resolved := make(map[string]string)
+	
+	for i := range seq.Commands {
+		cmd := &seq.Commands[i]
+		path := fmt.Sprintf("commands[%d]", i)
+		
+		actions, knownType := validSequenceActions[cmd.Type]
+		if cmd.Type == "" {
+			problems = append(problems, fmt.Sprintf("%s.type: required (light, group or scene)", path))
+		} else if !knownType {
+			problems = append(problems, fmt.Sprintf("%s.type: unknown type '%s' (expected light, group or scene)", path, cmd.Type))
+		}
+		
+		if cmd.Action == "" {
+			problems = append(problems, fmt.Sprintf("%s.action: required", path))
+		} else if knownType && !containsString(actions, cmd.Action) {
+			problems = append(problems, fmt.Sprintf("%s.action: unknown %s action '%s' (expected %s)", path, cmd.Type, cmd.Action, strings.Join(actions, ", ")))
+		}
+		
+		if cmd.Delay < 0 || cmd.Delay > maxSequenceDelay {
+			problems = append(problems, fmt.Sprintf("%s.delay: %dms is out of range (0-%d)", path, cmd.Delay.Milliseconds(), maxSequenceDelay.Milliseconds()))
+		}
+		
+		switch cmd.Action {
+		case "brightness":
+			brightness, ok := cmd.Params["brightness"].(float64)
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s.params.brightness: required number", path))
+			} else if brightness < 0 || brightness > 100 {
+				problems = append(problems, fmt.Sprintf("%s.params.brightness: %v is out of range (0-100)", path, brightness))
+			}
+		case "color_temp":
+			if kelvin, ok := cmd.Params["kelvin"].(float64); ok {
+				if kelvin < 2000 || kelvin > 6535 {
+					problems = append(problems, fmt.Sprintf("%s.params.kelvin: %v is out of range (2000-6535)", path, kelvin))
+				}
+			} else if mirek, ok := cmd.Params["mirek"].(float64); !ok {
+				problems = append(problems, fmt.Sprintf("%s.params.mirek: required number (or params.kelvin)", path))
+			} else if mirek < 153 || mirek > 500 {
+				problems = append(problems, fmt.Sprintf("%s.params.mirek: %v is out of range (153-500)", path, mirek))
+			}
+		case "effect":
+			if _, ok := cmd.Params["effect"].(string); !ok {
+				problems = append(problems, fmt.Sprintf("%s.params.effect: required string (e.g. candle, no_effect)", path))
+			}
+		case "color":
+			color, ok := cmd.Params["color"].(string)
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s.params.color: required string", path))
+			} else if hex, err := parseColor(color); err != nil {
+				problems = append(problems, fmt.Sprintf("%s.params.color: invalid color '%s'", path, color))
+			} else {
+				cmd.Params["color"] = hex
+			}
+		}
+		
+		if cmd.Target == "" {
+			problems = append(problems, fmt.Sprintf("%s.target: required", path))
+			continue
+		}
+		
+		if !knownType {
+			continue
+		}
+		
+		key := cmd.Type + ":" + cmd.Target
+		if id, ok := resolved[key]; ok {
+			cmd.Target = id
+			continue
+		}
+		
+		var id string
+		var err error
+		switch cmd.Type {
+		case "light":
+			id, err = resolveLightID(ctx, hueClient, cmd.Target)
+		case "group":
+			id, err = resolveGroupID(ctx, hueClient, cmd.Target)
+		case "scene":
+			id, err = resolveSceneID(ctx, hueClient, cmd.Target)
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s.target: %v", path, err))
+			continue
+		}
+		
+		resolved[key] = id
+		cmd.Target = id
+	}
+	
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "\n"))
+	}
+	
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/kungfusheep/hue/scheduler"
+)
+
+func TestValidateAndResolveSequenceResolvesNames(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk Lamp"}})
+	fb.Add("light", client.Light{ID: "light-2", Metadata: client.Metadata{Name: "Ceiling"}})
+	
+	var seq scheduler.Sequence
+	err := json.Unmarshal([]byte(`{"name":"Test","commands":[
+		{"type":"light","action":"color","target":"desk lamp","params":{"color":"red"},"delay":1500},
+		{"type":"light","action":"off","target":"Ceiling"}
+	]}`), &seq)
+	if err != nil {
+		t.Fatalf("failed to parse sequence: %v", err)
+	}
+	
+	if err := validateAndResolveSequence(context.Background(), fb.client(), &seq); err != nil {
+		t.Fatalf("validateAndResolveSequence failed: %v", err)
+	}
+	
+	if seq.Commands[0].Target != "light-1" || seq.Commands[1].Target != "light-2" {
+		t.Errorf("Expected targets resolved to light-1 and light-2, got %s and %s", seq.Commands[0].Target, seq.Commands[1].Target)
+	}
+	
+	if seq.Commands[0].Params["color"] != "#FF0000" {
+		t.Errorf("Expected named color resolved to hex, got %v", seq.Commands[0].Params["color"])
+	}
+	
+	if seq.Commands[0].Delay != 1500*time.Millisecond {
+		t.Errorf("Expected delay of 1500ms, got %v", seq.Commands[0].Delay)
+	}
+	
+	// Both names are resolved from a single light listing each
+	if got := len(fb.RequestsFor("GET", "/clip/v2/resource/light")); got != 2 {
+		t.Errorf("Expected 2 light lookups, got %d", got)
+	}
+}
+
+func TestValidateAndResolveSequencePinpointsErrors(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk Lamp"}})
+	
+	var seq scheduler.Sequence
+	json.Unmarshal([]byte(`{"commands":[
+		{"type":"light","action":"on","target":"light-1"},
+		{"type":"light","action":"brightness","params":{"brightness":150},"delay":-5},
+		{"type":"spaceship","action":"on","target":"x"}
+	]}`), &seq)
+	
+	err := validateAndResolveSequence(context.Background(), fb.client(), &seq)
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+	
+	for _, want := range []string{
+		"commands[1].target: required",
+		"commands[1].params.brightness: 150 is out of range",
+		"commands[1].delay: -5ms is out of range",
+		"commands[2].type: unknown type 'spaceship'",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got:\n%v", want, err)
+		}
+	}
+	
+	if strings.Contains(err.Error(), "commands[0]") {
+		t.Errorf("Valid command should not be reported, got:\n%v", err)
+	}
+}
+
+func TestValidateAndResolveSequenceUnknownName(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk Lamp"}})
+	
+	seq := scheduler.Sequence{Commands: []scheduler.Command{{Type: "light", Action: "on", Target: "Garage"}}}
+	
+	err := validateAndResolveSequence(context.Background(), fb.client(), &seq)
+	if err == nil || !strings.Contains(err.Error(), "commands[0].target: no light found matching 'Garage'") {
+		t.Errorf("Expected unresolved target error, got %v", err)
+	}
+}
+
+func TestHandleFlashEffectOnGroup(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("grouped_light", map[string]interface{}{"id": "group-1", "type": "grouped_light"})
+	hueClient := fb.client()
+	
+	previous := globalScheduler
+	globalScheduler = scheduler.NewScheduler(hueClient)
+	t.Cleanup(func() {
+		globalScheduler.Stop()
+		globalScheduler = previous
+	})
+	
+	result := callTool(t, HandleFlashEffect(hueClient), map[string]interface{}{
+		"target_id":         "group-1",
+		"flash_count":       float64(1),
+		"flash_duration_ms": float64(1),
+	})
+	if result.IsError {
+		t.Fatalf("flash_effect failed: %s", resultText(result))
+	}
+	
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && len(fb.RequestsFor("PUT", "/clip/v2/resource/grouped_light/group-1")) < 3 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/grouped_light/group-1"); len(puts) != 3 {
+		t.Errorf("Expected the flash to drive the group with 3 PUTs, got %d", len(puts))
+	}
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/"); len(puts) != 0 {
+		t.Errorf("Expected no light PUTs for a group target, got %d", len(puts))
+	}
+}
+
+func TestHandleFlashEffectUnknownTarget(t *testing.T) {
+	fb := newFakeBridge(t)
+	
+	result := callTool(t, HandleFlashEffect(fb.client()), map[string]interface{}{"target_id": "nothing"})
+	if !result.IsError {
+		t.Error("Expected an error for a target that is neither a light nor a group")
+	}
+}
+
+func TestLightEffectSchedulesStop(t *testing.T) {
+	fb := newFakeBridge(t)
+	hueClient := fb.client()
+	
+	previous := globalScheduler
+	globalScheduler = scheduler.NewScheduler(hueClient)
+	t.Cleanup(func() {
+		globalScheduler.Stop()
+		globalScheduler = previous
+	})
+	
+	result := callTool(t, HandleLightEffect(hueClient), map[string]interface{}{
+		"light_id": "light-1",
+		"effect":   "candle",
+		"duration": float64(60),
+	})
+	if result.IsError {
+		t.Fatalf("light_effect failed: %s", resultText(result))
+	}
+	
+	// The stop ends the effect, so the bridge must not also fade it in over the duration
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1"); len(puts) != 1 || puts[0].Body["dynamics"] != nil {
+		t.Errorf("Expected one effect update without dynamics, got %+v", puts)
+	}
+	
+	schedules := globalScheduler.GetSchedules()
+	if len(schedules) != 1 || !strings.Contains(resultText(result), schedules[0].ID) {
+		t.Fatalf("Expected one stop schedule named in the result, got %d:\n%s", len(schedules), resultText(result))
+	}
+	cmd := schedules[0].Sequence.Commands[0]
+	if cmd.Action != "effect" || cmd.Target != "light-1" || cmd.Params["effect"] != "no_effect" {
+		t.Errorf("Expected the schedule to clear light-1's effect, got %+v", cmd)
+	}
+	if until := time.Until(schedules[0].NextRun); until < 55*time.Second || until > 60*time.Second {
+		t.Errorf("Expected the stop in about 60 seconds, got %v", until)
+	}
+	
+	result = callTool(t, HandleCancelSchedule(hueClient), map[string]interface{}{"schedule_id": schedules[0].ID})
+	if result.IsError {
+		t.Errorf("Expected the stop to be cancellable: %s", resultText(result))
+	}
+}
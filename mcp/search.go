@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// searchTypes are the resource types search_resources can be filtered to.
+// "group" covers both rooms and zones.
+var searchTypes = []string{"light", "group", "room", "zone", "scene"}
+
+// HandleSearchResources finds lights, rooms, zones and scenes whose name contains a query
+func HandleSearchResources(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		query, ok := args["query"].(string)
+		if !ok || strings.TrimSpace(query) == "" {
+			return mcp.NewToolResultError("query is required"), nil
+		}
+		query = strings.TrimSpace(query)
+
+		resourceType, _ := args["type"].(string)
+		resourceType = strings.ToLower(resourceType)
+		if resourceType != "" && !containsString(searchTypes, resourceType) {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid type '%s' (expected one of: %s)", resourceType, strings.Join(searchTypes, ", "))), nil
+		}
+		wants := func(kind string) bool {
+			return resourceType == "" || resourceType == kind ||
+				(resourceType == "group" && (kind == "room" || kind == "zone"))
+		}
+
+		var sections []string
+		total := 0
+		addSection := func(title string, matches []namedResource, describe func(namedResource) string) {
+			if len(matches) == 0 {
+				return
+			}
+			total += len(matches)
+			var section strings.Builder
+			section.WriteString(fmt.Sprintf("%s:\n", title))
+			for _, match := range matches {
+				section.WriteString(fmt.Sprintf("- %s\n", describe(match)))
+			}
+			sections = append(sections, section.String())
+		}
+
+		if wants("light") {
+			lights, err := hueClient.GetLights(ctx)
+			if err != nil {
+				return toolError("Failed to get lights", err), nil
+			}
+			var candidates []namedResource
+			for _, light := range lights {
+				candidates = append(candidates, namedResource{ID: light.ID, Name: light.Metadata.Name})
+			}
+			addSection("Lights", containingName(candidates, query), func(match namedResource) string {
+				return fmt.Sprintf("%s (ID: %s)", match.Name, match.ID)
+			})
+		}
+
+		// Group tools take the grouped_light ID, room tools the room/zone ID, so show both
+		groupIDs := make(map[string]string)
+		groupCandidates := func(id, name, kind string, services []client.ResourceIdentifier) namedResource {
+			for _, service := range services {
+				if service.RType == "grouped_light" {
+					groupIDs[id] = service.RID
+				}
+			}
+			return namedResource{ID: id, Name: name, Kind: kind}
+		}
+		describeGroup := func(match namedResource) string {
+			if groupID, ok := groupIDs[match.ID]; ok {
+				return fmt.Sprintf("%s (ID: %s, group ID: %s)", match.Name, match.ID, groupID)
+			}
+			return fmt.Sprintf("%s (ID: %s)", match.Name, match.ID)
+		}
+
+		if wants("room") {
+			rooms, err := hueClient.GetRooms(ctx)
+			if err != nil {
+				return toolError("Failed to get rooms", err), nil
+			}
+			var candidates []namedResource
+			for _, room := range rooms {
+				candidates = append(candidates, groupCandidates(room.ID, room.Metadata.Name, "room", room.Services))
+			}
+			addSection("Rooms", containingName(candidates, query), describeGroup)
+		}
+
+		if wants("zone") {
+			zones, err := hueClient.GetZones(ctx)
+			if err != nil {
+				return toolError("Failed to get zones", err), nil
+			}
+			var candidates []namedResource
+			for _, zone := range zones {
+				candidates = append(candidates, groupCandidates(zone.ID, zone.Metadata.Name, "zone", zone.Services))
+			}
+			addSection("Zones", containingName(candidates, query), describeGroup)
+		}
+
+		if wants("scene") {
+			scenes, err := hueClient.GetScenes(ctx)
+			if err != nil {
+				return toolError("Failed to get scenes", err), nil
+			}
+			var candidates []namedResource
+			for _, scene := range scenes {
+				candidates = append(candidates, namedResource{ID: scene.ID, Name: scene.Metadata.Name})
+			}
+			addSection("Scenes", containingName(candidates, query), func(match namedResource) string {
+				return fmt.Sprintf("%s (ID: %s)", match.Name, match.ID)
+			})
+		}
+
+		if total == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No resources match '%s'", query)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Found %d matches for '%s':\n%s", total, query, strings.Join(sections, ""))), nil
+	}
+}
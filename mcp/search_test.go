@@ -0,0 +1,55 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestHandleSearchResources(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Kitchen Pendant"}})
+	fb.Add("light", client.Light{ID: "light-2", Metadata: client.Metadata{Name: "Desk Lamp"}})
+	fb.Add("room", client.Room{
+		ID:       "room-1",
+		Metadata: client.Metadata{Name: "Kitchen"},
+		Services: []client.ResourceIdentifier{{RID: "group-1", RType: "grouped_light"}},
+	})
+	fb.Add("scene", client.Scene{ID: "scene-1", Metadata: client.Metadata{Name: "Kitchen Bright"}})
+	
+	handler := HandleSearchResources(fb.client())
+	
+	text := resultText(callTool(t, handler, map[string]interface{}{"query": "KITCHEN"}))
+	for _, expected := range []string{
+		"Found 3 matches for 'KITCHEN'",
+		"Kitchen Pendant (ID: light-1)",
+		"Kitchen (ID: room-1, group ID: group-1)",
+		"Kitchen Bright (ID: scene-1)",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "Desk Lamp") {
+		t.Errorf("Did not expect non-matching lights in:\n%s", text)
+	}
+	
+	text = resultText(callTool(t, handler, map[string]interface{}{"query": "kitchen", "type": "group"}))
+	if !strings.Contains(text, "Found 1 matches") || !strings.Contains(text, "Rooms:") {
+		t.Errorf("Expected only the room for type=group, got:\n%s", text)
+	}
+	if gets := fb.RequestsFor("GET", "/clip/v2/resource/scene"); len(gets) != 1 {
+		t.Errorf("Expected the type filter to skip the scene lookup, got %d scene requests", len(gets))
+	}
+	
+	text = resultText(callTool(t, handler, map[string]interface{}{"query": "garage"}))
+	if text != "No resources match 'garage'" {
+		t.Errorf("Unexpected result for no matches: %s", text)
+	}
+	
+	result := callTool(t, handler, map[string]interface{}{"query": "kitchen", "type": "sensor"})
+	if !result.IsError {
+		t.Errorf("Expected an invalid type to be rejected")
+	}
+}
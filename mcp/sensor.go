@@ -3,6 +3,7 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/kungfusheep/hue/client"
@@ -15,7 +16,7 @@ func HandleListMotionSensors(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		sensors, err := hueClient.GetMotionSensors(ctx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to list motion sensors: %v", err)), nil
+			return toolError("Failed to list motion sensors", err), nil
 		}
 
 		var result strings.Builder
@@ -42,7 +43,7 @@ func HandleListTemperatureSensors(hueClient *client.Client) server.ToolHandlerFu
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		sensors, err := hueClient.GetTemperatureSensors(ctx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to list temperature sensors: %v", err)), nil
+			return toolError("Failed to list temperature sensors", err), nil
 		}
 
 		var result strings.Builder
@@ -69,7 +70,7 @@ func HandleListLightLevelSensors(hueClient *client.Client) server.ToolHandlerFun
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		sensors, err := hueClient.GetLightLevelSensors(ctx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to list light level sensors: %v", err)), nil
+			return toolError("Failed to list light level sensors", err), nil
 		}
 
 		var result strings.Builder
@@ -96,7 +97,7 @@ func HandleListButtons(hueClient *client.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		buttons, err := hueClient.GetButtons(ctx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to list buttons: %v", err)), nil
+			return toolError("Failed to list buttons", err), nil
 		}
 
 		var result strings.Builder
@@ -118,4 +119,330 @@ func HandleListButtons(hueClient *client.Client) server.ToolHandlerFunc {
 
 		return mcp.NewToolResultText(result.String()), nil
 	}
-}
\ No newline at end of file
+}
+
+// HandleListContactSensors returns a handler for listing contact sensors (doors and windows)
+func HandleListContactSensors(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		sensors, err := hueClient.GetContactSensors(ctx)
+		if err != nil {
+			return toolError("Failed to list contact sensors", err), nil
+		}
+
+		devices, err := hueClient.GetDevices(ctx)
+		if err != nil {
+			return toolError("Failed to list devices", err), nil
+		}
+
+		deviceNames := make(map[string]string)
+		for _, device := range devices {
+			deviceNames[device.ID] = device.Metadata.Name
+		}
+
+		var result strings.Builder
+		result.WriteString(fmt.Sprintf("Found %d contact sensors:\n", len(sensors)))
+		open := 0
+		for _, sensor := range sensors {
+			name := deviceNames[sensor.Owner.RID]
+			if name == "" {
+				name = sensor.ID
+			}
+
+			status := "Unknown"
+			changed := ""
+			if sensor.ContactReport != nil {
+				status = "Closed"
+				if sensor.ContactReport.IsOpen() {
+					status = "Open"
+					open++
+				}
+				if sensor.ContactReport.Changed != "" {
+					changed = fmt.Sprintf(" since %s", sensor.ContactReport.Changed)
+				}
+			}
+			enabled := "enabled"
+			if !sensor.Enabled {
+				enabled = "disabled"
+			}
+
+			result.WriteString(fmt.Sprintf("- %s: %s%s (%s) (ID: %s)\n",
+				name, status, changed, enabled, sensor.ID))
+		}
+
+		if open > 0 {
+			result.WriteString(fmt.Sprintf("\n%d doors/windows open\n", open))
+		}
+
+		return mcp.NewToolResultText(result.String()), nil
+	}
+}
+
+// HandleGetSensor returns a handler for fetching one sensor with its reading, device and room
+func HandleGetSensor(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		sensorID, ok := args["sensor_id"].(string)
+		if !ok || sensorID == "" {
+			return mcp.NewToolResultError("sensor_id is required"), nil
+		}
+		sensorType, _ := args["type"].(string)
+
+		var owner client.ResourceIdentifier
+		var enabled = true
+		var reading []string
+
+		switch sensorType {
+		case "motion":
+			sensor, err := hueClient.GetMotionSensor(ctx, sensorID)
+			if err != nil {
+				return toolError("Failed to get motion sensor", err), nil
+			}
+			owner, enabled = sensor.Owner, sensor.Enabled
+			motion := sensor.Motion.Motion
+			if sensor.Motion.MotionReport != nil {
+				motion = sensor.Motion.MotionReport.Motion
+				reading = append(reading, fmt.Sprintf("Last changed: %s", sensor.Motion.MotionReport.Changed))
+			}
+			state := "No motion"
+			if motion {
+				state = "Motion detected"
+			}
+			reading = append([]string{fmt.Sprintf("Motion: %s", state)}, reading...)
+			if sensor.Sensitivity != nil {
+				reading = append(reading, fmt.Sprintf("Sensitivity: %d of %d", sensor.Sensitivity.Sensitivity, sensor.Sensitivity.SensitivityMax))
+			}
+		case "temperature":
+			sensor, err := hueClient.GetTemperatureSensor(ctx, sensorID)
+			if err != nil {
+				return toolError("Failed to get temperature sensor", err), nil
+			}
+			owner, enabled = sensor.Owner, sensor.Enabled
+			tempC := sensor.Temperature.Temperature
+			reading = append(reading, fmt.Sprintf("Temperature: %.1f°C (%.1f°F)", tempC, tempC*9/5+32))
+			if sensor.Temperature.TemperatureReport != nil {
+				reading = append(reading, fmt.Sprintf("Last changed: %s", sensor.Temperature.TemperatureReport.Changed))
+			}
+		case "light_level":
+			sensor, err := hueClient.GetLightLevelSensor(ctx, sensorID)
+			if err != nil {
+				return toolError("Failed to get light level sensor", err), nil
+			}
+			owner, enabled = sensor.Owner, sensor.Enabled
+			reading = append(reading, fmt.Sprintf("Light level: %d", sensor.LightLevel.LightLevel))
+			if sensor.LightLevel.LightLevelReport != nil {
+				reading = append(reading, fmt.Sprintf("Last changed: %s", sensor.LightLevel.LightLevelReport.Changed))
+			}
+		case "button":
+			button, err := hueClient.GetButton(ctx, sensorID)
+			if err != nil {
+				return toolError("Failed to get button", err), nil
+			}
+			owner = button.Owner
+			lastEvent := "none"
+			if button.Button.ButtonReport != nil {
+				lastEvent = fmt.Sprintf("%s at %s", button.Button.ButtonReport.Event, button.Button.ButtonReport.Updated)
+			}
+			reading = append(reading, fmt.Sprintf("Last event: %s", lastEvent))
+			if len(button.Button.EventValues) > 0 {
+				reading = append(reading, fmt.Sprintf("Supported events: %s", strings.Join(button.Button.EventValues, ", ")))
+			}
+		case "contact":
+			sensor, err := hueClient.GetContactSensor(ctx, sensorID)
+			if err != nil {
+				return toolError("Failed to get contact sensor", err), nil
+			}
+			owner, enabled = sensor.Owner, sensor.Enabled
+			state := "Unknown"
+			if sensor.ContactReport != nil {
+				state = "Closed"
+				if sensor.ContactReport.IsOpen() {
+					state = "Open"
+				}
+				reading = append(reading, fmt.Sprintf("Last changed: %s", sensor.ContactReport.Changed))
+			}
+			reading = append([]string{fmt.Sprintf("State: %s", state)}, reading...)
+		default:
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown sensor type '%s' (expected motion, temperature, light_level, button or contact)", sensorType)), nil
+		}
+
+		deviceName, roomName := sensorLocation(ctx, hueClient, owner)
+
+		var result strings.Builder
+		result.WriteString(fmt.Sprintf("Sensor %s (%s):\n", sensorID, sensorType))
+		if deviceName != "" {
+			result.WriteString(fmt.Sprintf("- Device: %s (ID: %s)\n", deviceName, owner.RID))
+		}
+		if roomName != "" {
+			result.WriteString(fmt.Sprintf("- Room: %s\n", roomName))
+		}
+		if !enabled {
+			result.WriteString("- Disabled\n")
+		}
+		for _, line := range reading {
+			result.WriteString(fmt.Sprintf("- %s\n", line))
+		}
+
+		return mcp.NewToolResultText(result.String()), nil
+	}
+}
+
+// HandleEnableMotionSensor returns a handler that turns a motion sensor back on
+func HandleEnableMotionSensor(hueClient *client.Client) server.ToolHandlerFunc {
+	return handleMotionSensorEnabled(hueClient, true)
+}
+
+// HandleDisableMotionSensor returns a handler that stops a motion sensor detecting motion
+func HandleDisableMotionSensor(hueClient *client.Client) server.ToolHandlerFunc {
+	return handleMotionSensorEnabled(hueClient, false)
+}
+
+// handleMotionSensorEnabled sets a motion sensor's enabled flag and re-reads the sensor to confirm it
+func handleMotionSensorEnabled(hueClient *client.Client, enabled bool) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		sensorID, ok := args["sensor_id"].(string)
+		if !ok || sensorID == "" {
+			return mcp.NewToolResultError("sensor_id is required"), nil
+		}
+
+		state := "disabled"
+		if enabled {
+			state = "enabled"
+		}
+
+		if err := hueClient.SetMotionSensorEnabled(ctx, sensorID, enabled); err != nil {
+			return toolError("Failed to update motion sensor", err), nil
+		}
+
+		sensor, err := hueClient.GetMotionSensor(ctx, sensorID)
+		if err != nil {
+			return toolError("Motion sensor updated but could not be re-read", err), nil
+		}
+		if sensor.Enabled != enabled {
+			return mcp.NewToolResultError(fmt.Sprintf("Bridge accepted the change but motion sensor %s is still not %s", sensorID, state)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Motion sensor %s %s", sensorID, state)), nil
+	}
+}
+
+// HandleSetMotionSensitivity returns a handler that adjusts a motion sensor's sensitivity
+func HandleSetMotionSensitivity(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		sensorID, ok := args["sensor_id"].(string)
+		if !ok || sensorID == "" {
+			return mcp.NewToolResultError("sensor_id is required"), nil
+		}
+
+		sensitivity, ok := args["sensitivity"].(float64)
+		if !ok {
+			return mcp.NewToolResultError("sensitivity is required"), nil
+		}
+
+		if err := hueClient.SetMotionSensitivity(ctx, sensorID, int(sensitivity)); err != nil {
+			return toolError("Failed to set sensitivity", err), nil
+		}
+
+		sensor, err := hueClient.GetMotionSensor(ctx, sensorID)
+		if err != nil {
+			return toolError("Sensitivity updated but the sensor could not be re-read", err), nil
+		}
+		if sensor.Sensitivity == nil || sensor.Sensitivity.Sensitivity != int(sensitivity) {
+			return mcp.NewToolResultError(fmt.Sprintf("Bridge accepted the change but motion sensor %s does not report sensitivity %d", sensorID, int(sensitivity))), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Motion sensor %s sensitivity set to %d", sensorID, sensor.Sensitivity.Sensitivity)), nil
+	}
+}
+
+// sensorLocation looks up the name of a sensor's owning device and the room containing it.
+// Lookup failures leave the names empty rather than failing the reading.
+func sensorLocation(ctx context.Context, hueClient *client.Client, owner client.ResourceIdentifier) (deviceName, roomName string) {
+	if owner.RID == "" {
+		return "", ""
+	}
+
+	if device, err := hueClient.GetDevice(ctx, owner.RID); err == nil {
+		deviceName = device.Metadata.Name
+	}
+
+	rooms, err := hueClient.GetRooms(ctx)
+	if err != nil {
+		return deviceName, ""
+	}
+	for _, room := range rooms {
+		for _, child := range room.Children {
+			if child.RID == owner.RID {
+				return deviceName, room.Metadata.Name
+			}
+		}
+	}
+
+	return deviceName, ""
+}
+
+// lowBatteryLevel is the battery percentage below which devices are flagged
+const lowBatteryLevel = 20
+
+// HandleListBatteryLevels returns a handler for listing battery levels of sensors and remotes
+func HandleListBatteryLevels(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		powers, err := hueClient.GetDevicePowers(ctx)
+		if err != nil {
+			return toolError("Failed to list battery levels", err), nil
+		}
+
+		devices, err := hueClient.GetDevices(ctx)
+		if err != nil {
+			return toolError("Failed to list devices", err), nil
+		}
+
+		deviceNames := make(map[string]string)
+		for _, device := range devices {
+			deviceNames[device.ID] = device.Metadata.Name
+		}
+
+		// Lowest first, with devices that don't report a level at the end
+		sort.SliceStable(powers, func(i, j int) bool {
+			a, b := powers[i].PowerState.BatteryLevel, powers[j].PowerState.BatteryLevel
+			if a == nil || b == nil {
+				return a != nil && b == nil
+			}
+			return *a < *b
+		})
+
+		var result strings.Builder
+		result.WriteString(fmt.Sprintf("Found %d battery powered devices:\n", len(powers)))
+		low := 0
+		for _, power := range powers {
+			name := deviceNames[power.Owner.RID]
+			if name == "" {
+				name = power.Owner.RID
+			}
+
+			level := power.PowerState.BatteryLevel
+			line := fmt.Sprintf("- %s: level unknown", name)
+			if level != nil {
+				line = fmt.Sprintf("- %s: %.0f%%", name, *level)
+			}
+			if power.PowerState.BatteryState != "" {
+				line += fmt.Sprintf(" (%s)", power.PowerState.BatteryState)
+			}
+			if level != nil && *level < lowBatteryLevel {
+				line += " ⚠️ LOW BATTERY"
+				low++
+			}
+			result.WriteString(fmt.Sprintf("%s (ID: %s)\n", line, power.Owner.RID))
+		}
+
+		if low > 0 {
+			result.WriteString(fmt.Sprintf("\n%d devices below %d%% need new batteries\n", low, lowBatteryLevel))
+		}
+
+		return mcp.NewToolResultText(result.String()), nil
+	}
+}
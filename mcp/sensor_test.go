@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestGetSensorIncludesDeviceAndRoom(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("contact", map[string]interface{}{
+		"id":             "contact-1",
+		"type":           "contact",
+		"enabled":        true,
+		"owner":          map[string]string{"rid": "device-1", "rtype": "device"},
+		"contact_report": map[string]string{"changed": "2024-01-01T12:00:00.000Z", "state": "no_contact"},
+	})
+	fb.Add("device", client.Device{ID: "device-1", Metadata: client.Metadata{Name: "Front Door Sensor"}})
+	fb.Add("room", client.Room{
+		ID:       "room-1",
+		Metadata: client.Metadata{Name: "Hallway"},
+		Children: []client.ResourceIdentifier{{RID: "device-1", RType: "device"}},
+	})
+	
+	result := callTool(t, HandleGetSensor(fb.client()), map[string]interface{}{
+		"sensor_id": "contact-1",
+		"type":      "contact",
+	})
+	if result.IsError {
+		t.Fatalf("get_sensor failed: %s", resultText(result))
+	}
+	
+	text := resultText(result)
+	for _, want := range []string{"Front Door Sensor", "Room: Hallway", "State: Open"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestGetSensorRejectsUnknownType(t *testing.T) {
+	fb := newFakeBridge(t)
+	
+	result := callTool(t, HandleGetSensor(fb.client()), map[string]interface{}{
+		"sensor_id": "sensor-1",
+		"type":      "humidity",
+	})
+	if !result.IsError {
+		t.Errorf("Expected an error for an unknown sensor type, got: %s", resultText(result))
+	}
+}
+
+func TestDisableAndEnableMotionSensor(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("motion", client.Motion{ID: "motion-1", Enabled: true})
+	
+	result := callTool(t, HandleDisableMotionSensor(fb.client()), map[string]interface{}{"sensor_id": "motion-1"})
+	if result.IsError || resultText(result) != "Motion sensor motion-1 disabled" {
+		t.Fatalf("disable_motion_sensor failed: %s", resultText(result))
+	}
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/motion/motion-1")
+	if len(puts) != 1 || puts[0].Body["enabled"] != false {
+		t.Fatalf("Expected a PUT disabling the sensor, got %+v", puts)
+	}
+	
+	// The result is confirmed by re-reading the sensor
+	if gets := fb.RequestsFor("GET", "/clip/v2/resource/motion/motion-1"); len(gets) != 1 {
+		t.Errorf("Expected the sensor to be re-read, got %d GETs", len(gets))
+	}
+	
+	result = callTool(t, HandleEnableMotionSensor(fb.client()), map[string]interface{}{"sensor_id": "motion-1"})
+	if result.IsError || resultText(result) != "Motion sensor motion-1 enabled" {
+		t.Errorf("enable_motion_sensor failed: %s", resultText(result))
+	}
+}
+
+func TestSetMotionSensitivity(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("motion", client.Motion{ID: "motion-1", Enabled: true, Sensitivity: &client.MotionSensitivity{Sensitivity: 2, SensitivityMax: 4}})
+	fb.Add("motion", client.Motion{ID: "motion-old", Enabled: true})
+	
+	for _, args := range []map[string]interface{}{
+		{"sensor_id": "motion-1", "sensitivity": 9.0},
+		{"sensor_id": "motion-old", "sensitivity": 1.0},
+	} {
+		if result := callTool(t, HandleSetMotionSensitivity(fb.client()), args); !result.IsError {
+			t.Errorf("Expected %v to be rejected", args)
+		}
+	}
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/motion/"); len(puts) != 0 {
+		t.Errorf("Expected rejected sensitivities not to be sent, got %+v", puts)
+	}
+	
+	result := callTool(t, HandleSetMotionSensitivity(fb.client()), map[string]interface{}{"sensor_id": "motion-1", "sensitivity": 4.0})
+	if result.IsError || !strings.Contains(resultText(result), "sensitivity set to 4") {
+		t.Fatalf("set_motion_sensitivity failed: %s", resultText(result))
+	}
+}
+
+func TestListBatteryLevelsSkipsMissingLevels(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("device_power", map[string]interface{}{
+		"id":          "power-1",
+		"owner":       map[string]string{"rid": "device-1", "rtype": "device"},
+		"power_state": map[string]interface{}{"battery_state": "low", "battery_level": 12},
+	})
+	fb.Add("device_power", map[string]interface{}{
+		"id":          "power-2",
+		"owner":       map[string]string{"rid": "device-2", "rtype": "device"},
+		"power_state": map[string]interface{}{"battery_state": "normal"},
+	})
+	fb.Add("device", client.Device{ID: "device-1", Metadata: client.Metadata{Name: "Hallway Dimmer"}})
+	fb.Add("device", client.Device{ID: "device-2", Metadata: client.Metadata{Name: "Kitchen Motion"}})
+	
+	result := callTool(t, HandleListBatteryLevels(fb.client()), nil)
+	if result.IsError {
+		t.Fatalf("list_battery_levels failed: %s", resultText(result))
+	}
+	
+	text := resultText(result)
+	if !strings.Contains(text, "Hallway Dimmer: 12% (low) ⚠️ LOW BATTERY") {
+		t.Errorf("Expected the dimmer flagged low, got:\n%s", text)
+	}
+	if !strings.Contains(text, "Kitchen Motion: level unknown (normal) (ID") {
+		t.Errorf("Expected the sensor without a level listed as unknown and not flagged, got:\n%s", text)
+	}
+	if !strings.Contains(text, "1 devices below") {
+		t.Errorf("Expected only one low device, got:\n%s", text)
+	}
+}
@@ -0,0 +1,36 @@
+package mcp
+
+import (
+	"context"
+	"log"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+// Shutdown stops everything that keeps changing lights in the background: running sequences,
+// entertainment streams and the event stream, so the server doesn't exit with lights mid-strobe.
+// Streams that haven't stopped by the time ctx ends are abandoned.
+func Shutdown(ctx context.Context) {
+	if globalScheduler != nil {
+		if stopped := globalScheduler.StopAll(); stopped > 0 {
+			log.Printf("Stopped %d running sequences", stopped)
+		}
+		// Keep pending schedules from firing on the way out; they are reloaded on the next start
+		globalScheduler.Stop()
+	}
+
+	streamersMutex.Lock()
+	streamers := activeStreamers
+	activeStreamers = make(map[string]*client.EntertainmentStreamer)
+	streamersMutex.Unlock()
+
+	for configID, streamer := range streamers {
+		if err := streamer.Stop(ctx); err != nil {
+			log.Printf("Failed to stop streaming for configuration %s: %v", configID, err)
+		}
+	}
+
+	if stopEventStream(ctx) {
+		log.Println("Stopped event stream")
+	}
+}
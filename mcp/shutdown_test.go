@@ -0,0 +1,45 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/kungfusheep/hue/scheduler"
+)
+
+func TestShutdownStopsSequencesAndStreams(t *testing.T) {
+	fb := newFakeBridge(t)
+	hueClient := fb.client()
+	
+	previous := globalScheduler
+	globalScheduler = scheduler.NewScheduler(hueClient)
+	t.Cleanup(func() { globalScheduler = previous })
+	
+	seq := &scheduler.Sequence{ID: "strobe", Loop: true, Commands: []scheduler.Command{{Type: "light", Action: "on", Target: "light-1", Delay: time.Hour}}}
+	if _, err := globalScheduler.ExecuteSequence(seq); err != nil {
+		t.Fatalf("ExecuteSequence failed: %v", err)
+	}
+	
+	streamer, _ := client.NewEntertainmentStreamer(hueClient, "ent-1")
+	streamersMutex.Lock()
+	activeStreamers["ent-1"] = streamer
+	streamersMutex.Unlock()
+	
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	Shutdown(ctx)
+	
+	for id, seq := range globalScheduler.GetSequences() {
+		if seq.Running {
+			t.Errorf("Expected sequence %s to be stopped", id)
+		}
+	}
+	streamersMutex.RLock()
+	remaining := len(activeStreamers)
+	streamersMutex.RUnlock()
+	if remaining != 0 {
+		t.Errorf("Expected every streamer to be stopped, %d remain", remaining)
+	}
+}
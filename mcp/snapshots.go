@@ -0,0 +1,182 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// SnapshotStore keeps named light state snapshots for restore_state
+type SnapshotStore struct {
+	snapshots map[string]client.Snapshot
+	latest    string
+	mu        sync.RWMutex
+}
+
+// Global snapshot store instance
+var globalSnapshotStore = &SnapshotStore{
+	snapshots: make(map[string]client.Snapshot),
+}
+
+// Save stores a snapshot under its name and makes it the latest
+func (ss *SnapshotStore) Save(snapshot client.Snapshot) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	ss.snapshots[snapshot.Name] = snapshot
+	ss.latest = snapshot.Name
+}
+
+// Get returns a named snapshot, or the latest one when name is empty
+func (ss *SnapshotStore) Get(name string) (client.Snapshot, error) {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+
+	if name == "" {
+		name = ss.latest
+		if name == "" {
+			return client.Snapshot{}, fmt.Errorf("no snapshots have been taken")
+		}
+	}
+
+	snapshot, exists := ss.snapshots[name]
+	if !exists {
+		return client.Snapshot{}, fmt.Errorf("snapshot '%s' not found", name)
+	}
+	return snapshot, nil
+}
+
+// HandleSnapshotState captures the state of a group or a list of lights into a named snapshot
+func HandleSnapshotState(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		name, ok := args["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+
+		var lightIDs []string
+		if groupID, ok := args["group_id"].(string); ok && groupID != "" {
+			resolvedID, err := resolveGroupID(ctx, hueClient, groupID)
+			if err != nil {
+				return toolError("Failed to resolve group", err), nil
+			}
+
+			lightIDs, err = hueClient.GetGroupLights(ctx, resolvedID)
+			if err != nil {
+				return toolError("Failed to get group lights", err), nil
+			}
+		} else if lightIDsJSON, ok := args["light_ids"].(string); ok && lightIDsJSON != "" {
+			var names []string
+			if err := json.Unmarshal([]byte(lightIDsJSON), &names); err != nil {
+				return toolError("Failed to parse light_ids JSON", err), nil
+			}
+
+			for _, nameOrID := range names {
+				lightID, err := resolveLightID(ctx, hueClient, nameOrID)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				lightIDs = append(lightIDs, lightID)
+			}
+		} else {
+			return mcp.NewToolResultError("group_id or light_ids is required"), nil
+		}
+
+		if len(lightIDs) == 0 {
+			return mcp.NewToolResultError("No lights to snapshot"), nil
+		}
+
+		snapshot, err := hueClient.CaptureStates(ctx, lightIDs)
+		if err != nil {
+			return toolError("Failed to capture light states", err), nil
+		}
+		snapshot.Name = name
+		globalSnapshotStore.Save(snapshot)
+
+		return mcp.NewToolResultText(fmt.Sprintf("Snapshot '%s' saved with %d lights\n%sUse restore_state to return to it", name, len(snapshot.Lights), describeSnapshot(snapshot))), nil
+	}
+}
+
+// HandleRestoreState reapplies a named snapshot, or the most recent one
+func HandleRestoreState(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		name, _ := args["name"].(string)
+
+		snapshot, err := globalSnapshotStore.Get(name)
+		if err != nil {
+			return toolError("Failed to restore state", err), nil
+		}
+
+		if err := hueClient.ApplyStates(ctx, snapshot); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Snapshot '%s' partially restored: %v", snapshot.Name, err)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Restored snapshot '%s' (%d lights, captured %s)", snapshot.Name, len(snapshot.Lights), snapshot.CapturedAt.Format("15:04:05"))), nil
+	}
+}
+
+// describeSnapshot lists each captured light's state on its own line
+func describeSnapshot(snapshot client.Snapshot) string {
+	var result strings.Builder
+	for _, state := range snapshot.Lights {
+		label := state.Name
+		if label == "" {
+			label = state.LightID
+		}
+
+		if !state.On {
+			result.WriteString(fmt.Sprintf("- %s: off\n", label))
+			continue
+		}
+
+		detail := fmt.Sprintf("on, %.0f%%", state.Brightness)
+		if state.Mirek > 0 {
+			detail += fmt.Sprintf(", %s", describeMirek(state.Mirek))
+		} else if state.Color != nil {
+			detail += fmt.Sprintf(", %s", client.XYToHex(state.Color.X, state.Color.Y, state.Brightness))
+		}
+		result.WriteString(fmt.Sprintf("- %s: %s\n", label, detail))
+	}
+	return result.String()
+}
+
+// snapshotBatchTargets captures every light a batch touches, expanding group targets to their lights
+func snapshotBatchTargets(ctx context.Context, hueClient *client.Client, commands []map[string]interface{}) (client.Snapshot, error) {
+	seen := make(map[string]bool)
+	var lightIDs []string
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			lightIDs = append(lightIDs, id)
+		}
+	}
+
+	for _, cmd := range commands {
+		action, _ := cmd["action"].(string)
+		targetID, _ := cmd["target_id"].(string)
+
+		switch {
+		case strings.HasPrefix(action, "light_"):
+			add(targetID)
+		case strings.HasPrefix(action, "group_"):
+			groupLights, err := hueClient.GetGroupLights(ctx, targetID)
+			if err != nil {
+				return client.Snapshot{}, fmt.Errorf("failed to get lights for group %s: %w", targetID, err)
+			}
+			for _, id := range groupLights {
+				add(id)
+			}
+		}
+	}
+
+	return hueClient.CaptureStates(ctx, lightIDs)
+}
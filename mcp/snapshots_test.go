@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestSnapshotAndRestoreState(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk"}, On: client.OnState{On: true}, Dimming: client.Dimming{Brightness: 30}})
+	fb.Add("light", client.Light{ID: "light-2", Metadata: client.Metadata{Name: "Floor"}})
+	
+	result := callTool(t, HandleSnapshotState(fb.client()), map[string]interface{}{
+		"name":      "before-party",
+		"light_ids": `["light-1","light-2"]`,
+	})
+	if result.IsError {
+		t.Fatalf("snapshot_state failed: %s", resultText(result))
+	}
+	if text := resultText(result); !strings.Contains(text, "- Desk: on, 30%") || !strings.Contains(text, "- Floor: off") {
+		t.Errorf("Expected each light's state listed, got:\n%s", text)
+	}
+	
+	// Without a name the latest snapshot is restored
+	result = callTool(t, HandleRestoreState(fb.client()), nil)
+	if result.IsError {
+		t.Fatalf("restore_state failed: %s", resultText(result))
+	}
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/"); len(puts) != 2 {
+		t.Errorf("Expected both lights restored, got %d PUTs", len(puts))
+	}
+	
+	result = callTool(t, HandleRestoreState(fb.client()), map[string]interface{}{"name": "missing"})
+	if !result.IsError {
+		t.Error("Expected an unknown snapshot to be an error")
+	}
+}
+
+func TestBatchAutoSnapshot(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", On: client.OnState{On: true}, Dimming: client.Dimming{Brightness: 50}})
+	
+	result := callTool(t, HandleBatchCommands(fb.client()), map[string]interface{}{
+		"commands":      `[{"action":"light_off","target_id":"light-1"}]`,
+		"async":         false,
+		"auto_snapshot": true,
+	})
+	if result.IsError {
+		t.Fatalf("batch_commands failed: %s", resultText(result))
+	}
+	
+	name := regexp.MustCompile(`Snapshot '([^']+)'`).FindStringSubmatch(resultText(result))
+	if name == nil {
+		t.Fatalf("Expected the snapshot name in:\n%s", resultText(result))
+	}
+	
+	snapshot, err := globalSnapshotStore.Get(name[1])
+	if err != nil {
+		t.Fatalf("Expected the snapshot to be stored: %v", err)
+	}
+	if len(snapshot.Lights) != 1 || !snapshot.Lights[0].On || snapshot.Lights[0].Brightness != 50 {
+		t.Errorf("Expected the pre-batch state to be captured, got %+v", snapshot.Lights)
+	}
+}
+
+func TestAtomicBatchRollsBack(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", On: client.OnState{On: true}, Dimming: client.Dimming{Brightness: 50}})
+	fb.Add("light", client.Light{ID: "light-2", On: client.OnState{On: true}})
+	fb.Add("light", client.Light{ID: "light-3"})
+	fb.Fail("PUT", "/clip/v2/resource/light/light-2")
+	
+	result := callTool(t, HandleBatchCommands(fb.client()), map[string]interface{}{
+		"commands":      `[{"action":"light_off","target_id":"light-1"},{"action":"light_off","target_id":"light-2"},{"action":"light_on","target_id":"light-3"}]`,
+		"delay_ms":      0.0,
+		"auto_snapshot": true,
+		"atomic":        true,
+	})
+	if !result.IsError {
+		t.Fatalf("Expected the atomic batch to report the failure, got: %s", resultText(result))
+	}
+	text := resultText(result)
+	if !strings.Contains(text, "Command 1 (light_off)") || !strings.Contains(text, "Skipped 1 remaining commands") {
+		t.Errorf("Expected the failed command and skipped count, got:\n%s", text)
+	}
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-3"); len(puts) != 1 {
+		t.Errorf("Expected light-3 only touched by the rollback, got %d PUTs", len(puts))
+	}
+	
+	// light-1 was turned off by the batch, then back on by the rollback
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1")
+	if len(puts) != 2 {
+		t.Fatalf("Expected light-1 to be changed and rolled back, got %d PUTs", len(puts))
+	}
+	if on, _ := puts[1].Body["on"].(map[string]interface{}); on["on"] != true {
+		t.Errorf("Expected the rollback to turn light-1 back on, got %v", puts[1].Body)
+	}
+	if !strings.Contains(text, "Rollback to snapshot") {
+		t.Errorf("Expected the rollback of light-2 to be reported as failed, got:\n%s", text)
+	}
+	
+	result = callTool(t, HandleBatchCommands(fb.client()), map[string]interface{}{
+		"commands": `[{"action":"light_on","target_id":"light-3"}]`,
+		"atomic":   true,
+	})
+	if !result.IsError || !strings.Contains(resultText(result), "requires auto_snapshot") {
+		t.Errorf("Expected atomic without auto_snapshot to be rejected, got: %s", resultText(result))
+	}
+}
@@ -0,0 +1,297 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// stateUpdate holds the properties requested by set_light_state or set_group_state
+type stateUpdate struct {
+	On               *client.OnState
+	Dimming          *client.Dimming
+	Color            *client.Color
+	ColorTemperature *client.ColorTemperature
+	Dynamics         *client.Dynamics
+	hexColor         string // The requested color, so a light can convert it within its own gamut
+	changes          []string
+}
+
+// parseStateUpdate reads on, brightness, color, color_temp or kelvin, and transition_ms from tool arguments
+func parseStateUpdate(args map[string]interface{}) (*stateUpdate, error) {
+	update := &stateUpdate{}
+
+	if on, ok := args["on"].(bool); ok {
+		update.On = &client.OnState{On: on}
+		if on {
+			update.changes = append(update.changes, "on")
+		} else {
+			update.changes = append(update.changes, "off")
+		}
+	}
+
+	if brightness, ok := args["brightness"].(float64); ok {
+		if brightness < 0 || brightness > 100 {
+			return nil, fmt.Errorf("brightness must be between 0 and 100")
+		}
+		update.Dimming = &client.Dimming{Brightness: brightness}
+		update.changes = append(update.changes, fmt.Sprintf("brightness %.0f%%", brightness))
+	}
+
+	color, hasColor := args["color"].(string)
+	hasColor = hasColor && color != ""
+	mirek, hasTemp := args["color_temp"].(float64)
+	if kelvin, hasKelvin := args["kelvin"].(float64); hasKelvin {
+		if hasTemp {
+			return nil, fmt.Errorf("color_temp and kelvin cannot be set together")
+		}
+		if kelvin < 2000 || kelvin > 6535 {
+			return nil, fmt.Errorf("kelvin must be between 2000 and 6535")
+		}
+		mirek, hasTemp = float64(client.KelvinToMirek(int(kelvin))), true
+	}
+	if hasColor && hasTemp {
+		return nil, fmt.Errorf("color and color_temp cannot be set together")
+	}
+
+	if hasColor {
+		hexColor, err := parseColor(color)
+		if err != nil {
+			return nil, err
+		}
+		x, y := client.HexToXY(hexColor)
+		update.Color = &client.Color{XY: client.XY{X: x, Y: y}}
+		update.hexColor = hexColor
+		update.changes = append(update.changes, fmt.Sprintf("color %s", color))
+	}
+
+	if hasTemp {
+		if mirek < 153 || mirek > 500 {
+			return nil, fmt.Errorf("color_temp must be between 153 and 500 mirek")
+		}
+		update.ColorTemperature = &client.ColorTemperature{Mirek: int(mirek)}
+		update.changes = append(update.changes, fmt.Sprintf("color temperature %s", describeMirek(int(mirek))))
+	}
+
+	if transition, ok := args["transition_ms"].(float64); ok {
+		if transition < 0 {
+			return nil, fmt.Errorf("transition_ms cannot be negative")
+		}
+		update.Dynamics = &client.Dynamics{Duration: int(transition)}
+	}
+
+	if len(update.changes) == 0 {
+		return nil, fmt.Errorf("at least one of on, brightness, color or color_temp is required")
+	}
+
+	return update, nil
+}
+
+// describeMirek formats a color temperature in mirek with its Kelvin equivalent, e.g. "370 mirek (2703K)"
+func describeMirek(mirek int) string {
+	return fmt.Sprintf("%d mirek (%dK)", mirek, client.MirekToKelvin(mirek))
+}
+
+// describe summarises the requested changes for a tool result
+func (u *stateUpdate) describe() string {
+	desc := strings.Join(u.changes, ", ")
+	if u.Dynamics != nil {
+		desc += fmt.Sprintf(" over %dms", u.Dynamics.Duration)
+	}
+	return desc
+}
+
+// HandleSetLightState returns a handler that sets several light properties in one request
+func HandleSetLightState(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		lightID, ok := args["light_id"].(string)
+		if !ok || lightID == "" {
+			return mcp.NewToolResultError("light_id is required"), nil
+		}
+
+		update, err := parseStateUpdate(args)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		resolvedID, err := resolveLightID(ctx, hueClient, lightID)
+		if err != nil {
+			return toolError("Failed to resolve light", err), nil
+		}
+
+		if err := checkLightCapabilities(ctx, hueClient, resolvedID, update.Dimming != nil, update.Color != nil, update.ColorTemperature != nil); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		if update.hexColor != "" {
+			update.Color = &client.Color{XY: hueClient.LightColorXY(ctx, resolvedID, update.hexColor)}
+		}
+
+		var note string
+		if update.Dimming != nil {
+			offBelowMin, _ := args["off_below_min"].(bool)
+			brightness, turnOff, dimNote := fitMinDimLevel(ctx, hueClient, resolvedID, update.Dimming.Brightness, offBelowMin)
+			if turnOff {
+				update.On = &client.OnState{On: false}
+				update.Dimming = nil
+			} else {
+				update.Dimming.Brightness = brightness
+			}
+			if dimNote != "" {
+				note = fmt.Sprintf(" (%s)", dimNote)
+			}
+		}
+
+		err = hueClient.UpdateLight(ctx, resolvedID, client.LightUpdate{
+			On:               update.On,
+			Dimming:          update.Dimming,
+			Color:            update.Color,
+			ColorTemperature: update.ColorTemperature,
+			Dynamics:         update.Dynamics,
+		})
+		if err != nil {
+			return toolError("Failed to set light state", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Light %s set: %s%s", lightID, update.describe(), note)), nil
+	}
+}
+
+// HandleSetGroupState returns a handler that sets several group properties in one request
+func HandleSetGroupState(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		groupID, ok := args["group_id"].(string)
+		if !ok || groupID == "" {
+			return mcp.NewToolResultError("group_id is required"), nil
+		}
+
+		update, err := parseStateUpdate(args)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		resolvedID, err := resolveGroupID(ctx, hueClient, groupID)
+		if err != nil {
+			return toolError("Failed to resolve group", err), nil
+		}
+
+		err = hueClient.UpdateGroup(ctx, resolvedID, client.GroupUpdate{
+			On:               update.On,
+			Dimming:          update.Dimming,
+			Color:            update.Color,
+			ColorTemperature: update.ColorTemperature,
+			Dynamics:         update.Dynamics,
+		})
+		if err != nil {
+			return toolError("Failed to set group state", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Group %s set: %s", groupID, update.describe())), nil
+	}
+}
+
+// Defaults for white_preset: a warm reading white
+const (
+	defaultPresetKelvin     = 3000
+	defaultPresetBrightness = 80
+)
+
+// HandleWhitePreset returns a handler that turns a light or group on at a white color
+// temperature and brightness in one update, defaulting to a warm reading light
+func HandleWhitePreset(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		lightID, _ := args["light_id"].(string)
+		groupID, _ := args["group_id"].(string)
+		if (lightID == "") == (groupID == "") {
+			return mcp.NewToolResultError("exactly one of light_id or group_id is required"), nil
+		}
+
+		kelvin, ok := args["kelvin"].(float64)
+		if !ok {
+			kelvin = defaultPresetKelvin
+		}
+		brightness, ok := args["brightness"].(float64)
+		if !ok {
+			brightness = defaultPresetBrightness
+		}
+
+		stateArgs := map[string]interface{}{
+			"on":         true,
+			"kelvin":     kelvin,
+			"brightness": brightness,
+		}
+		if transition, ok := args["transition_ms"].(float64); ok {
+			stateArgs["transition_ms"] = transition
+		}
+
+		var stateRequest mcp.CallToolRequest
+		if lightID != "" {
+			stateArgs["light_id"] = lightID
+			stateRequest.Params.Arguments = stateArgs
+			return HandleSetLightState(hueClient)(ctx, stateRequest)
+		}
+		stateArgs["group_id"] = groupID
+		stateRequest.Params.Arguments = stateArgs
+		return HandleSetGroupState(hueClient)(ctx, stateRequest)
+	}
+}
+
+// fitMinDimLevel checks a requested brightness against the light's minimum dim level.
+// Below the minimum the bridge would clamp the light without saying so, so the brightness
+// snaps to the minimum, or with offBelowMin the light should be turned off instead.
+// The returned note explains any adjustment and is empty when none was needed. If the light
+// can't be read the brightness is left alone and the update itself reports the problem.
+func fitMinDimLevel(ctx context.Context, hueClient *client.Client, lightID string, brightness float64, offBelowMin bool) (adjusted float64, turnOff bool, note string) {
+	light, err := hueClient.GetLight(ctx, lightID)
+	if err != nil {
+		return brightness, false, ""
+	}
+
+	minimum := light.Dimming.MinDimLevel
+	if minimum <= 0 || brightness >= minimum {
+		return brightness, false, ""
+	}
+
+	if offBelowMin {
+		return 0, true, fmt.Sprintf("%.1f%% is below this light's minimum of %.1f%%, so it was turned off", brightness, minimum)
+	}
+	return minimum, false, fmt.Sprintf("%.1f%% is below this light's minimum of %.1f%%, so the minimum was used", brightness, minimum)
+}
+
+// checkLightCapabilities rejects brightness, color or color temperature for lights that lack
+// them, such as smart plugs and dimmable-only bulbs, so users get a clear error instead of the
+// bridge's rejection. A light that can't be fetched is let through for the update itself to report.
+func checkLightCapabilities(ctx context.Context, hueClient *client.Client, lightID string, brightness, color, colorTemp bool) error {
+	if !brightness && !color && !colorTemp {
+		return nil
+	}
+	light, err := hueClient.GetLight(ctx, lightID)
+	if err != nil {
+		return nil
+	}
+
+	label := light.Metadata.Name
+	if label == "" {
+		label = light.ID
+	}
+	if light.IsOnOffOnly() {
+		return fmt.Errorf("light %s is on/off only (e.g. a smart plug) and can only be turned on or off", label)
+	}
+	if color && light.Color == nil {
+		return fmt.Errorf("light %s does not support color", label)
+	}
+	if colorTemp && light.ColorTemperature == nil {
+		return fmt.Errorf("light %s does not support color temperature", label)
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestSetLightStateSendsSingleUpdate(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk"}, Color: &client.Color{}})
+	
+	result := callTool(t, HandleSetLightState(fb.client()), map[string]interface{}{
+		"light_id":      "Desk",
+		"on":            true,
+		"brightness":    60.0,
+		"color":         "red",
+		"transition_ms": 400.0,
+	})
+	if result.IsError {
+		t.Fatalf("set_light_state failed: %s", resultText(result))
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1")
+	if len(puts) != 1 {
+		t.Fatalf("Expected a single PUT, got %d", len(puts))
+	}
+	
+	body := puts[0].Body
+	for _, key := range []string{"on", "dimming", "color", "dynamics"} {
+		if body[key] == nil {
+			t.Errorf("Expected %s in the update, got %v", key, body)
+		}
+	}
+	if body["color_temperature"] != nil {
+		t.Errorf("Expected no color temperature, got %v", body)
+	}
+}
+
+func TestSetLightStateClampsColorToGamut(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk"}, Color: &client.Color{GamutType: "B"}})
+	
+	result := callTool(t, HandleSetLightState(fb.client()), map[string]interface{}{
+		"light_id": "Desk",
+		"color":    "#00FF00",
+	})
+	if result.IsError {
+		t.Fatalf("set_light_state failed: %s", resultText(result))
+	}
+	
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1")
+	if len(puts) != 1 {
+		t.Fatalf("Expected a single PUT, got %d", len(puts))
+	}
+	color, _ := puts[0].Body["color"].(map[string]interface{})
+	x, y := client.HexToXY("#00FF00")
+	if fmt.Sprint(color["xy"]) == fmt.Sprint(map[string]interface{}{"x": x, "y": y}) {
+		t.Errorf("Expected green to be clamped to gamut B, got the unclamped %v", color["xy"])
+	}
+}
+
+func TestSetGroupStateRejectsColorWithColorTemp(t *testing.T) {
+	fb := newFakeBridge(t)
+	
+	result := callTool(t, HandleSetGroupState(fb.client()), map[string]interface{}{
+		"group_id":   "group-1",
+		"color":      "#FF0000",
+		"color_temp": 300.0,
+	})
+	if !result.IsError {
+		t.Fatalf("Expected color with color_temp to be rejected, got: %s", resultText(result))
+	}
+	
+	if len(fb.RequestsFor("PUT", "/clip/v2/resource/")) != 0 {
+		t.Error("Expected no request when validation fails")
+	}
+}
+
+func TestBrightnessBelowMinDimLevel(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk"}, Dimming: client.Dimming{Brightness: 50, MinDimLevel: 2}})
+	fb.Add("light", client.Light{ID: "light-2", Metadata: client.Metadata{Name: "Shelf"}, Dimming: client.Dimming{Brightness: 50, MinDimLevel: 2}})
+	
+	result := callTool(t, HandleLightBrightness(fb.client()), map[string]interface{}{"light_id": "light-1", "brightness": 1.0})
+	if result.IsError || !strings.Contains(resultText(result), "minimum of 2.0%") {
+		t.Fatalf("Expected a note about snapping to the minimum, got: %s", resultText(result))
+	}
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1")
+	if len(puts) != 1 || fmt.Sprint(puts[0].Body["dimming"]) != "map[brightness:2]" {
+		t.Fatalf("Expected brightness to snap to 2%%, got %+v", puts)
+	}
+	
+	result = callTool(t, HandleSetLightState(fb.client()), map[string]interface{}{"light_id": "Shelf", "brightness": 0.5, "off_below_min": true})
+	if result.IsError || !strings.Contains(resultText(result), "turned off") {
+		t.Fatalf("Expected the light to be turned off, got: %s", resultText(result))
+	}
+	puts = fb.RequestsFor("PUT", "/clip/v2/resource/light/light-2")
+	last := puts[len(puts)-1].Body
+	if fmt.Sprint(last["on"]) != "map[on:false]" || last["dimming"] != nil {
+		t.Errorf("Expected an off update without dimming, got %v", last)
+	}
+	
+	result = callTool(t, HandleLightBrightness(fb.client()), map[string]interface{}{"light_id": "light-1", "brightness": 40.0})
+	if resultText(result) != "Light light-1 brightness set to 40%" {
+		t.Errorf("Expected brightness above the minimum to pass through, got: %s", resultText(result))
+	}
+}
+
+func TestSetLightStateAcceptsKelvin(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk"}, ColorTemperature: &client.ColorTemperature{}})
+	
+	result := callTool(t, HandleSetLightState(fb.client()), map[string]interface{}{"light_id": "Desk", "kelvin": 2700.0})
+	if result.IsError || !strings.Contains(resultText(result), "color temperature 370 mirek (2703K)") {
+		t.Fatalf("Expected kelvin to be converted to mirek, got: %s", resultText(result))
+	}
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1")
+	if len(puts) != 1 || fmt.Sprint(puts[0].Body["color_temperature"]) != "map[mirek:370]" {
+		t.Errorf("Expected mirek 370 to be sent, got %+v", puts)
+	}
+	
+	for _, args := range []map[string]interface{}{
+		{"light_id": "Desk", "kelvin": 1500.0},
+		{"light_id": "Desk", "kelvin": 3000.0, "color_temp": 300.0},
+	} {
+		if result := callTool(t, HandleSetLightState(fb.client()), args); !result.IsError {
+			t.Errorf("Expected %v to be rejected", args)
+		}
+	}
+}
+
+func TestColorRejectedOnDimmableOnlyLight(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Hallway"}, Dimming: client.Dimming{Brightness: 50}})
+
+	result := callTool(t, HandleLightColor(fb.client()), map[string]interface{}{"light_id": "light-1", "color": "red"})
+	if !result.IsError || resultText(result) != "light Hallway does not support color" {
+		t.Errorf("Expected a clear no-color error, got: %s", resultText(result))
+	}
+
+	result = callTool(t, HandleSetLightState(fb.client()), map[string]interface{}{"light_id": "Hallway", "color_temp": 300.0})
+	if !result.IsError || !strings.Contains(resultText(result), "does not support color temperature") {
+		t.Errorf("Expected a clear no-color-temperature error, got: %s", resultText(result))
+	}
+
+	if puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/"); len(puts) != 0 {
+		t.Errorf("Expected nothing to be sent, got %d PUTs", len(puts))
+	}
+}
+
+func TestSmartPlugIsOnOffOnly(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "plug-1", Metadata: client.Metadata{Name: "Kettle", Archetype: "plug"}, On: client.OnState{On: true}})
+
+	result := callTool(t, HandleListLights(fb.client()), map[string]interface{}{})
+	if !strings.Contains(resultText(result), "Kettle (plug): on (on/off only)") {
+		t.Errorf("Expected the plug to be listed as on/off only, got: %s", resultText(result))
+	}
+
+	result = callTool(t, HandleGetLightState(fb.client()), map[string]interface{}{"light_id": "plug-1"})
+	if text := resultText(result); !strings.Contains(text, "on/off only") || strings.Contains(text, "Brightness:") {
+		t.Errorf("Expected the plug state to show no brightness, got: %s", text)
+	}
+
+	for _, tc := range []struct {
+		handler func(*client.Client) server.ToolHandlerFunc
+		args    map[string]interface{}
+	}{
+		{HandleLightBrightness, map[string]interface{}{"light_id": "plug-1", "brightness": 50.0}},
+		{HandleLightColor, map[string]interface{}{"light_id": "plug-1", "color": "red"}},
+		{HandleSetLightState, map[string]interface{}{"light_id": "Kettle", "brightness": 50.0}},
+	} {
+		result := callTool(t, tc.handler(fb.client()), tc.args)
+		if !result.IsError || !strings.Contains(resultText(result), "Kettle is on/off only") {
+			t.Errorf("Expected %v to be rejected as on/off only, got: %s", tc.args, resultText(result))
+		}
+	}
+
+	result = callTool(t, HandleLightOff(fb.client()), map[string]interface{}{"light_id": "plug-1"})
+	if result.IsError {
+		t.Errorf("Expected the plug to switch off, got: %s", resultText(result))
+	}
+}
+
+func TestWhitePresetSendsSingleUpdate(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Name: "Desk"}, Dimming: client.Dimming{Brightness: 20}, ColorTemperature: &client.ColorTemperature{}})
+
+	result := callTool(t, HandleWhitePreset(fb.client()), map[string]interface{}{"light_id": "Desk"})
+	if result.IsError {
+		t.Fatalf("white_preset failed: %s", resultText(result))
+	}
+
+	puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1")
+	if len(puts) != 1 {
+		t.Fatalf("Expected a single update, got %d", len(puts))
+	}
+	body := puts[0].Body
+	if on, _ := body["on"].(map[string]interface{}); on["on"] != true {
+		t.Errorf("Expected the light to be turned on, got %v", body["on"])
+	}
+	if dimming, _ := body["dimming"].(map[string]interface{}); dimming["brightness"] != 80.0 {
+		t.Errorf("Expected the default brightness of 80, got %v", body["dimming"])
+	}
+	if ct, _ := body["color_temperature"].(map[string]interface{}); ct["mirek"] != float64(client.KelvinToMirek(3000)) {
+		t.Errorf("Expected the default 3000K, got %v", body["color_temperature"])
+	}
+
+	result = callTool(t, HandleWhitePreset(fb.client()), map[string]interface{}{"light_id": "Desk", "kelvin": 5000.0, "brightness": 100.0})
+	if result.IsError {
+		t.Fatalf("white_preset with overrides failed: %s", resultText(result))
+	}
+	puts = fb.RequestsFor("PUT", "/clip/v2/resource/light/light-1")
+	if ct, _ := puts[1].Body["color_temperature"].(map[string]interface{}); ct["mirek"] != float64(client.KelvinToMirek(5000)) {
+		t.Errorf("Expected the kelvin override, got %v", puts[1].Body["color_temperature"])
+	}
+
+	result = callTool(t, HandleWhitePreset(fb.client()), map[string]interface{}{"light_id": "Desk", "group_id": "group-1"})
+	if !result.IsError {
+		t.Error("Expected light_id and group_id together to be rejected")
+	}
+}
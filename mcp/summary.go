@@ -0,0 +1,144 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// archetypeWatts is the rough full-brightness draw of each light archetype, in watts
+var archetypeWatts = map[string]float64{
+	"classic_bulb":      9,
+	"sultan_bulb":       9.5,
+	"candle_bulb":       5.3,
+	"luster_bulb":       5.5,
+	"spot_bulb":         5.7,
+	"flood_bulb":        16,
+	"vintage_bulb":      7,
+	"hue_lightstrip":    20,
+	"hue_lightstrip_tv": 20,
+	"hue_go":            6,
+	"hue_play":          6.6,
+	"hue_bloom":         8,
+	"hue_iris":          10,
+	"ceiling_round":     24,
+	"ceiling_square":    24,
+	"pendant_round":     25,
+	"pendant_long":      25,
+	"floor_shade":       20,
+	"table_shade":       10,
+	"recessed_ceiling":  9,
+	"plug":              0,
+}
+
+// defaultLightWatts is used for archetypes missing from the table
+const defaultLightWatts = 9.0
+
+// estimateLightWatts estimates a light's current draw from its archetype and brightness
+func estimateLightWatts(light client.Light) float64 {
+	if !light.On.On {
+		return 0
+	}
+	watts, ok := archetypeWatts[light.Metadata.Archetype]
+	if !ok {
+		watts = defaultLightWatts
+	}
+	// Lights without dimming report zero brightness but run at full power
+	if light.Dimming.Brightness > 0 {
+		watts *= light.Dimming.Brightness / 100
+	}
+	return watts
+}
+
+// HandleSystemSummary returns a handler reporting a one-shot overview of lights, groups, scenes and sensors
+func HandleSystemSummary(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		text, err := systemSummary(ctx, hueClient)
+		if err != nil {
+			return toolError("Failed to build system summary", err), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	}
+}
+
+func systemSummary(ctx context.Context, hueClient *client.Client) (string, error) {
+	lights, err := hueClient.GetLights(ctx)
+	if err != nil {
+		return "", fmt.Errorf("lights: %w", err)
+	}
+	rooms, err := hueClient.GetRooms(ctx)
+	if err != nil {
+		return "", fmt.Errorf("rooms: %w", err)
+	}
+	zones, err := hueClient.GetZones(ctx)
+	if err != nil {
+		return "", fmt.Errorf("zones: %w", err)
+	}
+	scenes, err := hueClient.GetScenes(ctx)
+	if err != nil {
+		return "", fmt.Errorf("scenes: %w", err)
+	}
+	motion, err := hueClient.GetMotionSensors(ctx)
+	if err != nil {
+		return "", fmt.Errorf("motion sensors: %w", err)
+	}
+	temperatures, err := hueClient.GetTemperatureSensors(ctx)
+	if err != nil {
+		return "", fmt.Errorf("temperature sensors: %w", err)
+	}
+	contacts, err := hueClient.GetContactSensors(ctx)
+	if err != nil {
+		return "", fmt.Errorf("contact sensors: %w", err)
+	}
+
+	on := 0
+	watts := 0.0
+	for _, light := range lights {
+		if light.On.On {
+			on++
+		}
+		watts += estimateLightWatts(light)
+	}
+
+	var result strings.Builder
+	result.WriteString("System summary:\n")
+	result.WriteString(fmt.Sprintf("Lights: %d (%d on, %d off)\n", len(lights), on, len(lights)-on))
+	result.WriteString(fmt.Sprintf("Estimated power draw: %.1f W\n", watts))
+	result.WriteString(fmt.Sprintf("Rooms: %d, Zones: %d, Scenes: %d\n", len(rooms), len(zones), len(scenes)))
+
+	detecting := 0
+	for _, sensor := range motion {
+		if sensor.Enabled && sensor.Motion.Motion {
+			detecting++
+		}
+	}
+	result.WriteString(fmt.Sprintf("Motion sensors: %d (%d detecting motion)\n", len(motion), detecting))
+
+	open := 0
+	for _, sensor := range contacts {
+		if sensor.ContactReport != nil && sensor.ContactReport.IsOpen() {
+			open++
+		}
+	}
+	result.WriteString(fmt.Sprintf("Contact sensors: %d (%d open)\n", len(contacts), open))
+
+	if len(temperatures) > 0 {
+		readings := make([]string, 0, len(temperatures))
+		for _, sensor := range temperatures {
+			if sensor.Enabled {
+				readings = append(readings, fmt.Sprintf("%.1f°C", sensor.Temperature.Temperature))
+			}
+		}
+		result.WriteString(fmt.Sprintf("Temperature sensors: %d", len(temperatures)))
+		if len(readings) > 0 {
+			result.WriteString(fmt.Sprintf(" (%s)", strings.Join(readings, ", ")))
+		}
+		result.WriteString("\n")
+	}
+
+	return result.String(), nil
+}
@@ -0,0 +1,38 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestHandleSystemSummary(t *testing.T) {
+	fb := newFakeBridge(t)
+	fb.Add("light", client.Light{ID: "light-1", Metadata: client.Metadata{Archetype: "classic_bulb"}, On: client.OnState{On: true}, Dimming: client.Dimming{Brightness: 50}})
+	fb.Add("light", client.Light{ID: "light-2", Metadata: client.Metadata{Archetype: "hue_lightstrip"}, On: client.OnState{On: true}, Dimming: client.Dimming{Brightness: 100}})
+	fb.Add("light", client.Light{ID: "light-3", Metadata: client.Metadata{Archetype: "candle_bulb"}})
+	fb.Add("room", client.Room{ID: "room-1"})
+	fb.Add("zone", client.Zone{ID: "zone-1"})
+	fb.Add("scene", client.Scene{ID: "scene-1"})
+	fb.Add("motion", client.Motion{ID: "motion-1", Enabled: true, Motion: client.MotionReport{Motion: true}})
+	fb.Add("contact", client.ContactSensor{ID: "contact-1", Enabled: true, ContactReport: &client.ContactReport{State: "no_contact"}})
+
+	result := callTool(t, HandleSystemSummary(fb.client()), nil)
+	if result.IsError {
+		t.Fatalf("system_summary failed: %s", resultText(result))
+	}
+
+	text := resultText(result)
+	for _, want := range []string{
+		"Lights: 3 (2 on, 1 off)",
+		"Estimated power draw: 24.5 W",
+		"Rooms: 1, Zones: 1, Scenes: 1",
+		"Motion sensors: 1 (1 detecting motion)",
+		"Contact sensors: 1 (1 open)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// webhookTimeout bounds each webhook delivery so a slow receiver can't pile up requests
+const webhookTimeout = 3 * time.Second
+
+var webhookClient = &http.Client{Timeout: webhookTimeout}
+
+// SetWebhook sets the URL that every stored event is POSTed to, or clears it when url is empty
+func (em *EventManager) SetWebhook(url string) {
+	em.webhookMutex.Lock()
+	defer em.webhookMutex.Unlock()
+	em.webhookURL = url
+}
+
+// Webhook returns the URL events are POSTed to, if any
+func (em *EventManager) Webhook() string {
+	em.webhookMutex.RLock()
+	defer em.webhookMutex.RUnlock()
+	return em.webhookURL
+}
+
+// sendWebhook POSTs the event as JSON in the background. Delivery is fire-and-forget:
+// failures are logged and never retried.
+func (em *EventManager) sendWebhook(event client.Event) {
+	target := em.Webhook()
+	if target == "" {
+		return
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to encode event %s for webhook: %v", event.ID, err)
+		return
+	}
+
+	go func() {
+		resp, err := webhookClient.Post(target, "application/json", bytes.NewReader(body))
+		if err != nil {
+			log.Printf("Event webhook failed: %v", err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			log.Printf("Event webhook returned %s", resp.Status)
+		}
+	}()
+}
+
+// HandleSetEventWebhook registers a URL that receives every event from the stream
+func HandleSetEventWebhook(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		rawURL, ok := args["url"].(string)
+		if !ok || rawURL == "" {
+			return mcp.NewToolResultError("url is required"), nil
+		}
+
+		parsed, err := url.Parse(rawURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid webhook URL '%s': use an http or https URL", rawURL)), nil
+		}
+
+		if eventManager == nil {
+			InitEventManager(hueClient)
+		}
+		eventManager.SetWebhook(parsed.String())
+
+		result := fmt.Sprintf("Events will be POSTed as JSON to %s", parsed.String())
+		if !eventManager.isStreaming() {
+			result += "\nStart the event stream with start_event_stream to begin sending them"
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+// HandleClearEventWebhook stops sending events to the webhook
+func HandleClearEventWebhook(hueClient *client.Client) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if eventManager == nil || eventManager.Webhook() == "" {
+			return mcp.NewToolResultText("No event webhook is set"), nil
+		}
+
+		eventManager.SetWebhook("")
+		return mcp.NewToolResultText("Event webhook cleared"), nil
+	}
+}
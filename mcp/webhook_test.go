@@ -0,0 +1,53 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func TestEventWebhookReceivesStoredEvents(t *testing.T) {
+	t.Setenv("HUE_MCP_DATA_DIR", t.TempDir())
+	fb := newFakeBridge(t)
+	hueClient := fb.client()
+	InitEventManager(hueClient)
+	
+	received := make(chan client.Event, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event client.Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("Failed to decode webhook body: %v", err)
+		}
+		received <- event
+	}))
+	defer receiver.Close()
+	
+	if result := callTool(t, HandleSetEventWebhook(hueClient), map[string]interface{}{"url": "ftp://example.com"}); !result.IsError {
+		t.Error("Expected a non-http URL to be rejected")
+	}
+	if result := callTool(t, HandleSetEventWebhook(hueClient), map[string]interface{}{"url": receiver.URL}); result.IsError {
+		t.Fatalf("set_event_webhook failed: %s", resultText(result))
+	}
+	
+	eventManager.storeEvent(client.Event{ID: "evt-1", Type: "update", Data: []client.EventData{{ID: "motion-1", Type: "motion"}}})
+	select {
+	case event := <-received:
+		if event.ID != "evt-1" || event.Data[0].ID != "motion-1" {
+			t.Errorf("Expected evt-1 for motion-1, got %+v", event)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the event to be POSTed to the webhook")
+	}
+	
+	callTool(t, HandleClearEventWebhook(hueClient), nil)
+	eventManager.storeEvent(client.Event{ID: "evt-2"})
+	select {
+	case event := <-received:
+		t.Errorf("Expected no delivery after clearing the webhook, got %s", event.ID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronNext(t *testing.T) {
+	base := time.Date(2024, 3, 15, 18, 45, 30, 0, time.Local) // Friday
+	
+	tests := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"30 18 * * *", time.Date(2024, 3, 16, 18, 30, 0, 0, time.Local)},
+		{"0 23 * * *", time.Date(2024, 3, 15, 23, 0, 0, 0, time.Local)},
+		{"*/15 * * * *", time.Date(2024, 3, 15, 19, 0, 0, 0, time.Local)},
+		{"0 7 * * 1-5", time.Date(2024, 3, 18, 7, 0, 0, 0, time.Local)},
+		{"0 9 1 * *", time.Date(2024, 4, 1, 9, 0, 0, 0, time.Local)},
+		{"0 0 * * 7", time.Date(2024, 3, 17, 0, 0, 0, 0, time.Local)},
+		{"46,50 18 * * *", time.Date(2024, 3, 15, 18, 46, 0, 0, time.Local)},
+	}
+	
+	for _, test := range tests {
+		cron, err := parseCron(test.spec)
+		if err != nil {
+			t.Errorf("parseCron(%q) failed: %v", test.spec, err)
+			continue
+		}
+		
+		if got := cron.Next(base); !got.Equal(test.expected) {
+			t.Errorf("parseCron(%q).Next = %s, expected %s", test.spec, got, test.expected)
+		}
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "a * * * *", "5-1 * * * *"} {
+		if _, err := parseCron(spec); err == nil {
+			t.Errorf("Expected error for cron %q", spec)
+		}
+	}
+}
@@ -2,7 +2,12 @@ package scheduler
 
 import (
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/kungfusheep/hue/client"
 )
 
 // EffectType represents the type of effect
@@ -16,6 +21,9 @@ const (
 	EffectFade      EffectType = "fade"
 	EffectRainbow   EffectType = "rainbow"
 	EffectAlert     EffectType = "alert"
+	EffectWindDown  EffectType = "wind_down"
+	EffectSunrise   EffectType = "sunrise"
+	EffectSunset    EffectType = "sunset"
 )
 
 // Effect represents a lighting effect configuration
@@ -124,6 +132,27 @@ func CreateColorLoopEffect(targetID string, colors []string, transitionTime time
 	}
 }
 
+// CreateColorTempLoopEffect cycles a light through white color temperatures, given in mirek
+func CreateColorTempLoopEffect(targetID string, mireks []int, transitionTime time.Duration) *Sequence {
+	commands := []Command{}
+	
+	for _, mirek := range mireks {
+		commands = append(commands, Command{
+			Type:   "light",
+			Action: "color_temp",
+			Target: targetID,
+			Params: map[string]interface{}{"mirek": float64(mirek)},
+			Delay:  transitionTime,
+		})
+	}
+	
+	return &Sequence{
+		Name:     fmt.Sprintf("WhiteLoop %s", targetID),
+		Commands: commands,
+		Loop:     true, // Loops like the color loop
+	}
+}
+
 // CreateStrobeEffect creates a strobe light effect
 func CreateStrobeEffect(targetID string, color string, strobeRate time.Duration, duration time.Duration) *Sequence {
 	commands := []Command{}
@@ -204,6 +233,9 @@ func CreateAlertEffect(targetID string, alertColor string, normalColor string) *
 
 // CreateFadeEffect creates a smooth fade between two states
 func CreateFadeEffect(targetID string, startColor string, endColor string, startBrightness, endBrightness float64, duration time.Duration, steps int) *Sequence {
+	if steps < 1 {
+		steps = 1
+	}
 	commands := []Command{}
 	stepDuration := duration / time.Duration(steps)
 	
@@ -223,37 +255,444 @@ func CreateFadeEffect(targetID string, startColor string, endColor string, start
 		Delay:  0,
 	})
 	
-	// For simplicity, we'll just transition brightness and then change color
-	// A more sophisticated implementation would interpolate colors
+	// Interpolate color and brightness together at each step
+	colors := interpolateHexColors(startColor, endColor, steps)
 	for i := 1; i <= steps; i++ {
 		progress := float64(i) / float64(steps)
 		brightness := startBrightness + (endBrightness-startBrightness)*progress
 		
+		commands = append(commands, Command{
+			Type:   "light",
+			Action: "color",
+			Target: targetID,
+			Params: map[string]interface{}{"color": colors[i-1]},
+			Delay:  stepDuration,
+		})
 		commands = append(commands, Command{
 			Type:   "light",
 			Action: "brightness",
 			Target: targetID,
 			Params: map[string]interface{}{"brightness": brightness},
+			Delay:  0,
+		})
+	}
+	
+	return &Sequence{
+		Name:     fmt.Sprintf("Fade %s", targetID),
+		Commands: commands,
+		Loop:     false,
+	}
+}
+
+// interpolateHexColors returns the colors at each of steps equal increments from start to end,
+// blending in RGB space. The last color is always end. If either color is not a valid
+// hex color the fade snaps to end on the final step.
+func interpolateHexColors(start, end string, steps int) []string {
+	if steps < 1 {
+		return nil
+	}
+	
+	colors := make([]string, steps)
+	sr, sg, sb, okStart := parseHexRGB(start)
+	er, eg, eb, okEnd := parseHexRGB(end)
+	
+	for i := 1; i <= steps; i++ {
+		if !okStart || !okEnd {
+			colors[i-1] = start
+			continue
+		}
+		
+		t := float64(i) / float64(steps)
+		colors[i-1] = fmt.Sprintf("#%02X%02X%02X", lerpByte(sr, er, t), lerpByte(sg, eg, t), lerpByte(sb, eb, t))
+	}
+	colors[steps-1] = end
+	
+	return colors
+}
+
+// parseHexRGB parses a #RRGGBB (or RRGGBB) color
+func parseHexRGB(hex string) (r, g, b uint8, ok bool) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return 0, 0, 0, false
+	}
+	
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	
+	return uint8(value >> 16), uint8(value >> 8), uint8(value), true
+}
+
+func lerpByte(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+}
+
+// Wind down starts at a relaxed evening level and ends at the warmest, dimmest setting
+const (
+	windDownSteps           = 20
+	windDownStartBrightness = 60.0
+	windDownEndBrightness   = 1.0
+	windDownStartMirek      = 300.0 // ~3300K
+	windDownEndMirek        = 500.0 // ~2000K, warmest supported
+)
+
+// CreateWindDownEffect gradually dims and warms the light over the duration, then turns it off.
+// It starts from the light's current brightness and mirek when those are dimmer or warmer than
+// the usual evening level, so a light that is already low never brightens; pass 0 when unknown.
+func CreateWindDownEffect(targetID string, duration time.Duration, currentBrightness float64, currentMirek int) *Sequence {
+	commands := []Command{}
+	stepDuration := duration / time.Duration(windDownSteps+1) // Final step turns the light off
+	
+	startBrightness := windDownStartBrightness
+	if currentBrightness > 0 && currentBrightness < startBrightness {
+		startBrightness = currentBrightness
+	}
+	startMirek := windDownStartMirek
+	if float64(currentMirek) > startMirek && float64(currentMirek) <= windDownEndMirek {
+		startMirek = float64(currentMirek)
+	}
+	
+	// Set initial state
+	commands = append(commands, Command{
+		Type:   "light",
+		Action: "color_temp",
+		Target: targetID,
+		Params: map[string]interface{}{"mirek": startMirek},
+		Delay:  0,
+	})
+	commands = append(commands, Command{
+		Type:   "light",
+		Action: "brightness",
+		Target: targetID,
+		Params: map[string]interface{}{"brightness": startBrightness},
+		Delay:  0,
+	})
+	
+	for i := 1; i <= windDownSteps; i++ {
+		progress := float64(i) / float64(windDownSteps)
+		brightness := startBrightness + (windDownEndBrightness-startBrightness)*progress
+		mirek := startMirek + (windDownEndMirek-startMirek)*progress
+		
+		commands = append(commands, Command{
+			Type:   "light",
+			Action: "color_temp",
+			Target: targetID,
+			Params: map[string]interface{}{"mirek": float64(int(mirek + 0.5))},
 			Delay:  stepDuration,
 		})
+		commands = append(commands, Command{
+			Type:   "light",
+			Action: "brightness",
+			Target: targetID,
+			Params: map[string]interface{}{"brightness": brightness},
+			Delay:  0,
+		})
+	}
+	
+	// Lights out
+	commands = append(commands, Command{
+		Type:   "light",
+		Action: "off",
+		Target: targetID,
+		Delay:  stepDuration,
+	})
+	
+	return &Sequence{
+		Name:     fmt.Sprintf("Wind Down %s", targetID),
+		Commands: commands,
+		Loop:     false,
+	}
+}
+
+// Sunrise starts at a deep red glow, passes through amber and ends at full daylight
+const (
+	sunriseMinBrightness = 1.0
+	sunriseMaxBrightness = 100.0
+	sunriseColorPhase    = 0.3   // Fraction of the sunrise spent in red/amber colors
+	sunriseWarmMirek     = 500.0 // ~2000K, where the color phase hands over
+	sunriseDaylightMirek = 153.0 // ~6500K
+	sunsetStartMirek     = 250.0 // ~4000K
+	sunsetEndBrightness  = 5.0
+)
+
+// sunriseColors are the dawn colors used before switching to color temperature. They stop
+// at orange: amber is the 2000K white the color temperature phase starts from, which
+// white-capable lights render better as a color temperature than as an xy color.
+var sunriseColors = []string{
+	"#8B0000", // Deep red
+	"#B22200",
+	"#E03C00",
+	"#FF6A00", // Orange
+}
+
+// CreateSunriseEffect brightens the light from deep red through amber to daylight.
+// Step 0 turns the light on at minimum brightness; each following step waits duration/steps.
+func CreateSunriseEffect(targetID string, duration time.Duration, steps int) *Sequence {
+	if steps < 1 {
+		steps = 1
 	}
+	commands := []Command{}
+	stepDuration := duration / time.Duration(steps)
 	
-	// Set final color
+	// Step 0: on at minimum brightness in deep red
+	commands = append(commands, Command{
+		Type:   "light",
+		Action: "on",
+		Target: targetID,
+		Delay:  0,
+	})
+	commands = append(commands, Command{
+		Type:   "light",
+		Action: "brightness",
+		Target: targetID,
+		Params: map[string]interface{}{"brightness": sunriseMinBrightness},
+		Delay:  0,
+	})
 	commands = append(commands, Command{
 		Type:   "light",
 		Action: "color",
 		Target: targetID,
-		Params: map[string]interface{}{"color": endColor},
+		Params: map[string]interface{}{"color": sunriseColors[0]},
 		Delay:  0,
 	})
 	
+	for i := 1; i <= steps; i++ {
+		progress := float64(i) / float64(steps)
+		brightness := sunriseMinBrightness + (sunriseMaxBrightness-sunriseMinBrightness)*progress
+		
+		var colorCmd Command
+		if progress < sunriseColorPhase {
+			idx := int(progress / sunriseColorPhase * float64(len(sunriseColors)))
+			colorCmd = Command{
+				Type:   "light",
+				Action: "color",
+				Target: targetID,
+				Params: map[string]interface{}{"color": sunriseColors[idx]},
+			}
+		} else {
+			ctProgress := (progress - sunriseColorPhase) / (1 - sunriseColorPhase)
+			mirek := sunriseWarmMirek + (sunriseDaylightMirek-sunriseWarmMirek)*ctProgress
+			colorCmd = Command{
+				Type:   "light",
+				Action: "color_temp",
+				Target: targetID,
+				Params: map[string]interface{}{"mirek": float64(int(mirek + 0.5))},
+			}
+		}
+		colorCmd.Delay = stepDuration
+		
+		commands = append(commands, colorCmd)
+		commands = append(commands, Command{
+			Type:   "light",
+			Action: "brightness",
+			Target: targetID,
+			Params: map[string]interface{}{"brightness": brightness},
+			Delay:  0,
+		})
+	}
+	
 	return &Sequence{
-		Name:     fmt.Sprintf("Fade %s", targetID),
+		Name:     fmt.Sprintf("Sunrise %s", targetID),
+		Commands: commands,
+		Loop:     false,
+	}
+}
+
+// CreateSunsetEffect fades the light from neutral white down to a warm, dim glow.
+// It starts at the light's current brightness, or full brightness when that is 0 (off or
+// unknown). The light is left on; each step waits duration/steps.
+func CreateSunsetEffect(targetID string, duration time.Duration, steps int, currentBrightness float64) *Sequence {
+	if steps < 1 {
+		steps = 1
+	}
+	commands := []Command{}
+	stepDuration := duration / time.Duration(steps)
+	
+	startBrightness := sunriseMaxBrightness
+	if currentBrightness > 0 && currentBrightness < startBrightness {
+		startBrightness = math.Max(currentBrightness, sunsetEndBrightness)
+	}
+	
+	// Set initial state
+	commands = append(commands, Command{
+		Type:   "light",
+		Action: "on",
+		Target: targetID,
+		Delay:  0,
+	})
+	commands = append(commands, Command{
+		Type:   "light",
+		Action: "color_temp",
+		Target: targetID,
+		Params: map[string]interface{}{"mirek": sunsetStartMirek},
+		Delay:  0,
+	})
+	commands = append(commands, Command{
+		Type:   "light",
+		Action: "brightness",
+		Target: targetID,
+		Params: map[string]interface{}{"brightness": startBrightness},
+		Delay:  0,
+	})
+	
+	for i := 1; i <= steps; i++ {
+		progress := float64(i) / float64(steps)
+		brightness := startBrightness + (sunsetEndBrightness-startBrightness)*progress
+		mirek := sunsetStartMirek + (sunriseWarmMirek-sunsetStartMirek)*progress
+		
+		commands = append(commands, Command{
+			Type:   "light",
+			Action: "color_temp",
+			Target: targetID,
+			Params: map[string]interface{}{"mirek": float64(int(mirek + 0.5))},
+			Delay:  stepDuration,
+		})
+		commands = append(commands, Command{
+			Type:   "light",
+			Action: "brightness",
+			Target: targetID,
+			Params: map[string]interface{}{"brightness": brightness},
+			Delay:  0,
+		})
+	}
+	
+	return &Sequence{
+		Name:     fmt.Sprintf("Sunset %s", targetID),
 		Commands: commands,
 		Loop:     false,
 	}
 }
 
+// Wake-up and sleep ramps run between these brightness levels
+const (
+	wakeUpStartBrightness = 1.0
+	sleepEndBrightness    = 1.0
+)
+
+// CreateWakeUpSequence turns the light on at minimum brightness and startKelvin, then ramps
+// brightness up to endBrightness while shifting to endKelvin (e.g. 2000K to 4000K).
+// Each step waits duration/steps.
+func CreateWakeUpSequence(targetID string, duration time.Duration, steps int, startKelvin, endKelvin int, endBrightness float64) *Sequence {
+	commands := []Command{{
+		Type:   "light",
+		Action: "on",
+		Target: targetID,
+		Delay:  0,
+	}}
+	commands = append(commands, colorTempRamp(targetID, duration, steps, startKelvin, endKelvin, wakeUpStartBrightness, endBrightness)...)
+	
+	return &Sequence{
+		Name:     fmt.Sprintf("Wake Up %s", targetID),
+		Commands: commands,
+		Loop:     false,
+	}
+}
+
+// CreateSleepSequence starts the light at startBrightness and startKelvin, ramps down to
+// minimum brightness at endKelvin (e.g. 4000K to 2000K) and turns it off one step later.
+// Each step waits duration/(steps+1).
+func CreateSleepSequence(targetID string, duration time.Duration, steps int, startKelvin, endKelvin int, startBrightness float64) *Sequence {
+	if steps < 1 {
+		steps = 1
+	}
+	stepDuration := duration / time.Duration(steps+1) // Final step turns the light off
+	
+	commands := []Command{{
+		Type:   "light",
+		Action: "on",
+		Target: targetID,
+		Delay:  0,
+	}}
+	commands = append(commands, colorTempRamp(targetID, stepDuration*time.Duration(steps), steps, startKelvin, endKelvin, startBrightness, sleepEndBrightness)...)
+	commands = append(commands, Command{
+		Type:   "light",
+		Action: "off",
+		Target: targetID,
+		Delay:  stepDuration,
+	})
+	
+	return &Sequence{
+		Name:     fmt.Sprintf("Sleep %s", targetID),
+		Commands: commands,
+		Loop:     false,
+	}
+}
+
+// CreateColorTempFadeEffect fades a light between two white color temperatures and brightness levels
+func CreateColorTempFadeEffect(targetID string, startKelvin, endKelvin int, startBrightness, endBrightness float64, duration time.Duration, steps int) *Sequence {
+	return &Sequence{
+		Name:     fmt.Sprintf("Fade %s", targetID),
+		Commands: colorTempRamp(targetID, duration, steps, startKelvin, endKelvin, startBrightness, endBrightness),
+		Loop:     false,
+	}
+}
+
+// colorTempRamp sets the start color temperature and brightness immediately, then moves both
+// linearly to their end values over the given number of steps
+func colorTempRamp(targetID string, duration time.Duration, steps int, startKelvin, endKelvin int, startBrightness, endBrightness float64) []Command {
+	if steps < 1 {
+		steps = 1
+	}
+	stepDuration := duration / time.Duration(steps)
+	startMirek := float64(client.KelvinToMirek(startKelvin))
+	endMirek := float64(client.KelvinToMirek(endKelvin))
+	
+	var commands []Command
+	for i := 0; i <= steps; i++ {
+		progress := float64(i) / float64(steps)
+		mirek := startMirek + (endMirek-startMirek)*progress
+		delay := stepDuration
+		if i == 0 {
+			delay = 0
+		}
+		
+		commands = append(commands, Command{
+			Type:   "light",
+			Action: "color_temp",
+			Target: targetID,
+			Params: map[string]interface{}{"mirek": float64(int(mirek + 0.5))},
+			Delay:  delay,
+		})
+		commands = append(commands, Command{
+			Type:   "light",
+			Action: "brightness",
+			Target: targetID,
+			Params: map[string]interface{}{"brightness": startBrightness + (endBrightness-startBrightness)*progress},
+			Delay:  0,
+		})
+	}
+	return commands
+}
+
+// CreateMultiLightEffect applies a single-light effect to each of the given lights.
+// Commands are repeated per light, keeping the original delay on the first copy only so
+// the lights change together.
+func CreateMultiLightEffect(effect *Sequence, lightIDs []string) *Sequence {
+	var commands []Command
+	for _, cmd := range effect.Commands {
+		if cmd.Type != "light" {
+			commands = append(commands, cmd)
+			continue
+		}
+		for i, lightID := range lightIDs {
+			lightCmd := cmd
+			lightCmd.Target = lightID
+			if i > 0 {
+				lightCmd.Delay = 0
+			}
+			commands = append(commands, lightCmd)
+		}
+	}
+	
+	return &Sequence{
+		Name:     fmt.Sprintf("%d lights - %s", len(lightIDs), effect.Name),
+		Commands: commands,
+		Loop:     effect.Loop,
+	}
+}
+
 // CreateGroupEffect applies an effect to all lights in a group
 func CreateGroupEffect(effect *Sequence, groupID string) *Sequence {
 	// Convert all light commands to group commands
@@ -272,4 +711,22 @@ func CreateGroupEffect(effect *Sequence, groupID string) *Sequence {
 		Commands: groupCommands,
 		Loop:     effect.Loop,
 	}
-}
\ No newline at end of file
+}
+
+// CreateEffectStop clears the effect on each target, ending effects that would otherwise run forever
+func CreateEffectStop(targetType string, targetIDs ...string) *Sequence {
+	commands := make([]Command, len(targetIDs))
+	for i, id := range targetIDs {
+		commands[i] = Command{
+			Type:   targetType,
+			Action: "effect",
+			Target: id,
+			Params: map[string]interface{}{"effect": "no_effect"},
+		}
+	}
+	
+	return &Sequence{
+		Name:     fmt.Sprintf("Stop effect on %s", strings.Join(targetIDs, ", ")),
+		Commands: commands,
+	}
+}
@@ -0,0 +1,308 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCreateWindDownEffect(t *testing.T) {
+	seq := CreateWindDownEffect("light-1", 10*time.Minute, 0, 0)
+	
+	if seq.Loop {
+		t.Error("Wind down should not loop")
+	}
+	
+	var brightness, mirek []float64
+	var total time.Duration
+	for _, cmd := range seq.Commands {
+		total += cmd.Delay
+		switch cmd.Action {
+		case "brightness":
+			brightness = append(brightness, cmd.Params["brightness"].(float64))
+		case "color_temp":
+			mirek = append(mirek, cmd.Params["mirek"].(float64))
+		}
+	}
+	
+	if len(brightness) < 2 || len(mirek) < 2 {
+		t.Fatalf("Expected brightness and color temperature steps, got %d and %d", len(brightness), len(mirek))
+	}
+	
+	for i := 1; i < len(brightness); i++ {
+		if brightness[i] >= brightness[i-1] {
+			t.Errorf("Brightness should decrease: step %d went from %.1f to %.1f", i, brightness[i-1], brightness[i])
+		}
+	}
+	
+	for i := 1; i < len(mirek); i++ {
+		if mirek[i] <= mirek[i-1] {
+			t.Errorf("Mirek should increase: step %d went from %.0f to %.0f", i, mirek[i-1], mirek[i])
+		}
+	}
+	
+	if mirek[len(mirek)-1] > 500 {
+		t.Errorf("Final mirek %.0f exceeds the supported range", mirek[len(mirek)-1])
+	}
+	
+	last := seq.Commands[len(seq.Commands)-1]
+	if last.Action != "off" || last.Target != "light-1" {
+		t.Errorf("Expected final command to turn light-1 off, got %s %s", last.Action, last.Target)
+	}
+	
+	if total < 10*time.Minute-time.Second || total > 11*time.Minute {
+		t.Errorf("Expected sequence to span about 10 minutes, got %v", total)
+	}
+}
+
+func TestCreateWindDownEffectForGroup(t *testing.T) {
+	seq := CreateGroupEffect(CreateWindDownEffect("group-1", time.Minute, 0, 0), "group-1")
+	
+	for _, cmd := range seq.Commands {
+		if cmd.Type != "group" || cmd.Target != "group-1" {
+			t.Fatalf("Expected all commands to target group-1, got %s %s", cmd.Type, cmd.Target)
+		}
+	}
+}
+
+func TestCreateWindDownEffectStartsFromDimLight(t *testing.T) {
+	seq := CreateWindDownEffect("light-1", time.Minute, 20, 400)
+	
+	for _, cmd := range seq.Commands {
+		if brightness, ok := cmd.Params["brightness"].(float64); ok && brightness > 20 {
+			t.Errorf("Expected a light at 20%% never to brighten, got %.1f%%", brightness)
+		}
+		if mirek, ok := cmd.Params["mirek"].(float64); ok && mirek < 400 {
+			t.Errorf("Expected a light at 400 mirek never to cool, got %.0f", mirek)
+		}
+	}
+	
+	bright := CreateWindDownEffect("light-1", time.Minute, 100, 153)
+	if first := bright.Commands[1].Params["brightness"].(float64); first != windDownStartBrightness {
+		t.Errorf("Expected a bright light to start at %.0f%%, got %.1f%%", windDownStartBrightness, first)
+	}
+}
+
+func TestCreateSunriseEffect(t *testing.T) {
+	seq := CreateSunriseEffect("light-1", 30*time.Minute, 30)
+	
+	if seq.Commands[0].Action != "on" || seq.Commands[0].Delay != 0 {
+		t.Fatalf("Expected step 0 to turn the light on immediately, got %+v", seq.Commands[0])
+	}
+	if b := seq.Commands[1].Params["brightness"].(float64); b != sunriseMinBrightness {
+		t.Errorf("Expected step 0 at minimum brightness, got %v", b)
+	}
+	if c := seq.Commands[2].Params["color"]; c != sunriseColors[0] {
+		t.Errorf("Expected step 0 in deep red, got %v", c)
+	}
+	
+	var brightness, mirek []float64
+	var total time.Duration
+	for _, cmd := range seq.Commands {
+		if cmd.Delay != 0 && cmd.Delay != time.Minute {
+			t.Errorf("Expected per-step delay of duration/steps (1m), got %s", cmd.Delay)
+		}
+		total += cmd.Delay
+		switch cmd.Action {
+		case "brightness":
+			brightness = append(brightness, cmd.Params["brightness"].(float64))
+		case "color_temp":
+			mirek = append(mirek, cmd.Params["mirek"].(float64))
+		}
+	}
+	
+	if total != 30*time.Minute {
+		t.Errorf("Expected total duration of 30m, got %s", total)
+	}
+	
+	for i := 1; i < len(brightness); i++ {
+		if brightness[i] <= brightness[i-1] {
+			t.Errorf("Brightness should increase: step %d went from %.1f to %.1f", i, brightness[i-1], brightness[i])
+		}
+	}
+	if brightness[len(brightness)-1] != sunriseMaxBrightness {
+		t.Errorf("Expected sunrise to end at full brightness, got %.1f", brightness[len(brightness)-1])
+	}
+	
+	// Amber comes from the color temperature phase rather than an xy color
+	if len(mirek) == 0 || mirek[0] != sunriseWarmMirek {
+		t.Errorf("Expected the color temperature phase to start at %v mirek, got %v", sunriseWarmMirek, mirek)
+	}
+	for i := 1; i < len(mirek); i++ {
+		if mirek[i] >= mirek[i-1] {
+			t.Errorf("Color temperature should get cooler: step %d went from %.0f to %.0f mirek", i, mirek[i-1], mirek[i])
+		}
+	}
+	if len(mirek) == 0 || mirek[len(mirek)-1] != sunriseDaylightMirek {
+		t.Errorf("Expected sunrise to end at daylight (%v mirek), got %v", sunriseDaylightMirek, mirek)
+	}
+}
+
+func TestCreateSunsetEffect(t *testing.T) {
+	seq := CreateSunsetEffect("light-1", 10*time.Minute, 20, 0)
+	
+	last := seq.Commands[len(seq.Commands)-1]
+	if last.Action != "brightness" || last.Params["brightness"].(float64) != sunsetEndBrightness {
+		t.Errorf("Expected sunset to end dim, got %+v", last)
+	}
+	
+	for _, cmd := range seq.Commands {
+		if cmd.Action == "off" {
+			t.Error("Sunset should leave the light on")
+		}
+	}
+}
+
+func TestCreateSunsetEffectStartsFromCurrentBrightness(t *testing.T) {
+	seq := CreateSunsetEffect("light-1", 10*time.Minute, 20, 40)
+	
+	for _, cmd := range seq.Commands {
+		if brightness, ok := cmd.Params["brightness"].(float64); ok && brightness > 40 {
+			t.Fatalf("Expected a light at 40%% never to brighten, got %.1f%%", brightness)
+		}
+	}
+}
+
+func TestInterpolateHexColorsMidpoint(t *testing.T) {
+	colors := interpolateHexColors("#FF0000", "#0000FF", 2)
+	if len(colors) != 2 {
+		t.Fatalf("Expected 2 colors, got %d", len(colors))
+	}
+	
+	r, g, b, ok := parseHexRGB(colors[0])
+	if !ok {
+		t.Fatalf("Midpoint %s is not a valid hex color", colors[0])
+	}
+	if r < 0x70 || r > 0x90 || g != 0 || b < 0x70 || b > 0x90 {
+		t.Errorf("Expected a purple midpoint, got %s", colors[0])
+	}
+	
+	if colors[1] != "#0000FF" {
+		t.Errorf("Expected fade to end on #0000FF, got %s", colors[1])
+	}
+}
+
+func TestCreateFadeEffectInterpolatesColor(t *testing.T) {
+	seq := CreateFadeEffect("light-1", "#FF0000", "#0000FF", 100, 20, time.Second, 10)
+	
+	var colors []string
+	for _, cmd := range seq.Commands {
+		if cmd.Action == "color" {
+			colors = append(colors, cmd.Params["color"].(string))
+		}
+	}
+	
+	// Initial color plus one per step
+	if len(colors) != 11 {
+		t.Fatalf("Expected 11 color commands, got %d", len(colors))
+	}
+	if colors[0] != "#FF0000" || colors[10] != "#0000FF" {
+		t.Errorf("Expected fade from #FF0000 to #0000FF, got %s to %s", colors[0], colors[10])
+	}
+	
+	for i := 1; i < len(colors); i++ {
+		r1, _, b1, _ := parseHexRGB(colors[i-1])
+		r2, _, b2, _ := parseHexRGB(colors[i])
+		if r2 > r1 || b2 < b1 {
+			t.Errorf("Expected red to fall and blue to rise at step %d: %s -> %s", i, colors[i-1], colors[i])
+		}
+	}
+}
+
+func TestCreateMultiLightEffectKeepsTiming(t *testing.T) {
+	effect := &Sequence{Name: "Test", Commands: []Command{
+		{Type: "light", Action: "on", Target: "x"},
+		{Type: "light", Action: "off", Target: "x", Delay: time.Second},
+	}}
+	
+	seq := CreateMultiLightEffect(effect, []string{"a", "b"})
+	
+	if len(seq.Commands) != 4 {
+		t.Fatalf("Expected 4 commands, got %d", len(seq.Commands))
+	}
+	
+	var total time.Duration
+	for _, cmd := range seq.Commands {
+		total += cmd.Delay
+	}
+	if total != time.Second {
+		t.Errorf("Expected the total delay to stay at 1s, got %v", total)
+	}
+	if seq.Commands[2].Target != "a" || seq.Commands[2].Delay != time.Second || seq.Commands[3].Target != "b" || seq.Commands[3].Delay != 0 {
+		t.Errorf("Unexpected off commands: %+v", seq.Commands[2:])
+	}
+}
+
+func TestCreateWakeUpSequence(t *testing.T) {
+	seq := CreateWakeUpSequence("light-1", 20*time.Minute, 20, 2000, 4000, 80)
+	
+	if seq.Commands[0].Action != "on" {
+		t.Fatalf("Expected the wake up to turn the light on first, got %+v", seq.Commands[0])
+	}
+	
+	var brightness, mirek []float64
+	var total time.Duration
+	for _, cmd := range seq.Commands {
+		total += cmd.Delay
+		switch cmd.Action {
+		case "brightness":
+			brightness = append(brightness, cmd.Params["brightness"].(float64))
+		case "color_temp":
+			mirek = append(mirek, cmd.Params["mirek"].(float64))
+		}
+	}
+	
+	if total != 20*time.Minute {
+		t.Errorf("Expected total duration of 20m, got %s", total)
+	}
+	if mirek[0] != 500 || mirek[len(mirek)-1] != 250 {
+		t.Errorf("Expected 2000K (500 mirek) to 4000K (250 mirek), got %v to %v", mirek[0], mirek[len(mirek)-1])
+	}
+	if brightness[0] != wakeUpStartBrightness || brightness[len(brightness)-1] != 80 {
+		t.Errorf("Expected brightness to ramp from minimum to 80%%, got %v to %v", brightness[0], brightness[len(brightness)-1])
+	}
+	for i := 1; i < len(brightness); i++ {
+		if brightness[i] <= brightness[i-1] {
+			t.Errorf("Brightness should increase: step %d went from %.1f to %.1f", i, brightness[i-1], brightness[i])
+		}
+	}
+}
+
+func TestCreateSleepSequenceEndsOff(t *testing.T) {
+	seq := CreateSleepSequence("light-1", 10*time.Minute, 9, 4000, 2000, 70)
+	
+	var total time.Duration
+	var mirek []float64
+	for _, cmd := range seq.Commands {
+		total += cmd.Delay
+		if cmd.Action == "color_temp" {
+			mirek = append(mirek, cmd.Params["mirek"].(float64))
+		}
+	}
+	
+	if total != 10*time.Minute {
+		t.Errorf("Expected total duration of 10m, got %s", total)
+	}
+	if mirek[0] != 250 || mirek[len(mirek)-1] != 500 {
+		t.Errorf("Expected 4000K to 2000K, got %v to %v mirek", mirek[0], mirek[len(mirek)-1])
+	}
+	if last := seq.Commands[len(seq.Commands)-1]; last.Action != "off" {
+		t.Errorf("Expected the sleep sequence to end with the light off, got %+v", last)
+	}
+}
+
+func TestCreateColorTempLoopEffect(t *testing.T) {
+	seq := CreateColorTempLoopEffect("light-1", []int{370, 250}, 30*time.Second)
+	
+	if !seq.Loop {
+		t.Error("Expected the white loop to loop by default")
+	}
+	if len(seq.Commands) != 2 {
+		t.Fatalf("Expected one command per temperature, got %d", len(seq.Commands))
+	}
+	for i, want := range []float64{370, 250} {
+		cmd := seq.Commands[i]
+		if cmd.Action != "color_temp" || cmd.Params["mirek"] != want || cmd.Delay != 30*time.Second {
+			t.Errorf("Command %d: expected color_temp %v after 30s, got %+v", i, want, cmd)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Schedule runs a sequence at a fixed time or on a cron expression
+type Schedule struct {
+	ID        string    `json:"id"`
+	Sequence  *Sequence `json:"sequence"`
+	At        time.Time `json:"at,omitempty"`   // One-shot run time
+	Cron      string    `json:"cron,omitempty"` // Recurring cron expression
+	NextRun   time.Time `json:"next_run"`
+	CreatedAt time.Time `json:"created_at"`
+
+	cron     *cronSpec
+	runIDs   []string
+	stopChan chan struct{}
+}
+
+// ScheduleAt runs a sequence once at the given time
+func (s *Scheduler) ScheduleAt(seq *Sequence, when time.Time) (string, error) {
+	if !when.After(time.Now()) {
+		return "", fmt.Errorf("scheduled time %s is in the past", when.Format(time.RFC3339))
+	}
+
+	sched := &Schedule{
+		ID:        fmt.Sprintf("sched_%d", time.Now().UnixNano()),
+		Sequence:  seq,
+		At:        when,
+		CreatedAt: time.Now(),
+	}
+
+	return s.addSchedule(sched)
+}
+
+// ScheduleCron runs a sequence every time the cron expression matches (local time)
+func (s *Scheduler) ScheduleCron(seq *Sequence, spec string) (string, error) {
+	cron, err := parseCron(spec)
+	if err != nil {
+		return "", err
+	}
+
+	sched := &Schedule{
+		ID:        fmt.Sprintf("sched_%d", time.Now().UnixNano()),
+		Sequence:  seq,
+		Cron:      spec,
+		CreatedAt: time.Now(),
+		cron:      cron,
+	}
+
+	return s.addSchedule(sched)
+}
+
+// CancelSchedule removes a schedule and stops any run it started that is still going
+func (s *Scheduler) CancelSchedule(scheduleID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sched, exists := s.schedules[scheduleID]
+	if !exists {
+		return fmt.Errorf("schedule %s not found", scheduleID)
+	}
+
+	close(sched.stopChan)
+	delete(s.schedules, scheduleID)
+
+	for _, runID := range sched.runIDs {
+		if seq, ok := s.sequences[runID]; ok && seq.Running && seq.stopChan != nil {
+			close(seq.stopChan)
+			seq.Running = false
+		}
+	}
+
+	s.persistSchedulesLocked()
+	return nil
+}
+
+// GetSchedules returns all pending schedules ordered by their next run
+func (s *Scheduler) GetSchedules() []Schedule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]Schedule, 0, len(s.schedules))
+	for _, sched := range s.schedules {
+		result = append(result, *sched)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NextRun.Before(result[j].NextRun)
+	})
+	return result
+}
+
+// EnableSchedulePersistence stores schedules in the given file and restores any saved there.
+// One-shot schedules whose time passed while the server was down are dropped.
+func (s *Scheduler) EnableSchedulePersistence(path string) error {
+	var saved []*Schedule
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &saved); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+
+	s.mu.Lock()
+	s.schedulePath = path
+	s.mu.Unlock()
+
+	for _, sched := range saved {
+		if sched.Sequence == nil {
+			continue
+		}
+		if sched.Cron != "" {
+			cron, err := parseCron(sched.Cron)
+			if err != nil {
+				log.Printf("Dropping schedule %s: %v", sched.ID, err)
+				continue
+			}
+			sched.cron = cron
+		} else if !sched.At.After(time.Now()) {
+			log.Printf("Dropping schedule %s: missed run at %s", sched.ID, sched.At.Format(time.RFC3339))
+			continue
+		}
+
+		if _, err := s.addSchedule(sched); err != nil {
+			log.Printf("Failed to restore schedule %s: %v", sched.ID, err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.persistSchedulesLocked()
+	return nil
+}
+
+// addSchedule registers a schedule and starts its timer goroutine
+func (s *Scheduler) addSchedule(sched *Schedule) (string, error) {
+	next := sched.nextRun(time.Now())
+	if next.IsZero() {
+		return "", fmt.Errorf("schedule never runs")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sched.NextRun = next
+	sched.stopChan = make(chan struct{})
+	s.schedules[sched.ID] = sched
+	s.persistSchedulesLocked()
+
+	go s.runSchedule(sched)
+
+	return sched.ID, nil
+}
+
+// nextRun returns the next time the schedule should fire after t, or zero if it is finished
+func (sched *Schedule) nextRun(t time.Time) time.Time {
+	if sched.cron != nil {
+		return sched.cron.Next(t)
+	}
+	if sched.At.After(t) {
+		return sched.At
+	}
+	return time.Time{}
+}
+
+// runSchedule waits for each run time and starts a fresh copy of the sequence
+func (s *Scheduler) runSchedule(sched *Schedule) {
+	var lastRun *Sequence
+	for {
+		s.mu.RLock()
+		next := sched.NextRun
+		s.mu.RUnlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-timer.C:
+		case <-sched.stopChan:
+			timer.Stop()
+			return
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		}
+
+		run := &Sequence{
+			ID:        fmt.Sprintf("%s_run_%d", sched.ID, time.Now().UnixNano()),
+			Name:      sched.Sequence.Name,
+			Commands:  sched.Sequence.Commands,
+			Loop:      sched.Sequence.Loop,
+			transient: true,
+		}
+		lastRun = run
+
+		// Start the run under the lock so a concurrent cancel either prevents it or sees it
+		s.mu.Lock()
+		select {
+		case <-sched.stopChan:
+			s.mu.Unlock()
+			return
+		default:
+		}
+
+		var active []string
+		for _, runID := range sched.runIDs {
+			if seq, ok := s.sequences[runID]; ok && seq.Running {
+				active = append(active, runID)
+			}
+		}
+		sched.runIDs = append(active, run.ID)
+		s.startSequenceLocked(run)
+
+		sched.NextRun = sched.nextRun(time.Now())
+		if sched.NextRun.IsZero() {
+			s.mu.Unlock()
+			break
+		}
+		s.persistSchedulesLocked()
+		s.mu.Unlock()
+	}
+
+	// One-shot schedules stay cancellable until their run finishes
+	select {
+	case <-sched.stopChan:
+		return
+	case <-s.ctx.Done():
+		return
+	case <-lastRun.done:
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.schedules[sched.ID]; exists {
+		delete(s.schedules, sched.ID)
+		s.persistSchedulesLocked()
+	}
+}
+
+// sequenceDone returns a channel closed once the sequence has finished running.
+// Sequences that have already been forgotten count as finished.
+func (s *Scheduler) sequenceDone(sequenceID string) <-chan struct{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if seq, ok := s.sequences[sequenceID]; ok {
+		return seq.done
+	}
+	closed := make(chan struct{})
+	close(closed)
+	return closed
+}
+
+// persistSchedulesLocked writes schedules to disk. The caller must hold s.mu.
+func (s *Scheduler) persistSchedulesLocked() {
+	if s.schedulePath == "" {
+		return
+	}
+
+	schedules := make([]*Schedule, 0, len(s.schedules))
+	for _, sched := range s.schedules {
+		schedules = append(schedules, sched)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].ID < schedules[j].ID
+	})
+
+	data, err := json.MarshalIndent(schedules, "", "  ")
+	if err != nil {
+		log.Printf("Failed to encode schedules: %v", err)
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.schedulePath), 0755); err != nil {
+		log.Printf("Failed to save schedules: %v", err)
+		return
+	}
+
+	tmp := s.schedulePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		log.Printf("Failed to save schedules: %v", err)
+		return
+	}
+	if err := os.Rename(tmp, s.schedulePath); err != nil {
+		log.Printf("Failed to save schedules: %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+)
+
+func newTestScheduler(t *testing.T) *Scheduler {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{}, "errors": []interface{}{}})
+	}))
+	t.Cleanup(server.Close)
+	
+	s := NewScheduler(client.NewClient(strings.TrimPrefix(server.URL, "https://"), "test-key", server.Client()))
+	t.Cleanup(s.Stop)
+	return s
+}
+
+func TestScheduleAtRunsSequence(t *testing.T) {
+	var puts int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			atomic.AddInt32(&puts, 1)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{}, "errors": []interface{}{}})
+	}))
+	t.Cleanup(server.Close)
+	
+	s := NewScheduler(client.NewClient(strings.TrimPrefix(server.URL, "https://"), "test-key", server.Client()))
+	t.Cleanup(s.Stop)
+	
+	seq := &Sequence{Name: "Test", Commands: []Command{{Type: "light", Action: "on", Target: "light-1"}}}
+	id, err := s.ScheduleAt(seq, time.Now().Add(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("ScheduleAt failed: %v", err)
+	}
+	
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if len(s.GetSchedules()) == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	
+	if len(s.GetSchedules()) != 0 {
+		t.Fatalf("Expected one-shot schedule %s to be removed after running", id)
+	}
+	if atomic.LoadInt32(&puts) != 1 {
+		t.Errorf("Expected the schedule's run to turn the light on once, got %d requests", puts)
+	}
+	
+	// Finished runs are forgotten so repeated schedules don't pile up
+	for seqID := range s.GetSequences() {
+		if strings.HasPrefix(seqID, id+"_run_") {
+			t.Errorf("Expected the finished run %s to be removed", seqID)
+		}
+	}
+}
+
+func TestFinishedSequencesArePruned(t *testing.T) {
+	s := newTestScheduler(t)
+	
+	for i := 0; i < maxFinishedSequences+5; i++ {
+		id, err := s.ExecuteSequence(&Sequence{ID: fmt.Sprintf("seq-%d", i)})
+		if err != nil {
+			t.Fatalf("ExecuteSequence failed: %v", err)
+		}
+		<-s.sequenceDone(id)
+	}
+	
+	sequences := s.GetSequences()
+	if len(sequences) != maxFinishedSequences {
+		t.Errorf("Expected %d finished sequences to be kept, got %d", maxFinishedSequences, len(sequences))
+	}
+	if _, ok := sequences["seq-0"]; ok {
+		t.Error("Expected the oldest finished sequence to be pruned")
+	}
+	if _, ok := sequences[fmt.Sprintf("seq-%d", maxFinishedSequences+4)]; !ok {
+		t.Error("Expected the newest finished sequence to be kept")
+	}
+}
+
+func TestScheduleAtRejectsPast(t *testing.T) {
+	s := newTestScheduler(t)
+	
+	if _, err := s.ScheduleAt(&Sequence{Name: "Late"}, time.Now().Add(-time.Minute)); err == nil {
+		t.Error("Expected error scheduling in the past")
+	}
+}
+
+func TestCancelScheduleStopsInFlightRun(t *testing.T) {
+	s := newTestScheduler(t)
+	
+	seq := &Sequence{
+		Name: "Slow",
+		Commands: []Command{
+			{Type: "light", Action: "on", Target: "light-1"},
+			{Type: "light", Action: "off", Target: "light-1", Delay: time.Hour},
+		},
+	}
+	id, err := s.ScheduleAt(seq, time.Now().Add(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("ScheduleAt failed: %v", err)
+	}
+	
+	var run *Sequence
+	deadline := time.Now().Add(2 * time.Second)
+	for run == nil && time.Now().Before(deadline) {
+		for seqID, candidate := range s.GetSequences() {
+			if strings.HasPrefix(seqID, id+"_run_") {
+				run = candidate
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if run == nil {
+		t.Fatal("Schedule never started its run")
+	}
+	
+	if err := s.CancelSchedule(id); err != nil {
+		t.Fatalf("CancelSchedule failed: %v", err)
+	}
+	
+	select {
+	case <-run.done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected cancelling the schedule to stop its in-flight run")
+	}
+	
+	if len(s.GetSchedules()) != 0 {
+		t.Error("Expected schedule to be removed")
+	}
+}
+
+func TestSchedulePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedules.json")
+	
+	s := newTestScheduler(t)
+	if err := s.EnableSchedulePersistence(path); err != nil {
+		t.Fatalf("EnableSchedulePersistence failed: %v", err)
+	}
+	
+	seq := &Sequence{Name: "Nightly off", Commands: []Command{{Type: "group", Action: "off", Target: "group-1"}}}
+	cronID, err := s.ScheduleCron(seq, "0 23 * * *")
+	if err != nil {
+		t.Fatalf("ScheduleCron failed: %v", err)
+	}
+	if _, err := s.ScheduleAt(seq, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("ScheduleAt failed: %v", err)
+	}
+	
+	restored := newTestScheduler(t)
+	if err := restored.EnableSchedulePersistence(path); err != nil {
+		t.Fatalf("EnableSchedulePersistence failed: %v", err)
+	}
+	
+	schedules := restored.GetSchedules()
+	if len(schedules) != 2 {
+		t.Fatalf("Expected 2 restored schedules, got %d", len(schedules))
+	}
+	
+	if err := restored.CancelSchedule(cronID); err != nil {
+		t.Fatalf("CancelSchedule failed: %v", err)
+	}
+	
+	again := newTestScheduler(t)
+	if err := again.EnableSchedulePersistence(path); err != nil {
+		t.Fatalf("EnableSchedulePersistence failed: %v", err)
+	}
+	if len(again.GetSchedules()) != 1 {
+		t.Errorf("Expected cancellation to be persisted, got %d schedules", len(again.GetSchedules()))
+	}
+}
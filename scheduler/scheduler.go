@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
@@ -11,30 +12,125 @@ import (
 
 // Command represents a scheduled command
 type Command struct {
-	Type      string                 // "light", "group", "scene", etc.
-	Action    string                 // "on", "off", "color", "brightness", etc.
-	Target    string                 // ID of the target (light, group, etc.)
-	Params    map[string]interface{} // Additional parameters
-	Delay     time.Duration          // Delay before executing this command
+	Type      string                 `json:"type"`             // "light", "group", "scene", etc.
+	Action    string                 `json:"action"`           // "on", "off", "color", "brightness", etc.
+	Target    string                 `json:"target"`           // ID of the target (light, group, etc.)
+	Params    map[string]interface{} `json:"params,omitempty"` // Additional parameters
+	Delay     time.Duration          `json:"-"`                // Delay before executing this command
+}
+
+// commandJSON is the wire format of a Command, with the delay in milliseconds
+type commandJSON struct {
+	Type    string                 `json:"type"`
+	Action  string                 `json:"action"`
+	Target  string                 `json:"target"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+	DelayMs int64                  `json:"delay,omitempty"`
+}
+
+// MarshalJSON encodes the command with its delay in milliseconds
+func (c Command) MarshalJSON() ([]byte, error) {
+	return json.Marshal(commandJSON{
+		Type:    c.Type,
+		Action:  c.Action,
+		Target:  c.Target,
+		Params:  c.Params,
+		DelayMs: c.Delay.Milliseconds(),
+	})
+}
+
+// UnmarshalJSON decodes a command whose delay is given in milliseconds
+func (c *Command) UnmarshalJSON(data []byte) error {
+	var raw commandJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	
+	*c = Command{
+		Type:   raw.Type,
+		Action: raw.Action,
+		Target: raw.Target,
+		Params: raw.Params,
+		Delay:  time.Duration(raw.DelayMs) * time.Millisecond,
+	}
+	return nil
 }
 
 // Sequence represents a sequence of commands
 type Sequence struct {
-	ID       string
-	Name     string
-	Commands []Command
-	Loop     bool          // Whether to loop the sequence
-	Running  bool
-	stopChan chan struct{}
+	ID       string    `json:"id,omitempty"`
+	Name     string    `json:"name"`
+	Commands []Command `json:"commands"`
+	Loop     bool      `json:"loop"` // Whether to loop the sequence
+	Running  bool      `json:"running,omitempty"`
+	// Progress of a running sequence, updated as each command starts
+	StartedAt      time.Time `json:"started_at,omitempty"`
+	CurrentCommand int       `json:"current_command"`
+	commandStarted time.Time
+	Paused         bool `json:"paused,omitempty"`
+	resumeChan     chan struct{} // Closed when a paused sequence is resumed
+	pauseChan      chan struct{} // Closed when a running sequence is paused
+	pausedAt       time.Time
+	stopChan       chan struct{}
+	done           chan struct{}
+	transient      bool // Forgotten as soon as it finishes, as scheduled runs are
 }
 
+// SequenceProgress describes how far through its commands a sequence is
+type SequenceProgress struct {
+	Command   int           // 1-based index of the command being run or waited on
+	Total     int           // Number of commands in one pass
+	Remaining time.Duration // Estimated time left from the command delays
+	Infinite  bool          // Looping sequences never finish on their own
+}
+
+// String formats the progress as "command 4/20, ~12s remaining"
+func (p SequenceProgress) String() string {
+	if p.Infinite {
+		return fmt.Sprintf("command %d/%d, ∞ remaining (loops)", p.Command, p.Total)
+	}
+	return fmt.Sprintf("command %d/%d, ~%s remaining", p.Command, p.Total, p.Remaining.Round(time.Second))
+}
+
+// Progress estimates the sequence's position and remaining time at the given moment
+func (seq *Sequence) Progress(now time.Time) SequenceProgress {
+	progress := SequenceProgress{
+		Command:  min(seq.CurrentCommand+1, len(seq.Commands)),
+		Total:    len(seq.Commands),
+		Infinite: seq.Loop,
+	}
+	if seq.Loop || seq.CurrentCommand >= len(seq.Commands) {
+		return progress
+	}
+	
+	// Time left on the current command's delay plus every delay still to come.
+	// The delay doesn't count down while paused.
+	if seq.Paused {
+		now = seq.pausedAt
+	}
+	if !seq.commandStarted.IsZero() {
+		progress.Remaining = max(0, seq.Commands[seq.CurrentCommand].Delay-now.Sub(seq.commandStarted))
+	}
+	for _, cmd := range seq.Commands[seq.CurrentCommand+1:] {
+		progress.Remaining += cmd.Delay
+	}
+	
+	return progress
+}
+
+// maxFinishedSequences bounds how many finished sequences are kept for list_sequences
+const maxFinishedSequences = 50
+
 // Scheduler manages scheduled lighting operations
 type Scheduler struct {
-	client    *client.Client
-	sequences map[string]*Sequence
-	mu        sync.RWMutex
-	ctx       context.Context
-	cancel    context.CancelFunc
+	client       *client.Client
+	sequences    map[string]*Sequence
+	finished     []string // IDs of finished sequences still in sequences, oldest first
+	schedules    map[string]*Schedule
+	schedulePath string
+	mu           sync.RWMutex
+	ctx          context.Context
+	cancel       context.CancelFunc
 }
 
 // NewScheduler creates a new scheduler
@@ -43,6 +139,7 @@ func NewScheduler(client *client.Client) *Scheduler {
 	return &Scheduler{
 		client:    client,
 		sequences: make(map[string]*Sequence),
+		schedules: make(map[string]*Schedule),
 		ctx:       ctx,
 		cancel:    cancel,
 	}
@@ -97,14 +194,26 @@ func (s *Scheduler) ExecuteSequence(seq *Sequence) (string, error) {
 		return "", fmt.Errorf("sequence %s is already running", seq.ID)
 	}
 	
+	s.startSequenceLocked(seq)
+	
+	return seq.ID, nil
+}
+
+// startSequenceLocked registers and starts a sequence. The caller must hold s.mu.
+func (s *Scheduler) startSequenceLocked(seq *Sequence) {
 	seq.Running = true
+	seq.StartedAt = time.Now()
+	seq.CurrentCommand = 0
+	seq.commandStarted = time.Time{}
+	seq.Paused = false
+	seq.resumeChan = nil
+	seq.pauseChan = make(chan struct{})
 	seq.stopChan = make(chan struct{})
+	seq.done = make(chan struct{})
 	s.sequences[seq.ID] = seq
 	
 	// Start the sequence in a goroutine
 	go s.runSequence(seq)
-	
-	return seq.ID, nil
 }
 
 // StopSequence stops a running sequence
@@ -125,29 +234,178 @@ func (s *Scheduler) StopSequence(sequenceID string) error {
 	return nil
 }
 
+// PauseSequence holds a running sequence, including any delay it is waiting out, until it is resumed
+func (s *Scheduler) PauseSequence(sequenceID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	seq, exists := s.sequences[sequenceID]
+	if !exists {
+		return fmt.Errorf("sequence %s not found", sequenceID)
+	}
+	if !seq.Running {
+		return fmt.Errorf("sequence %s is not running", sequenceID)
+	}
+	if seq.Paused {
+		return nil
+	}
+	
+	seq.Paused = true
+	seq.pausedAt = time.Now()
+	seq.resumeChan = make(chan struct{})
+	close(seq.pauseChan)
+	return nil
+}
+
+// ResumeSequence continues a paused sequence from the command it stopped at
+func (s *Scheduler) ResumeSequence(sequenceID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	seq, exists := s.sequences[sequenceID]
+	if !exists {
+		return fmt.Errorf("sequence %s not found", sequenceID)
+	}
+	if !seq.Paused {
+		return fmt.Errorf("sequence %s is not paused", sequenceID)
+	}
+	
+	// The delay clock stood still while paused
+	if !seq.commandStarted.IsZero() {
+		seq.commandStarted = seq.commandStarted.Add(time.Since(seq.pausedAt))
+	}
+	seq.Paused = false
+	close(seq.resumeChan)
+	seq.resumeChan = nil
+	seq.pauseChan = make(chan struct{})
+	return nil
+}
+
+// waitIfPaused blocks while the sequence is paused. It returns false if the
+// sequence or scheduler was stopped while waiting.
+func (s *Scheduler) waitIfPaused(seq *Sequence) bool {
+	s.mu.RLock()
+	resume := seq.resumeChan
+	s.mu.RUnlock()
+	
+	if resume == nil {
+		return true
+	}
+	
+	select {
+	case <-resume:
+		return true
+	case <-seq.stopChan:
+		return false
+	case <-s.ctx.Done():
+		return false
+	}
+}
+
+// waitDelay waits out a command's delay. A pause stops the clock, and the rest of the
+// delay runs once the sequence is resumed. It returns false if the sequence or scheduler
+// was stopped while waiting.
+func (s *Scheduler) waitDelay(seq *Sequence, delay time.Duration) bool {
+	remaining := delay
+	for remaining > 0 {
+		s.mu.RLock()
+		pause := seq.pauseChan
+		s.mu.RUnlock()
+		
+		started := time.Now()
+		timer := time.NewTimer(remaining)
+		select {
+		case <-timer.C:
+			return true
+		case <-pause:
+			timer.Stop()
+			remaining -= time.Since(started)
+			if !s.waitIfPaused(seq) {
+				return false
+			}
+		case <-seq.stopChan:
+			timer.Stop()
+			return false
+		case <-s.ctx.Done():
+			timer.Stop()
+			return false
+		}
+	}
+	return true
+}
+
+// StopAll stops every running sequence and returns how many were stopped
+func (s *Scheduler) StopAll() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	stopped := 0
+	for _, seq := range s.sequences {
+		if seq.Running && seq.stopChan != nil {
+			close(seq.stopChan)
+			seq.Running = false
+			stopped++
+		}
+	}
+	
+	return stopped
+}
+
 // GetSequences returns all sequences
 func (s *Scheduler) GetSequences() map[string]*Sequence {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	
-	// Return a copy to avoid concurrent modification
+	// Return copies to avoid concurrent modification while progress is updated
 	result := make(map[string]*Sequence)
 	for k, v := range s.sequences {
-		result[k] = v
+		seq := *v
+		result[k] = &seq
 	}
 	return result
 }
 
+// forgetFinishedLocked drops a finished transient sequence, and otherwise prunes the oldest
+// finished sequences beyond maxFinishedSequences. The caller must hold s.mu.
+func (s *Scheduler) forgetFinishedLocked(seq *Sequence) {
+	if s.sequences[seq.ID] != seq {
+		return // Replaced by a newer run with the same ID
+	}
+	if seq.transient {
+		delete(s.sequences, seq.ID)
+		return
+	}
+	
+	kept := s.finished[:0]
+	for _, id := range s.finished {
+		if id != seq.ID {
+			kept = append(kept, id)
+		}
+	}
+	s.finished = append(kept, seq.ID)
+	
+	for len(s.finished) > maxFinishedSequences {
+		oldest := s.finished[0]
+		s.finished = s.finished[1:]
+		if old, ok := s.sequences[oldest]; ok && !old.Running {
+			delete(s.sequences, oldest)
+		}
+	}
+}
+
 // runSequence executes a sequence of commands
 func (s *Scheduler) runSequence(seq *Sequence) {
 	defer func() {
 		s.mu.Lock()
 		seq.Running = false
+		seq.Paused = false
+		s.forgetFinishedLocked(seq)
 		s.mu.Unlock()
+		close(seq.done)
 	}()
 	
 	for {
-		for _, cmd := range seq.Commands {
+		for i, cmd := range seq.Commands {
 			// Check if we should stop
 			select {
 			case <-seq.stopChan:
@@ -157,19 +415,27 @@ func (s *Scheduler) runSequence(seq *Sequence) {
 			default:
 			}
 			
-			// Apply delay if specified
-			if cmd.Delay > 0 {
-				select {
-				case <-time.After(cmd.Delay):
-				case <-seq.stopChan:
-					return
-				case <-s.ctx.Done():
-					return
-				}
+			if !s.waitIfPaused(seq) {
+				return
+			}
+			
+			s.mu.Lock()
+			seq.CurrentCommand = i
+			seq.commandStarted = time.Now()
+			s.mu.Unlock()
+			
+			// Apply delay if specified; a pause during the delay holds it until resumed
+			if cmd.Delay > 0 && !s.waitDelay(seq, cmd.Delay) {
+				return
 			}
 			
-			// Execute the command
-			ctx, cancel := context.WithTimeout(s.ctx, 5*time.Second)
+			// A pause requested just as the delay ended still holds the command
+			if !s.waitIfPaused(seq) {
+				return
+			}
+			
+			// Execute the command; sequences set their own pace, so they skip the client's rate limits
+			ctx, cancel := context.WithTimeout(client.WithoutRateLimit(s.ctx), 5*time.Second)
 			s.executeCommandSync(ctx, cmd)
 			cancel()
 		}
@@ -212,6 +478,17 @@ func (s *Scheduler) executeLightCommand(ctx context.Context, cmd Command) error
 			return s.client.SetLightColor(ctx, cmd.Target, color)
 		}
 		return fmt.Errorf("color parameter required")
+	case "color_temp":
+		mirek, err := commandMirek(cmd.Params)
+		if err != nil {
+			return err
+		}
+		return s.client.SetLightColorTemperature(ctx, cmd.Target, mirek)
+	case "effect":
+		if effect, ok := cmd.Params["effect"].(string); ok {
+			return s.client.SetLightEffect(ctx, cmd.Target, effect, 0)
+		}
+		return fmt.Errorf("effect parameter required")
 	default:
 		return fmt.Errorf("unknown light action: %s", cmd.Action)
 	}
@@ -234,11 +511,33 @@ func (s *Scheduler) executeGroupCommand(ctx context.Context, cmd Command) error
 			return s.client.SetGroupColor(ctx, cmd.Target, color)
 		}
 		return fmt.Errorf("color parameter required")
+	case "color_temp":
+		mirek, err := commandMirek(cmd.Params)
+		if err != nil {
+			return err
+		}
+		return s.client.SetGroupColorTemperature(ctx, cmd.Target, mirek)
+	case "effect":
+		if effect, ok := cmd.Params["effect"].(string); ok {
+			return s.client.SetGroupEffect(ctx, cmd.Target, effect, 0)
+		}
+		return fmt.Errorf("effect parameter required")
 	default:
 		return fmt.Errorf("unknown group action: %s", cmd.Action)
 	}
 }
 
+// commandMirek reads a color_temp command's temperature from its mirek or kelvin param
+func commandMirek(params map[string]interface{}) (int, error) {
+	if mirek, ok := params["mirek"].(float64); ok {
+		return int(mirek), nil
+	}
+	if kelvin, ok := params["kelvin"].(float64); ok {
+		return client.KelvinToMirek(int(kelvin)), nil
+	}
+	return 0, fmt.Errorf("mirek or kelvin parameter required")
+}
+
 // executeSceneCommand executes a scene command
 func (s *Scheduler) executeSceneCommand(ctx context.Context, cmd Command) error {
 	if cmd.Action == "recall" || cmd.Action == "activate" {
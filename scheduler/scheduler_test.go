@@ -0,0 +1,255 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kungfusheep/hue/client"
+	"github.com/kungfusheep/hue/internal/fakebridge"
+)
+
+func TestStopAllStopsRunningSequences(t *testing.T) {
+	s := newTestScheduler(t)
+	
+	if stopped := s.StopAll(); stopped != 0 {
+		t.Fatalf("Expected nothing to stop on an idle scheduler, got %d", stopped)
+	}
+	
+	var dones []<-chan struct{}
+	for _, id := range []string{"seq-a", "seq-b"} {
+		seq := &Sequence{ID: id, Loop: true, Commands: []Command{{Type: "light", Action: "on", Target: "light-1", Delay: time.Hour}}}
+		if _, err := s.ExecuteSequence(seq); err != nil {
+			t.Fatalf("ExecuteSequence failed: %v", err)
+		}
+		dones = append(dones, s.sequenceDone(id))
+	}
+	
+	if stopped := s.StopAll(); stopped != 2 {
+		t.Fatalf("Expected 2 sequences stopped, got %d", stopped)
+	}
+	
+	for i, done := range dones {
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Sequence %d did not finish after StopAll", i)
+		}
+	}
+	
+	if stopped := s.StopAll(); stopped != 0 {
+		t.Errorf("Expected a second StopAll to find nothing running, got %d", stopped)
+	}
+}
+
+func TestSequenceProgress(t *testing.T) {
+	now := time.Now()
+	seq := &Sequence{
+		Commands: []Command{
+			{Delay: 0},
+			{Delay: 10 * time.Second},
+			{Delay: 5 * time.Second},
+			{Delay: 2 * time.Second},
+		},
+		CurrentCommand: 1,
+		commandStarted: now.Add(-4 * time.Second),
+	}
+	
+	progress := seq.Progress(now)
+	if progress.Command != 2 || progress.Total != 4 {
+		t.Errorf("Expected command 2/4, got %d/%d", progress.Command, progress.Total)
+	}
+	// 6s left on the current delay plus 5s and 2s still to come
+	if progress.Remaining != 13*time.Second {
+		t.Errorf("Expected 13s remaining, got %s", progress.Remaining)
+	}
+	if got := progress.String(); got != "command 2/4, ~13s remaining" {
+		t.Errorf("Unexpected progress text %q", got)
+	}
+	
+	// An overdue delay never reports negative time
+	seq.commandStarted = now.Add(-time.Minute)
+	if remaining := seq.Progress(now).Remaining; remaining != 7*time.Second {
+		t.Errorf("Expected only the later delays to remain, got %s", remaining)
+	}
+	
+	seq.Loop = true
+	if got := seq.Progress(now).String(); got != "command 2/4, ∞ remaining (loops)" {
+		t.Errorf("Expected looping sequences to report infinite time, got %q", got)
+	}
+}
+
+func TestGetSequencesTracksCurrentCommand(t *testing.T) {
+	s := newTestScheduler(t)
+	
+	seq := &Sequence{ID: "seq-progress", Commands: []Command{
+		{Type: "light", Action: "on", Target: "light-1"},
+		{Type: "light", Action: "off", Target: "light-1", Delay: time.Hour},
+	}}
+	if _, err := s.ExecuteSequence(seq); err != nil {
+		t.Fatalf("ExecuteSequence failed: %v", err)
+	}
+	
+	deadline := time.Now().Add(2 * time.Second)
+	var progress SequenceProgress
+	for time.Now().Before(deadline) {
+		progress = s.GetSequences()["seq-progress"].Progress(time.Now())
+		if progress.Command == 2 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	
+	if progress.Command != 2 || progress.Remaining < 59*time.Minute {
+		t.Errorf("Expected to be waiting on the hour-long second command, got %+v", progress)
+	}
+	if s.GetSequences()["seq-progress"].StartedAt.IsZero() {
+		t.Error("Expected the start time to be recorded")
+	}
+}
+
+func TestPauseAndResumeSequence(t *testing.T) {
+	s := newTestScheduler(t)
+	
+	if err := s.PauseSequence("missing"); err == nil {
+		t.Error("Expected pausing an unknown sequence to fail")
+	}
+	
+	var commands []Command
+	for i := 0; i < 5; i++ {
+		commands = append(commands, Command{Type: "light", Action: "on", Target: "light-1", Delay: 20 * time.Millisecond})
+	}
+	seq := &Sequence{ID: "seq-loop", Loop: true, Commands: commands}
+	if _, err := s.ExecuteSequence(seq); err != nil {
+		t.Fatalf("ExecuteSequence failed: %v", err)
+	}
+	
+	time.Sleep(50 * time.Millisecond)
+	if err := s.PauseSequence("seq-loop"); err != nil {
+		t.Fatalf("PauseSequence failed: %v", err)
+	}
+	
+	// Let any in-flight delay finish, then the position must hold
+	time.Sleep(50 * time.Millisecond)
+	paused := s.GetSequences()["seq-loop"]
+	if !paused.Paused {
+		t.Fatal("Expected the sequence to report paused")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if held := s.GetSequences()["seq-loop"].CurrentCommand; held != paused.CurrentCommand {
+		t.Fatalf("Expected paused sequence to stay on command %d, moved to %d", paused.CurrentCommand, held)
+	}
+	
+	if err := s.ResumeSequence("seq-loop"); err != nil {
+		t.Fatalf("ResumeSequence failed: %v", err)
+	}
+	if err := s.ResumeSequence("seq-loop"); err == nil {
+		t.Error("Expected resuming a running sequence to fail")
+	}
+	
+	// Resuming continues with the next command rather than restarting
+	want := (paused.CurrentCommand + 1) % len(commands)
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && s.GetSequences()["seq-loop"].CurrentCommand == paused.CurrentCommand {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := s.GetSequences()["seq-loop"].CurrentCommand; got != want {
+		t.Errorf("Expected to resume at command %d, got %d", want, got)
+	}
+	
+	// Stopping a paused sequence still ends it
+	s.PauseSequence("seq-loop")
+	s.StopSequence("seq-loop")
+	select {
+	case <-s.sequenceDone("seq-loop"):
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a paused sequence to stop")
+	}
+}
+
+func TestPauseHoldsPendingDelay(t *testing.T) {
+	s := newTestScheduler(t)
+	
+	seq := &Sequence{ID: "seq-delay", Commands: []Command{
+		{Type: "light", Action: "on", Target: "light-1", Delay: 200 * time.Millisecond},
+	}}
+	if _, err := s.ExecuteSequence(seq); err != nil {
+		t.Fatalf("ExecuteSequence failed: %v", err)
+	}
+	
+	time.Sleep(100 * time.Millisecond)
+	if err := s.PauseSequence("seq-delay"); err != nil {
+		t.Fatalf("PauseSequence failed: %v", err)
+	}
+	
+	// The delay would have ended during the pause if its clock kept running
+	time.Sleep(250 * time.Millisecond)
+	remaining := s.GetSequences()["seq-delay"].Progress(time.Now()).Remaining
+	if remaining < 50*time.Millisecond || remaining > 150*time.Millisecond {
+		t.Errorf("Expected about 100ms of the delay left while paused, got %v", remaining)
+	}
+	
+	resumed := time.Now()
+	if err := s.ResumeSequence("seq-delay"); err != nil {
+		t.Fatalf("ResumeSequence failed: %v", err)
+	}
+	select {
+	case <-s.sequenceDone("seq-delay"):
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the sequence to finish after resuming")
+	}
+	if waited := time.Since(resumed); waited < 50*time.Millisecond {
+		t.Errorf("Expected the rest of the delay to run after resuming, finished after %v", waited)
+	}
+}
+
+func TestColorTempCommandDispatch(t *testing.T) {
+	fb := fakebridge.New(t)
+	s := NewScheduler(client.NewClient(fb.Host(), "test-key", fb.HTTPClient()))
+	t.Cleanup(s.Stop)
+	
+	commands := []Command{
+		{Type: "light", Action: "color_temp", Target: "light-1", Params: map[string]interface{}{"mirek": 366.0}},
+		{Type: "group", Action: "color_temp", Target: "group-1", Params: map[string]interface{}{"kelvin": 4000.0}},
+	}
+	for _, cmd := range commands {
+		if err := s.executeCommandSync(context.Background(), cmd); err != nil {
+			t.Fatalf("%s color_temp failed: %v", cmd.Type, err)
+		}
+	}
+	
+	for path, mirek := range map[string]string{
+		"/clip/v2/resource/light/light-1":         "366",
+		"/clip/v2/resource/grouped_light/group-1": "250",
+	} {
+		puts := fb.RequestsFor("PUT", path)
+		if len(puts) != 1 || fmt.Sprint(puts[0].Body["color_temperature"]) != "map[mirek:"+mirek+"]" {
+			t.Errorf("Expected %s to be set to %s mirek, got %+v", path, mirek, puts)
+		}
+	}
+	
+	err := s.executeCommandSync(context.Background(), Command{Type: "light", Action: "color_temp", Target: "light-1"})
+	if err == nil {
+		t.Error("Expected color_temp without mirek or kelvin to fail")
+	}
+}
+
+func TestEffectStopClearsEffect(t *testing.T) {
+	fb := fakebridge.New(t)
+	s := NewScheduler(client.NewClient(fb.Host(), "test-key", fb.HTTPClient()))
+	t.Cleanup(s.Stop)
+	
+	for _, cmd := range CreateEffectStop("light", "light-1", "light-2").Commands {
+		if err := s.executeCommandSync(context.Background(), cmd); err != nil {
+			t.Fatalf("effect stop failed: %v", err)
+		}
+	}
+	
+	for _, id := range []string{"light-1", "light-2"} {
+		puts := fb.RequestsFor("PUT", "/clip/v2/resource/light/"+id)
+		if len(puts) != 1 || fmt.Sprint(puts[0].Body["effects"]) != "map[effect:no_effect]" {
+			t.Errorf("Expected %s's effect to be cleared, got %+v", id, puts)
+		}
+	}
+}